@@ -72,9 +72,27 @@ func main() {
 
 	services := service.NewServices(repos, cfg)
 
+	// Фоновые задачи останавливаются вместе с сервером
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	go services.Reminder.Run(bgCtx, cfg.Reminder.Interval)
+	go services.Webhooks.Run(bgCtx, 5*time.Second)
+	go services.Relay.Run(bgCtx, cfg.Events.RelayInterval)
+	go services.Hub.Run(bgCtx)
+	go services.Presence.Run(bgCtx, 15*time.Second)
+	go services.Ranks.Run(bgCtx, 10*time.Minute)
+	if services.PostgresBroker != nil {
+		go services.PostgresBroker.Run(bgCtx)
+	}
+	if services.Emails != nil {
+		go services.Emails.Run(bgCtx, cfg.Email.PollInterval)
+		go services.Digests.Run(bgCtx, 15*time.Minute)
+	}
+
 	authMiddleware := middleware.NewAuthMiddleware(services.Auth)
 
-	handler := handlers.NewHandler(services, repos)
+	handler := handlers.NewHandler(services, cfg)
 
 	router := gin.Default()
 
@@ -89,7 +107,7 @@ func main() {
 	// Добавляем маршрут для Swagger UI
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	handler.InitRoutes(router, authMiddleware.AuthRequired())
+	handler.InitRoutes(router, authMiddleware.AuthRequired(), authMiddleware.OptionalAuth())
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", cfg.HTTP.Port),
@@ -108,6 +126,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Info("Shutting down server...")
+	stopBackground()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
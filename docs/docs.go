@@ -15,117 +15,112 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/boards/{board_id}/labels": {
+        "/api/board-templates": {
             "get": {
-                "description": "Get all labels for a specific board",
+                "description": "Возвращает встроенные шаблоны (scrum, kanban, bug-triage) и шаблоны текущего пользователя",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "labels"
-                ],
-                "summary": "Get labels by board ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Board ID",
-                        "name": "board_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "board-templates"
                 ],
+                "summary": "Получить галерею шаблонов",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Шаблоны",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.LabelResponse"
+                                "$ref": "#/definitions/models.BoardTemplate"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                        "description": "Неавторизованный запрос",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/cards": {
-            "post": {
-                "description": "Create a new card in a column",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/board-templates/{template_id}": {
+            "delete": {
+                "description": "Удаляет сохраненный шаблон текущего пользователя. Встроенные шаблоны удалить нельзя",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "board-templates"
                 ],
-                "summary": "Create a new card",
+                "summary": "Удалить шаблон",
                 "parameters": [
                     {
-                        "description": "Card data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Card"
-                        }
+                        "type": "string",
+                        "description": "ID шаблона",
+                        "name": "template_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Встроенный шаблон",
                         "schema": {
-                            "$ref": "#/definitions/models.Card"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Неавторизованный запрос",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Шаблон не найден",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/cards/positions": {
-            "put": {
-                "description": "Update the positions of multiple cards within a column",
+        "/api/board-templates/{template_id}/boards": {
+            "post": {
+                "description": "Создает доску текущего пользователя с колонками, дорожками и метками шаблона.\ntemplate_id — ID сохраненного шаблона или ключ встроенного",
                 "consumes": [
                     "application/json"
                 ],
@@ -133,134 +128,220 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "board-templates"
                 ],
-                "summary": "Update card positions",
+                "summary": "Создать доску из шаблона",
                 "parameters": [
                     {
-                        "description": "Cards with new positions",
+                        "type": "string",
+                        "description": "ID или ключ шаблона",
+                        "name": "template_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Параметры доски",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Card"
-                            }
+                            "$ref": "#/definitions/handlers.CreateBoardFromTemplateInput"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Доска создана",
+                        "schema": {
+                            "$ref": "#/definitions/models.Board"
+                        }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные входные данные",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Шаблон или рабочее пространство не найдены",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/cards/{card_id}/comments": {
+        "/api/boards/recent": {
             "get": {
-                "description": "Get all comments for a specific card",
+                "description": "Возвращает доски, которые пользователь недавно открывал, начиная с последней. Архивные доски не включаются",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "board"
                 ],
-                "summary": "Get comments by card ID",
+                "summary": "Получить недавние доски",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "card_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Количество (по умолчанию и максимум 20)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Недавние доски",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Comment"
+                                "$ref": "#/definitions/models.Board"
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Неверные параметры запроса",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/cards/{id}": {
-            "get": {
-                "description": "Get a card by its ID",
+        "/api/boards/{board_id}/archive": {
+            "post": {
+                "description": "Переносит доску в архив. Архивная доска скрыта из списков по умолчанию, ее содержимое доступно только для чтения",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "board"
                 ],
-                "summary": "Get a card by ID",
+                "summary": "Архивировать доску",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag доски",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Доска в архиве",
                         "schema": {
-                            "$ref": "#/definitions/models.Card"
+                            "$ref": "#/definitions/models.Board"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия доски"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав администратора доски",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска не найдена",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Доска изменена другим запросом",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update a card by its ID",
+            }
+        },
+        "/api/boards/{board_id}/clone": {
+            "post": {
+                "description": "Создает новую доску текущего пользователя по образцу существующей в одной транзакции.\nКарточки копируются только вместе с колонками, исполнитель карточки сохраняется, если у него есть доступ к копии.\nБез workspace_id копия создается в рабочем пространстве исходной доски",
                 "consumes": [
                     "application/json"
                 ],
@@ -268,276 +349,356 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "board-templates"
                 ],
-                "summary": "Update a card",
+                "summary": "Скопировать доску",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
+                        "description": "ID исходной доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated card data",
+                        "description": "Параметры копирования",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Card"
+                            "$ref": "#/definitions/handlers.CloneBoardInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Копия создана",
                         "schema": {
-                            "$ref": "#/definitions/models.Card"
+                            "$ref": "#/definitions/models.Board"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные входные данные",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Нет доступа к доске",
                         "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete a card by its ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "cards"
-                ],
-                "summary": "Delete a card",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска или рабочее пространство не найдены",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/cards/{id}/assign": {
-            "post": {
-                "description": "Assign a card to a user by user ID",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/boards/{board_id}/events": {
+            "get": {
+                "description": "Server-Sent Events с теми же событиями, что и WebSocket. Поле id события — его порядковый номер seq,\nимя события — тип (например, card.moved). При переподключении заголовок Last-Event-ID (или параметр last_event_id)\nпозволяет получить пропущенные события. Если их уже нет в журнале, приходит событие reset: доску нужно загрузить заново.\nКлиент, не успевающий читать события, отключается и может переподключиться с Last-Event-ID.\nПоток отмечает пользователя на доске; события присутствия приходят без id и не восстанавливаются из журнала.\nРедактируемую карточку задают запросом PUT /api/boards/{board_id}/presence с session_id потока.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "cards"
+                    "realtime"
                 ],
-                "summary": "Assign a card to a user",
+                "summary": "Поток событий доски (SSE)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User ID",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AssignCardInput"
-                        }
+                        "type": "string",
+                        "description": "ID сессии присутствия; по умолчанию создается новый",
+                        "name": "session_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер последнего полученного события",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер последнего полученного события",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT-токен",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "Поток событий",
+                        "schema": {
+                            "$ref": "#/definitions/service.BoardEvent"
+                        }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверный запрос",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к доске",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска не найдена",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/cards/{id}/due-date": {
-            "put": {
-                "description": "Set or update the due date for a card",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/boards/{board_id}/full": {
+            "get": {
+                "description": "Возвращает доску с колонками, дорожками и карточками, а у карточек — метки, исполнителя и число комментариев.\nГость доски получает только открытые ему карточки.\nПараметр fields ограничивает разделы ответа: swimlanes, cards, labels, assignees, comment_counts; доска и колонки возвращаются всегда.\nETag меняется при любом изменении содержимого, поэтому повторный запрос с If-None-Match обходится одним запросом к базе",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "board"
                 ],
-                "summary": "Update card due date",
+                "summary": "Получить доску целиком",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Due date (null to remove)",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateDueDateInput"
-                        }
+                        "type": "string",
+                        "description": "Разделы через запятую (по умолчанию все)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag полученного ранее снимка",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "Снимок доски",
+                        "schema": {
+                            "$ref": "#/definitions/service.BoardSnapshot"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия снимка"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Доска не изменилась"
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверный формат ID или неизвестный раздел",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к доске",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска не найдена",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/cards/{id}/labels": {
+        "/api/boards/{board_id}/guests": {
             "get": {
-                "description": "Get all labels associated with a card",
+                "description": "Возвращает гостей доски и для каждого — карточки, которые он видит. Доступно владельцу и администраторам доски",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards",
-                    "labels"
+                    "members"
                 ],
-                "summary": "Get all labels for a card",
+                "summary": "Получить гостей доски",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Гости и их карточки",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Label"
+                                "$ref": "#/definitions/service.GuestAccess"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на управление доской",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска не найдена",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Add a label to a card",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/boards/{board_id}/guests/{user_id}/cards/{card_id}": {
+            "put": {
+                "description": "Делает карточку доски видимой гостю. Повторный вызов ничего не меняет. Доступно владельцу и администраторам доски",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards",
-                    "labels"
+                    "members"
                 ],
-                "summary": "Add a label to a card",
+                "summary": "Открыть карточку гостю",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Label ID",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AddLabelInput"
-                        }
+                        "type": "integer",
+                        "description": "ID гостя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID карточки",
+                        "name": "card_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -545,40 +706,71 @@ const docTemplate = `{
                         "description": "No Content"
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверный формат ID или пользователь не гость",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Нет прав на управление доской",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Участник или карточка не найдены",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Remove all labels associated with a card",
+                "description": "Убирает карточку из видимых гостю. Доступно владельцу и администраторам доски",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards",
-                    "labels"
+                    "members"
                 ],
-                "summary": "Remove all labels from a card",
+                "summary": "Закрыть карточку от гостя",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID гостя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID карточки",
+                        "name": "card_id",
                         "in": "path",
                         "required": true
                     }
@@ -587,230 +779,321 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Неверный формат ID или пользователь не гость",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на управление доской",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Участник не найден",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/cards/{id}/labels/batch": {
-            "post": {
-                "description": "Add multiple labels to a card in a single request",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/boards/{board_id}/labels": {
+            "get": {
+                "description": "Get all labels for a specific board, including labels shared with its workspace",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards",
                     "labels"
                 ],
-                "summary": "Add multiple labels to a card",
+                "summary": "Get labels by board ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
+                        "description": "Board ID",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Label IDs",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.BatchAddLabelsInput"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LabelResponse"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/cards/{id}/labels/{label_id}": {
-            "delete": {
-                "description": "Remove a label from a card",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/boards/{board_id}/lanes": {
+            "get": {
+                "description": "Возвращает колонки доски и ее дорожки, в каждой дорожке — ячейки всех колонок с карточками.\nКарточки без дорожки лежат в дорожке по умолчанию (swimlane равен null), она идет последней",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards",
-                    "labels"
+                    "swimlanes"
                 ],
-                "summary": "Remove a label from a card",
+                "summary": "Получить карточки доски по дорожкам",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Label ID",
-                        "name": "label_id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "Карточки по дорожкам и колонкам",
+                        "schema": {
+                            "$ref": "#/definitions/service.BoardLanes"
+                        }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к доске",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска не найдена",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/cards/{id}/move": {
-            "post": {
-                "description": "Move a card to another column with a specific position",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/boards/{board_id}/members": {
+            "get": {
+                "description": "Возвращает участников доски (без владельца)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "members"
                 ],
-                "summary": "Move a card to another column",
+                "summary": "Получить участников доски",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "New column and position",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.MoveCardInput"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "Список участников",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BoardMember"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к доске",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска не найдена",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/api/cards/{id}/unassign": {
+            },
             "post": {
-                "description": "Remove user assignment from a card",
+                "description": "Добавляет пользователя на доску. Доступно владельцу и администраторам доски.\nРоль guest дает доступ только к карточкам, открытым гостю через /guests/{user_id}/cards/{card_id}",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "members"
                 ],
-                "summary": "Unassign a card",
+                "summary": "Добавить участника доски",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Card ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Пользователь и роль",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddMemberInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "201": {
+                        "description": "Участник добавлен",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.BoardMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на управление доской",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Доска или пользователь не найдены",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь уже участник доски",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/columns/positions": {
+        "/api/boards/{board_id}/members/{user_id}": {
             "put": {
-                "description": "Обновляет позиции нескольких колонок в рамках одной доски.",
+                "description": "Изменяет роль участника доски. Доступно владельцу и администраторам доски",
                 "consumes": [
                     "application/json"
                 ],
@@ -818,26 +1101,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "columns"
+                    "members"
                 ],
-                "summary": "Обновить позиции колонок",
+                "summary": "Изменить роль участника",
                 "parameters": [
                     {
-                        "description": "Список колонок с новыми позициями",
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая роль",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Column"
-                            }
+                            "$ref": "#/definitions/handlers.UpdateMemberRoleInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Positions updated successfully",
+                        "description": "Роль обновлена",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -846,7 +1140,16 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Неверные входные данные или отсутствуют колонки",
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на управление доской",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -855,7 +1158,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Доска не найдена",
+                        "description": "Участник не найден",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -873,146 +1176,144 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/columns/{column_id}/cards": {
-            "get": {
-                "description": "Get all cards in a column",
+            },
+            "delete": {
+                "description": "Удаляет пользователя из участников доски. Доступно владельцу и администраторам доски",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cards"
+                    "members"
                 ],
-                "summary": "Get cards by column ID",
+                "summary": "Удалить участника доски",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Column ID",
-                        "name": "column_id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Card"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "string"
-                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/comments": {
-            "post": {
-                "description": "Create a new comment for a card",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "comments"
-                ],
-                "summary": "Create a new comment",
-                "parameters": [
                     {
-                        "description": "Comment data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Comment"
-                        }
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/models.Comment"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Нет прав на управление доской",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Участник не найден",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/comments/{comment_id}": {
+        "/api/boards/{board_id}/presence": {
             "get": {
-                "description": "Get a comment by its ID",
+                "description": "Возвращает активные сессии доски: пользователя и карточку, которую он редактирует.\nИзменения приходят в realtime-каналы событиями presence.joined, presence.updated и presence.left.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "presence"
                 ],
-                "summary": "Get comment by ID",
+                "summary": "Кто сейчас на доске",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Comment ID",
-                        "name": "comment_id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Активные сессии",
                         "schema": {
-                            "$ref": "#/definitions/models.Comment"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BoardPresence"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к доске",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска не найдена",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             },
             "put": {
-                "description": "Update a comment's content",
+                "description": "Отмечает сессию на доске и задает карточку, которую пользователь редактирует.\nСессия истекает через 60 секунд без heartbeat. Клиенты WebSocket и SSE отмечаются автоматически,\nа для SSE редактируемую карточку задают этим запросом с тем же session_id, что и у потока.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1020,69 +1321,91 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "presence"
                 ],
-                "summary": "Update a comment",
+                "summary": "Heartbeat присутствия",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Comment ID",
-                        "name": "comment_id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated comment data",
+                        "description": "Сессия и редактируемая карточка",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Comment"
+                            "$ref": "#/definitions/handlers.PresenceInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Сессия отмечена",
                         "schema": {
-                            "$ref": "#/definitions/models.Comment"
+                            "$ref": "#/definitions/models.BoardPresence"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные входные данные",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к доске",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска или карточка не найдена",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Delete a comment by ID",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Снимает отметку сессии, например при закрытии вкладки.",
                 "tags": [
-                    "comments"
+                    "presence"
                 ],
-                "summary": "Delete a comment",
+                "summary": "Покинуть доску",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Comment ID",
-                        "name": "comment_id",
-                        "in": "path",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID сессии",
+                        "name": "session_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -1091,466 +1414,542 @@ const docTemplate = `{
                         "description": "No Content"
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные входные данные",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Нет доступа к доске",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска не найдена",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/labels": {
-            "post": {
-                "description": "Create a new label for a board",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/boards/{board_id}/purge": {
+            "delete": {
+                "description": "Безвозвратно удаляет доску (в том числе ранее удаленную) со всеми колонками, дорожками, карточками, комментариями, метками, участниками, вебхуками и уведомлениями. Доступно владельцу и администраторам доски",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "labels"
+                    "board"
                 ],
-                "summary": "Create a new label",
+                "summary": "Удалить доску безвозвратно",
                 "parameters": [
                     {
-                        "description": "Label information",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LabelRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "204": {
+                        "description": "Доска удалена"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LabelResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Нет прав администратора доски",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Доска не найдена",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/labels/{label_id}": {
-            "get": {
-                "description": "Get label details by its ID",
+        "/api/boards/{board_id}/restore": {
+            "post": {
+                "description": "Восстанавливает доску вместе с колонками, дорожками, карточками и комментариями, удаленными вместе с ней. Доступно владельцу и администраторам доски",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "labels"
+                    "board"
                 ],
-                "summary": "Get label by ID",
+                "summary": "Восстановить удаленную доску",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Label ID",
-                        "name": "label_id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Восстановленная доска",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LabelResponse"
+                            "$ref": "#/definitions/models.Board"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия доски"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Нет прав администратора доски",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска не найдена",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update label details",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/boards/{board_id}/share-links": {
+            "get": {
+                "description": "Возвращает все ссылки доски, включая отозванные и истекшие. Доступно владельцу и администраторам доски",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "labels"
+                    "share-links"
                 ],
-                "summary": "Update label",
+                "summary": "Получить публичные ссылки доски",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Label ID",
-                        "name": "label_id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Label information",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LabelRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Список ссылок",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LabelResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BoardShareLink"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Нет прав на управление доской",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска не найдена",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Delete a label by its ID",
+            "post": {
+                "description": "Выпускает ссылку на просмотр доски без учетной записи. Ссылку можно ограничить сроком действия и защитить паролем.\nКомментарии и адреса почты по ссылке скрыты, если их не разрешить явно",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "labels"
+                    "share-links"
                 ],
-                "summary": "Delete label",
+                "summary": "Создать публичную ссылку",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Label ID",
-                        "name": "label_id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Параметры ссылки",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ShareLinkInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Ссылка создана",
+                        "schema": {
+                            "$ref": "#/definitions/models.BoardShareLink"
+                        }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные входные данные",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Нет прав на управление доской",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Доска не найдена",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Login with email and password",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/boards/{board_id}/share-links/{link_id}": {
+            "delete": {
+                "description": "После отзыва снимок доски по ссылке недоступен. Отозванная ссылка остается в списке",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "share-links"
                 ],
-                "summary": "Login a user",
+                "summary": "Отозвать публичную ссылку",
                 "parameters": [
                     {
-                        "description": "User login credentials",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.loginRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID ссылки",
+                        "name": "link_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Ссылка отозвана",
                         "schema": {
-                            "$ref": "#/definitions/handlers.authResponse"
+                            "$ref": "#/definitions/models.BoardShareLink"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.errorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/handlers.errorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.errorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/me": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get information about the currently authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Get current user info",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "403": {
+                        "description": "Нет прав на управление доской",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Ссылка не найдена",
                         "schema": {
-                            "$ref": "#/definitions/handlers.errorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.errorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/auth/refresh": {
+        "/api/boards/{board_id}/star": {
             "post": {
-                "description": "Refresh the authentication token using the current valid token",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "board"
                 ],
-                "summary": "Refresh authentication token",
+                "summary": "Отметить доску звездочкой",
                 "parameters": [
                     {
-                        "description": "Refresh token request",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.refreshTokenRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/handlers.authResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Нет доступа к доске",
                         "schema": {
-                            "$ref": "#/definitions/handlers.errorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Доска не найдена",
                         "schema": {
-                            "$ref": "#/definitions/handlers.errorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.errorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user with email, password and name",
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "board"
                 ],
-                "summary": "Register a new user",
+                "summary": "Снять звездочку с доски",
                 "parameters": [
                     {
-                        "description": "User registration info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.registerRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/handlers.authResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Нет доступа к доске",
                         "schema": {
-                            "$ref": "#/definitions/handlers.errorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Доска не найдена",
                         "schema": {
-                            "$ref": "#/definitions/handlers.errorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.errorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/boards": {
+        "/api/boards/{board_id}/swimlanes": {
             "get": {
-                "description": "Возвращает все доски, принадлежащие авторизованному пользователю",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "board"
+                    "swimlanes"
+                ],
+                "summary": "Получить дорожки доски",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получить доски пользователя",
                 "responses": {
                     "200": {
-                        "description": "Список досок",
+                        "description": "Дорожки в порядке отображения",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Board"
+                                "$ref": "#/definitions/models.Swimlane"
                             }
                         }
                     },
                     "400": {
-                        "description": "Пользователь не найден",
+                        "description": "Неверный формат ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1558,8 +1957,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Неавторизованный запрос",
+                    "403": {
+                        "description": "Нет доступа к доске",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Доска не найдена",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1579,7 +1987,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Создает новую доску для авторизованного пользователя",
+                "description": "Добавляет дорожку в конец доски",
                 "consumes": [
                     "application/json"
                 ],
@@ -1587,29 +1995,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "board"
+                    "swimlanes"
                 ],
-                "summary": "Создать доску",
+                "summary": "Создать дорожку",
                 "parameters": [
                     {
-                        "description": "Данные доски",
-                        "name": "board",
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Параметры дорожки",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Board"
+                            "$ref": "#/definitions/handlers.SwimlaneInput"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Доска успешно создана",
+                        "description": "Дорожка создана",
                         "schema": {
-                            "$ref": "#/definitions/models.Board"
+                            "$ref": "#/definitions/models.Swimlane"
                         }
                     },
                     "400": {
-                        "description": "Ошибка запроса или пользователь не найден",
+                        "description": "Неверные входные данные",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1617,8 +2032,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Неавторизованный запрос",
+                    "403": {
+                        "description": "Нет доступа к доске",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Доска не найдена",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1638,16 +2062,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/boards/{board_id}": {
-            "get": {
-                "description": "Возвращает доску по указанному ID",
-                "produces": [
-                    "application/json"
+        "/api/boards/{board_id}/swimlanes/order": {
+            "put": {
+                "description": "Атомарно меняет порядок дорожек. Список должен содержать ровно все дорожки доски, иначе возвращается 409 с текущим порядком.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "board"
+                    "swimlanes"
                 ],
-                "summary": "Получить доску",
+                "summary": "Изменить порядок дорожек доски",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1655,17 +2082,38 @@ const docTemplate = `{
                         "name": "board_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "ID всех дорожек доски в новом порядке",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReorderSwimlanesInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Доска успешно найдена",
+                        "description": "Дорожки в новом порядке",
                         "schema": {
-                            "$ref": "#/definitions/models.Board"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Swimlane"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверный формат ID",
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к доске",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1682,6 +2130,12 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "409": {
+                        "description": "Состав дорожек доски изменился",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderConflictError"
+                        }
+                    },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
@@ -1692,9 +2146,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/boards/{board_id}/swimlanes/{swimlane_id}": {
             "put": {
-                "description": "Обновляет данные доски по ID, если пользователь является её владельцем",
+                "description": "Переименовывает дорожку. С заголовком If-Match изменение применяется, только если версия дорожки совпадает",
                 "consumes": [
                     "application/json"
                 ],
@@ -1702,9 +2158,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "board"
+                    "swimlanes"
                 ],
-                "summary": "Обновить доску",
+                "summary": "Обновить дорожку",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1714,24 +2170,37 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Новые данные доски",
-                        "name": "board",
+                        "type": "integer",
+                        "description": "ID дорожки",
+                        "name": "swimlane_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag дорожки",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Параметры дорожки",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Board"
+                            "$ref": "#/definitions/handlers.SwimlaneInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Доска успешно обновлена",
+                        "description": "Дорожка обновлена",
                         "schema": {
-                            "$ref": "#/definitions/models.Board"
+                            "$ref": "#/definitions/models.Swimlane"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат ID или ошибка данных",
+                        "description": "Неверные входные данные",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1739,8 +2208,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Неавторизованный запрос",
+                    "403": {
+                        "description": "Нет доступа к доске",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1748,8 +2217,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Нет прав на обновление доски",
+                    "404": {
+                        "description": "Дорожка не найдена",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1757,8 +2226,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Доска не найдена",
+                    "412": {
+                        "description": "Дорожка изменена другим запросом",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1778,14 +2247,14 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Удаляет доску по ID, если пользователь является её владельцем",
+                "description": "Удаляет дорожку. Ее карточки остаются в своих колонках и переходят в дорожку по умолчанию",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "board"
+                    "swimlanes"
                 ],
-                "summary": "Удалить доску",
+                "summary": "Удалить дорожку",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1793,17 +2262,24 @@ const docTemplate = `{
                         "name": "board_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID дорожки",
+                        "name": "swimlane_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag дорожки",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Сообщение об успешном удалении",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Неверный формат ID",
@@ -1814,8 +2290,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Неавторизованный запрос",
+                    "403": {
+                        "description": "Нет доступа к доске",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1823,8 +2299,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Нет прав на удаление доски",
+                    "404": {
+                        "description": "Дорожка не найдена",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1832,8 +2308,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Доска не найдена",
+                    "412": {
+                        "description": "Дорожка изменена другим запросом",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1853,16 +2329,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/boards/{board_id}/columns": {
-            "get": {
-                "description": "Возвращает все колонки, принадлежащие указанной доске.",
+        "/api/boards/{board_id}/template": {
+            "post": {
+                "description": "Сохраняет колонки, дорожки и метки доски как шаблон текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "columns"
+                    "board-templates"
                 ],
-                "summary": "Получить колонки доски",
+                "summary": "Сохранить доску как шаблон",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1870,20 +2349,35 @@ const docTemplate = `{
                         "name": "board_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Название шаблона",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SaveTemplateInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Список колонок доски",
+                    "201": {
+                        "description": "Шаблон сохранен",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Column"
-                            }
+                            "$ref": "#/definitions/models.BoardTemplate"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат ID доски",
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к доске",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1912,39 +2406,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/columns": {
+        "/api/boards/{board_id}/unarchive": {
             "post": {
-                "description": "Создает новую колонку для указанной доски.",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "columns"
+                    "board"
                 ],
-                "summary": "Создать колонку",
+                "summary": "Восстановить доску из архива",
                 "parameters": [
                     {
-                        "description": "Данные колонки (должны содержать BoardID и Title)",
-                        "name": "column",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Column"
-                        }
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag доски",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Колонка успешно создана",
+                    "200": {
+                        "description": "Доска восстановлена",
                         "schema": {
-                            "$ref": "#/definitions/models.Column"
+                            "$ref": "#/definitions/models.Board"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия доски"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверные входные данные или отсутствует BoardID/Title",
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав администратора доски",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1961,6 +2470,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "412": {
+                        "description": "Доска изменена другим запросом",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
@@ -1973,34 +2491,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/columns/{column_id}": {
+        "/api/boards/{board_id}/webhooks": {
             "get": {
-                "description": "Возвращает колонку по её ID.",
+                "description": "Возвращает вебхуки доски. Доступно владельцу и администраторам доски",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "columns"
+                    "webhooks"
                 ],
-                "summary": "Получить колонку",
+                "summary": "Получить вебхуки доски",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID колонки",
-                        "name": "column_id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Колонка успешно найдена",
+                        "description": "Список вебхуков",
                         "schema": {
-                            "$ref": "#/definitions/models.Column"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Webhook"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверный формат ID колонки",
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на управление доской",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2009,7 +2539,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Колонка не найдена",
+                        "description": "Доска не найдена",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2028,8 +2558,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Обновляет данные колонки по ID.",
+            "post": {
+                "description": "Подписывает URL на события доски. Пустой список events означает все события.\nЗапросы подписываются заголовком X-Octaview-Signature: t=\u003cunix\u003e,v1=\u003cHMAC-SHA256 от \"\u003cunix\u003e.\u003cтело\u003e\"\u003e\nURL не может указывать на локальные, link-local и частные адреса: такие доставки отклоняются",
                 "consumes": [
                     "application/json"
                 ],
@@ -2037,36 +2567,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "columns"
+                    "webhooks"
                 ],
-                "summary": "Обновить колонку",
+                "summary": "Создать вебхук",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID колонки",
-                        "name": "column_id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Новые данные колонки",
-                        "name": "column",
+                        "description": "Параметры вебхука",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Column"
+                            "$ref": "#/definitions/handlers.WebhookInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Колонка успешно обновлена",
+                    "201": {
+                        "description": "Вебхук создан",
                         "schema": {
-                            "$ref": "#/definitions/models.Column"
+                            "$ref": "#/definitions/handlers.WebhookWithSecret"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат ID или входные данные",
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на управление доской",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2075,7 +2614,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Колонка не найдена",
+                        "description": "Доска не найдена",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2093,34 +2632,51 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Удаляет колонку по её ID.",
+            }
+        },
+        "/api/boards/{board_id}/webhooks/{webhook_id}": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "columns"
+                    "webhooks"
                 ],
-                "summary": "Удалить колонку",
+                "summary": "Получить вебхук",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID колонки",
-                        "name": "column_id",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "webhook_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Колонка успешно удалена",
+                    "200": {
+                        "description": "Вебхук",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Webhook"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат ID колонки",
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на управление доской",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2129,7 +2685,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Колонка не найдена",
+                        "description": "Вебхук не найден",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2147,16 +2703,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get a user by their ID",
+            },
+            "put": {
+                "description": "Меняет адрес, фильтр событий, секрет (если передан) и состояние вебхука.\nПовторное включение отключенного вебхука сбрасывает счетчик неудач",
                 "consumes": [
                     "application/json"
                 ],
@@ -2164,283 +2713,5916 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Обновить вебхук",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "webhook_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Параметры вебхука",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Вебхук обновлен",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Webhook"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные входные данные",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на управление доской",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Вебхук не найден",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Update user information",
-                "consumes": [
-                    "application/json"
-                ],
+            "delete": {
+                "description": "Удаляет вебхук вместе с журналом доставок",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Update user",
+                "summary": "Удалить вебхук",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User update info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.updateUserRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.User"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Нет прав на управление доской",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Вебхук не найден",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Delete a user by ID",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/boards/{board_id}/webhooks/{webhook_id}/deliveries": {
+            "get": {
+                "description": "Возвращает последние доставки вебхука, новые сначала",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Delete user",
+                "summary": "Журнал доставок вебхука",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "webhook_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество (по умолчанию 50, максимум 200)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Доставки",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MessageResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Нет прав на управление доской",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Вебхук не найден",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/users/{id}/change-password": {
+        "/api/boards/{board_id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Change the password for a user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Ставит в очередь повторную отправку события с тем же телом",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Change user password",
+                "summary": "Повторить доставку",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "ID доски",
+                        "name": "board_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Password change info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.changePasswordRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID доставки",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Доставка поставлена в очередь",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MessageResponse"
+                            "$ref": "#/definitions/models.WebhookDelivery"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Нет прав на управление доской",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Вебхук или доставка не найдены",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.AddLabelInput": {
-            "type": "object",
-            "properties": {
-                "label_id": {
+        },
+        "/api/boards/{board_id}/ws": {
+            "get": {
+                "description": "Открывает WebSocket и передает изменения карточек, колонок, меток и комментариев доски в виде JSON-сообщений.\nПервым приходит сообщение {\"type\":\"subscribed\"}, затем события с полями id, type, board_id, actor_id, occurred_at, data.\nСервер отправляет ping каждые 25 секунд и закрывает соединение, если клиент не отвечает 60 секунд.\nКлиент может отправить {\"type\":\"ping\"} и получить {\"type\":\"pong\"}. Клиент, не успевающий читать события, отключается с кодом 1008.\nСоединение отмечает пользователя на доске на время подключения (session_id приходит в сообщении subscribed).\nСообщение {\"type\":\"presence\",\"card_id\":42} сообщает, что пользователь редактирует карточку, без card_id — что закончил.\nЕсли браузер не позволяет передать заголовок Authorization, токен передается параметром access_token.\nПодключение со страниц других сайтов (заголовок Origin) отклоняется с кодом 403, если сайт не указан в HTTP_ALLOWED_ORIGINS.",
+                "tags": [
+                    "realtime"
+                ],
+                "summary": "WebSocket с событиями доски",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID сессии присутствия; по умолчанию создается новый",
+                        "name": "session_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT-токен",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Соединение переключено на WebSocket",
+                        "schema": {
+                            "$ref": "#/definitions/service.BoardEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к доске или запрос с чужого сайта",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Доска не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards": {
+            "post": {
+                "description": "Create a new card in a column",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Create a new card",
+                "parameters": [
+                    {
+                        "description": "Card data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Card"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Card"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "403": {
+                        "description": "Board guests cannot create cards",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The column is full and the board blocks WIP limit overruns",
+                        "schema": {
+                            "$ref": "#/definitions/models.WIPLimitError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/positions": {
+            "put": {
+                "description": "Reorder the cards of a column. The list must contain exactly the cards currently in the column",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Update card positions",
+                "parameters": [
+                    {
+                        "description": "All cards of the column in the new order",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Card"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderConflictError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/watched": {
+            "get": {
+                "description": "Get all cards the current user is watching, most recently updated first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards",
+                    "watchers"
+                ],
+                "summary": "Get watched cards",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Card"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/{card_id}/comments": {
+            "get": {
+                "description": "Get all comments for a specific card",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Get comments by card ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "card_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Comment"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/{card_id}/watch": {
+            "post": {
+                "description": "Subscribe the current user to changes of a card",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards",
+                    "watchers"
+                ],
+                "summary": "Watch a card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "card_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Unsubscribe the current user from changes of a card",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards",
+                    "watchers"
+                ],
+                "summary": "Unwatch a card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "card_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/{card_id}/watchers": {
+            "get": {
+                "description": "Get all users watching a card",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards",
+                    "watchers"
+                ],
+                "summary": "Get card watchers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "card_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CardWatcher"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/{id}": {
+            "get": {
+                "description": "Get a card by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Get a card by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously received version",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Card"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Card version"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a card by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Update a card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated card data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Card"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being updated",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Card"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New card version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The new column is full and the board blocks WIP limit overruns",
+                        "schema": {
+                            "$ref": "#/definitions/models.WIPLimitError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a card by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Delete a card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being deleted",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/{id}/assign": {
+            "post": {
+                "description": "Assign a card to a user by user ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Assign a card to a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User ID",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AssignCardInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/{id}/due-date": {
+            "put": {
+                "description": "Set or update the due date for a card",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Update card due date",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Due date (null to remove)",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateDueDateInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being updated",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/{id}/labels": {
+            "get": {
+                "description": "Get all labels associated with a card",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards",
+                    "labels"
+                ],
+                "summary": "Get all labels for a card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Label"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a label to a card",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards",
+                    "labels"
+                ],
+                "summary": "Add a label to a card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Label ID",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddLabelInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove all labels associated with a card",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards",
+                    "labels"
+                ],
+                "summary": "Remove all labels from a card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/{id}/labels/batch": {
+            "post": {
+                "description": "Add multiple labels to a card in a single request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards",
+                    "labels"
+                ],
+                "summary": "Add multiple labels to a card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Label IDs",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BatchAddLabelsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/{id}/labels/{label_id}": {
+            "delete": {
+                "description": "Remove a label from a card",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards",
+                    "labels"
+                ],
+                "summary": "Remove a label from a card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Label ID",
+                        "name": "label_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/{id}/move": {
+            "post": {
+                "description": "Move a card to a specific position in the cell of a column and a swimlane",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Move a card to another column",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New column and position",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MoveCardInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The target column is full and the board blocks WIP limit overruns",
+                        "schema": {
+                            "$ref": "#/definitions/models.WIPLimitError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cards/{id}/unassign": {
+            "post": {
+                "description": "Remove user assignment from a card",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Unassign a card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/columns/positions": {
+            "put": {
+                "description": "Меняет порядок колонок доски. Список должен содержать ровно все колонки доски.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "columns"
+                ],
+                "summary": "Обновить позиции колонок",
+                "parameters": [
+                    {
+                        "description": "Все колонки доски в новом порядке",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Column"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Positions updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные входные данные или отсутствуют колонки",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Доска не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Состав колонок доски изменился",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderConflictError"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/columns/{column_id}/cards": {
+            "get": {
+                "description": "Get all cards in a column",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Get cards by column ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Column ID",
+                        "name": "column_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Card"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/columns/{column_id}/cards/order": {
+            "put": {
+                "description": "Atomically reorder the cards of a column. The list must contain exactly the cards currently in the column, otherwise 409 is returned with the current order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Reorder cards in a column",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Column ID",
+                        "name": "column_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "All card IDs of the column in the new order",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReorderCardsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Card"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "403": {
+                        "description": "Board guests cannot reorder cards",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderConflictError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comments": {
+            "post": {
+                "description": "Create a new comment for a card",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Create a new comment",
+                "parameters": [
+                    {
+                        "description": "Comment data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comments/{comment_id}": {
+            "get": {
+                "description": "Get a comment by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Get comment by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously received version",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Comment version"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a comment's content",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Update a comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated comment data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being updated",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comment"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New comment version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft-delete a comment by ID. The comment stays in the card thread as a tombstone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Delete a comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being deleted",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comments/{comment_id}/purge": {
+            "delete": {
+                "description": "Permanently delete a comment and its edit history. Only board admins can purge comments",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Permanently delete a comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comments/{comment_id}/revisions": {
+            "get": {
+                "description": "Get all revisions of a comment, oldest first. Revisions of a deleted comment are available to board admins only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Get comment edit history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CommentRevision"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/labels": {
+            "post": {
+                "description": "Create a new label for a board",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Create a new label",
+                "parameters": [
+                    {
+                        "description": "Label information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LabelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LabelResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/labels/{label_id}": {
+            "get": {
+                "description": "Get label details by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Get label by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Label ID",
+                        "name": "label_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously received version",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LabelResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Label version"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update label details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Update label",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Label ID",
+                        "name": "label_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Label information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LabelRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being updated",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LabelResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New label version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a label by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Delete label",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Label ID",
+                        "name": "label_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being deleted",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/labels/{label_id}/share": {
+            "put": {
+                "description": "Share a label with every board of its board's workspace, or make it a board label again. Unsharing removes the label from cards of other boards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Share label with workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Label ID",
+                        "name": "label_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sharing state",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ShareLabelRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being updated",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LabelResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New label version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications": {
+            "get": {
+                "description": "Возвращает входящие уведомления текущего пользователя, новые сначала. С group=card уведомления группируются по карточкам",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Получить уведомления",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только непрочитанные",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Группировка (card)",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество (по умолчанию 50, максимум 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список уведомлений",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Notification"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/email-preferences": {
+            "get": {
+                "description": "Возвращает, приходят ли письма о назначениях и упоминаниях сразу и как часто отправляется дайджест",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Получить настройки писем",
+                "responses": {
+                    "200": {
+                        "description": "Настройки писем",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmailPreference"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Включает или отключает немедленные письма и задает периодичность дайджеста (none, daily, weekly)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Обновить настройки писем",
+                "parameters": [
+                    {
+                        "description": "Настройки писем",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EmailPreference"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Настройки обновлены",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmailPreference"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/preferences": {
+            "get": {
+                "description": "Возвращает отключенные типы уведомлений и доски",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Получить настройки уведомлений",
+                "responses": {
+                    "200": {
+                        "description": "Настройки уведомлений",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NotificationPreferencesInput"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Заменяет список отключенных типов уведомлений и досок",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Обновить настройки уведомлений",
+                "parameters": [
+                    {
+                        "description": "Настройки уведомлений",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NotificationPreferencesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Настройки обновлены",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NotificationPreferencesInput"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/read-all": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Отметить все уведомления прочитанными",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/unread-count": {
+            "get": {
+                "description": "Возвращает количество непрочитанных уведомлений текущего пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Количество непрочитанных уведомлений",
+                "responses": {
+                    "200": {
+                        "description": "Количество непрочитанных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UnreadCountResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/{notification_id}/read": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Отметить уведомление прочитанным",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID уведомления",
+                        "name": "notification_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Уведомление не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/{notification_id}/unread": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Отметить уведомление непрочитанным",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID уведомления",
+                        "name": "notification_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Уведомление не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces": {
+            "get": {
+                "description": "Возвращает рабочие пространства, в которых состоит пользователь: сначала личное, затем остальные по названию",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Получить рабочие пространства пользователя",
+                "responses": {
+                    "200": {
+                        "description": "Рабочие пространства",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Workspace"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создает рабочее пространство; текущий пользователь становится его владельцем и администратором",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Создать рабочее пространство",
+                "parameters": [
+                    {
+                        "description": "Название и описание",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WorkspaceInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Рабочее пространство создано",
+                        "schema": {
+                            "$ref": "#/definitions/models.Workspace"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspace_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Получить рабочее пространство",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID рабочего пространства",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Рабочее пространство",
+                        "schema": {
+                            "$ref": "#/definitions/models.Workspace"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия рабочего пространства"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Рабочее пространство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Меняет название и описание. Доступно администраторам рабочего пространства",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Изменить рабочее пространство",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID рабочего пространства",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Название и описание",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WorkspaceInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag рабочего пространства",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Рабочее пространство обновлено",
+                        "schema": {
+                            "$ref": "#/definitions/models.Workspace"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия рабочего пространства"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав администратора",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Рабочее пространство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Рабочее пространство изменено другим запросом",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет рабочее пространство без досок. Личное пространство удалить нельзя. Доступно администраторам",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Удалить рабочее пространство",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID рабочего пространства",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag рабочего пространства",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или личное пространство",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав администратора",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Рабочее пространство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "В рабочем пространстве есть доски",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Рабочее пространство изменено другим запросом",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspace_id}/boards": {
+            "get": {
+                "description": "Возвращает доски рабочего пространства, которыми пользователь владеет или в которых состоит. Параметры те же, что у списка досок пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Получить доски рабочего пространства",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID рабочего пространства",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: name (по умолчанию), updated_at или activity",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Архивные доски: false (по умолчанию), true — только архивные, all — все",
+                        "name": "archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только доски со звездочкой",
+                        "name": "starred",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 50, максимум 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список досок",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Board"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Курсор следующей страницы"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Рабочее пространство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspace_id}/labels": {
+            "get": {
+                "description": "Возвращает метки, которые можно назначать карточкам любой доски рабочего пространства",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Получить общие метки рабочего пространства",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID рабочего пространства",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Общие метки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LabelResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Рабочее пространство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspace_id}/members": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Получить участников рабочего пространства",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID рабочего пространства",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Участники, включая владельца",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WorkspaceMember"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Рабочее пространство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Добавляет пользователя в рабочее пространство. Доступно администраторам; в личное пространство добавить нельзя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Добавить участника рабочего пространства",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID рабочего пространства",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Пользователь и роль",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddWorkspaceMemberInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Участник добавлен",
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkspaceMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав администратора",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Рабочее пространство или пользователь не найдены",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь уже участник",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workspaces/{workspace_id}/members/{user_id}": {
+            "put": {
+                "description": "Роль владельца не меняется. Доступно администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Изменить роль участника рабочего пространства",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID рабочего пространства",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая роль",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateMemberRoleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Роль обновлена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав администратора",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Участник не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Администраторы исключают любого участника, кроме владельца; участник может выйти сам",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Исключить участника рабочего пространства",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID рабочего пространства",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или владелец",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав администратора",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Участник не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Login with email and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Login a user",
+                "parameters": [
+                    {
+                        "description": "User login credentials",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.loginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.authResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get information about the currently authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get current user info",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Refresh the authentication token using the current valid token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh authentication token",
+                "parameters": [
+                    {
+                        "description": "Refresh token request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.refreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.authResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user with email, password and name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User registration info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.registerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.authResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/boards": {
+            "get": {
+                "description": "Возвращает доски, которыми пользователь владеет или в которых состоит. Архивные доски по умолчанию скрыты.\nЕсли есть следующая страница, ее курсор возвращается в заголовке X-Next-Cursor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "board"
+                ],
+                "summary": "Получить доски пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Сортировка: name (по умолчанию), updated_at или activity",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Архивные доски: false (по умолчанию), true — только архивные, all — все",
+                        "name": "archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только доски со звездочкой",
+                        "name": "starred",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 50, максимум 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список досок",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Board"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Курсор следующей страницы"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создает новую доску для авторизованного пользователя в рабочем пространстве workspace_id, в котором он состоит",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "board"
+                ],
+                "summary": "Создать доску",
+                "parameters": [
+                    {
+                        "description": "Данные доски",
+                        "name": "board",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Board"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Доска успешно создана",
+                        "schema": {
+                            "$ref": "#/definitions/models.Board"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка запроса или пользователь не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Рабочее пространство не найдено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/boards/{board_id}": {
+            "get": {
+                "description": "Возвращает доску по указанному ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "board"
+                ],
+                "summary": "Получить доску",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag полученной ранее версии",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Доска успешно найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Board"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия доски"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Доска не изменилась"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Доска не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет данные доски по ID, если пользователь является её владельцем.\nДругой workspace_id переносит доску в это рабочее пространство; общие метки прежнего пространства снимаются с ее карточек",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "board"
+                ],
+                "summary": "Обновить доску",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные доски",
+                        "name": "board",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Board"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии, которую изменяет клиент",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Доска успешно обновлена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Board"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия доски"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или ошибка данных",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на обновление доски",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Доска или рабочее пространство не найдены",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Доска в архиве",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Доска изменена другим запросом",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет доску по ID, если пользователь является её владельцем. Колонки, дорожки, карточки и комментарии доски удаляются вместе с ней; доску можно восстановить",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "board"
+                ],
+                "summary": "Удалить доску",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии, которую удаляет клиент",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сообщение об успешном удалении",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на удаление доски",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Доска не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Доска изменена другим запросом",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/boards/{board_id}/columns": {
+            "get": {
+                "description": "Возвращает все колонки, принадлежащие указанной доске.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "columns"
+                ],
+                "summary": "Получить колонки доски",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список колонок доски",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Column"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID доски",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Доска не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/boards/{board_id}/columns/order": {
+            "put": {
+                "description": "Атомарно меняет порядок колонок. Список должен содержать ровно все колонки доски, иначе возвращается 409 с текущим порядком.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "columns"
+                ],
+                "summary": "Изменить порядок колонок доски",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID доски",
+                        "name": "board_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID всех колонок доски в новом порядке",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReorderColumnsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Колонки в новом порядке",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Column"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Доска не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Состав колонок доски изменился",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderConflictError"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/columns": {
+            "post": {
+                "description": "Создает новую колонку для указанной доски.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "columns"
+                ],
+                "summary": "Создать колонку",
+                "parameters": [
+                    {
+                        "description": "Данные колонки (должны содержать BoardID и Title)",
+                        "name": "column",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Column"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Колонка успешно создана",
+                        "schema": {
+                            "$ref": "#/definitions/models.Column"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные входные данные или отсутствует BoardID/Title",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Доска не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/columns/{column_id}": {
+            "get": {
+                "description": "Возвращает колонку по её ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "columns"
+                ],
+                "summary": "Получить колонку",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID колонки",
+                        "name": "column_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag полученной ранее версии",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Колонка успешно найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Column"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия колонки"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Колонка не изменилась"
+                    },
+                    "400": {
+                        "description": "Неверный формат ID колонки",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Колонка не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет данные колонки по ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "columns"
+                ],
+                "summary": "Обновить колонку",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID колонки",
+                        "name": "column_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные колонки",
+                        "name": "column",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Column"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии, которую изменяет клиент",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Колонка успешно обновлена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Column"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Новая версия колонки"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или входные данные",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Колонка не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Колонка изменена другим запросом",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет колонку по её ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "columns"
+                ],
+                "summary": "Удалить колонку",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID колонки",
+                        "name": "column_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии, которую удаляет клиент",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Колонка успешно удалена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID колонки",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Колонка не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Колонка изменена другим запросом",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/public/boards/{token}": {
+            "get": {
+                "description": "Возвращает снимок доски только для чтения. Учетная запись не нужна; пароль защищенной ссылки передается в заголовке X-Share-Password.\nПользователю с доступом к доске пароль не нужен. Комментарии и адреса почты скрыты, если ссылка их не показывает",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share-links"
+                ],
+                "summary": "Просмотр доски по публичной ссылке",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен ссылки",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль ссылки",
+                        "name": "X-Share-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Снимок доски",
+                        "schema": {
+                            "$ref": "#/definitions/service.BoardSnapshot"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный или отсутствующий пароль",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Ссылка не найдена, отозвана или истекла",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a user by their ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update user information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User update info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.updateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/change-password": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the password for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Change user password",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Password change info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.changePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.AddLabelInput": {
+            "type": "object",
+            "properties": {
+                "label_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.AddMemberInput": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "member"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.AddWorkspaceMemberInput": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "member"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.AssignCardInput": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.BatchAddLabelsInput": {
+            "type": "object",
+            "properties": {
+                "label_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handlers.CloneBoardInput": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "columns": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "members": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "swimlanes": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Project B"
+                },
+                "workspace_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.CreateBoardFromTemplateInput": {
+            "type": "object",
+            "required": [
+                "workspace_id"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Project C"
+                },
+                "wip_mode": {
+                    "type": "string",
+                    "example": "warn"
+                },
+                "workspace_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Error message details"
+                }
+            }
+        },
+        "handlers.LabelRequest": {
+            "type": "object",
+            "required": [
+                "board_id",
+                "color",
+                "name"
+            ],
+            "properties": {
+                "board_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "color": {
+                    "type": "string",
+                    "example": "#FF0000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Bug"
+                }
+            }
+        },
+        "handlers.LabelResponse": {
+            "type": "object",
+            "properties": {
+                "board_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "color": {
+                    "type": "string",
+                    "example": "#FF0000"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Bug"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "workspace_id": {
+                    "description": "WorkspaceID is set for labels shared with every board of the workspace",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Operation successful"
+                }
+            }
+        },
+        "handlers.MoveCardInput": {
+            "type": "object",
+            "properties": {
+                "column_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "swimlane_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.NotificationPreferencesInput": {
+            "type": "object",
+            "properties": {
+                "muted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NotificationPreference"
+                    }
+                }
+            }
+        },
+        "handlers.PresenceInput": {
+            "type": "object",
+            "required": [
+                "session_id"
+            ],
+            "properties": {
+                "card_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "session_id": {
+                    "type": "string",
+                    "example": "3f2c9a"
+                }
+            }
+        },
+        "handlers.ReorderCardsInput": {
+            "type": "object",
+            "properties": {
+                "card_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handlers.ReorderColumnsInput": {
+            "type": "object",
+            "properties": {
+                "column_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handlers.ReorderSwimlanesInput": {
+            "type": "object",
+            "properties": {
+                "swimlane_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handlers.SaveTemplateInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Sprint board"
+                }
+            }
+        },
+        "handlers.ShareLabelRequest": {
+            "type": "object",
+            "properties": {
+                "shared": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "handlers.ShareLinkInput": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "show_comments": {
+                    "type": "boolean"
+                },
+                "show_emails": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.SwimlaneInput": {
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string",
+                    "example": "Expedite"
+                }
+            }
+        },
+        "handlers.UnreadCountResponse": {
+            "type": "object",
+            "properties": {
+                "unread_count": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.UpdateDueDateInput": {
+            "type": "object",
+            "properties": {
+                "due_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.UpdateMemberRoleInput": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "handlers.WebhookInput": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "card.created",
+                        "card.moved"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/kanban"
+                }
+            }
+        },
+        "handlers.WebhookWithSecret": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "board_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failure_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.WorkspaceInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Platform team"
+                }
+            }
+        },
+        "handlers.authResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "handlers.changePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.errorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.loginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.refreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.registerRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "handlers.updateUserRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "models.Board": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "description": "ArchivedAt задан у архивных досок: они скрыты из списков и доступны только для чтения.\nLastActivityAt обновляется при записи событий доски, а не при сохранении самой доски.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_activity_at": {
+                    "type": "string"
+                },
+                "owner": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "starred": {
+                    "description": "Starred и ViewedAt заполняются в списках досок текущего пользователя.",
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "viewed_at": {
+                    "type": "string"
+                },
+                "wip_mode": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BoardLayout": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemplateColumn"
+                    }
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemplateLabel"
+                    }
+                },
+                "swimlanes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemplateSwimlane"
+                    }
+                }
+            }
+        },
+        "models.BoardMember": {
+            "type": "object",
+            "properties": {
+                "board_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BoardPresence": {
+            "type": "object",
+            "properties": {
+                "board_id": {
+                    "type": "integer"
+                },
+                "card_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BoardShareLink": {
+            "type": "object",
+            "properties": {
+                "board_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "has_password": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "show_comments": {
+                    "type": "boolean"
+                },
+                "show_emails": {
+                    "type": "boolean"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BoardTemplate": {
+            "type": "object",
+            "properties": {
+                "built_in": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "layout": {
+                    "$ref": "#/definitions/models.BoardLayout"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Card": {
+            "type": "object",
+            "properties": {
+                "assigned_to": {
+                    "type": "integer"
+                },
+                "column": {
+                    "$ref": "#/definitions/models.Column"
+                },
+                "column_id": {
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "description_html": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MentionSpan"
+                    }
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "swimlane_id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CardWatcher": {
+            "type": "object",
+            "properties": {
+                "card_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Column": {
+            "type": "object",
+            "properties": {
+                "board": {
+                    "$ref": "#/definitions/models.Board"
+                },
+                "board_id": {
+                    "type": "integer"
+                },
+                "card_count": {
+                    "description": "CardCount и OverLimit заполняются при чтении колонок; гостю доски считаются только\nоткрытые ему карточки.",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "over_limit": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "wip_limit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Comment": {
+            "type": "object",
+            "properties": {
+                "card": {
+                    "$ref": "#/definitions/models.Card"
+                },
+                "card_id": {
+                    "type": "integer"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "content_html": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted": {
+                    "description": "Deleted помечает удаленный комментарий, который отображается в ленте как заглушка.",
+                    "type": "boolean"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MentionSpan"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CommentRevision": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "type": "integer"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "edited_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "version": {
                     "type": "integer"
                 }
             }
         },
-        "handlers.AssignCardInput": {
+        "models.EmailPreference": {
+            "type": "object",
+            "properties": {
+                "digest": {
+                    "type": "string"
+                },
+                "immediate": {
+                    "type": "boolean"
+                },
+                "last_digest_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Label": {
+            "type": "object",
+            "properties": {
+                "board": {
+                    "$ref": "#/definitions/models.Board"
+                },
+                "board_id": {
+                    "type": "integer"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "workspace_id": {
+                    "description": "WorkspaceID задан у общих меток: их можно назначать карточкам любой доски рабочего\nпространства. Событиями меток по-прежнему владеет доска BoardID.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MentionSpan": {
             "type": "object",
             "properties": {
+                "end": {
+                    "type": "integer"
+                },
+                "handle": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "integer"
+                },
                 "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "handlers.BatchAddLabelsInput": {
+        "models.Notification": {
             "type": "object",
             "properties": {
-                "label_ids": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "board_id": {
+                    "type": "integer"
+                },
+                "card_id": {
+                    "type": "integer"
+                },
+                "comment_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.NotificationPreference": {
+            "type": "object",
+            "properties": {
+                "board_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderConflictError": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "unexpected": {
                     "type": "array",
                     "items": {
                         "type": "integer"
@@ -2448,209 +8630,386 @@ const docTemplate = `{
                 }
             }
         },
-        "handlers.ErrorResponse": {
+        "models.Swimlane": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "Error message details"
+                "board_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.LabelRequest": {
+        "models.TemplateColumn": {
             "type": "object",
-            "required": [
-                "board_id",
-                "color",
-                "name"
-            ],
             "properties": {
-                "board_id": {
-                    "type": "integer",
-                    "example": 1
+                "kind": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 },
+                "wip_limit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TemplateLabel": {
+            "type": "object",
+            "properties": {
                 "color": {
-                    "type": "string",
-                    "example": "#FF0000"
+                    "type": "string"
                 },
                 "name": {
-                    "type": "string",
-                    "example": "Bug"
+                    "type": "string"
                 }
             }
         },
-        "handlers.LabelResponse": {
+        "models.TemplateSwimlane": {
             "type": "object",
             "properties": {
-                "board_id": {
-                    "type": "integer",
-                    "example": 1
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "color": {
-                    "type": "string",
-                    "example": "#FF0000"
+                "deleted_at": {
+                    "description": "Added DeletedAt for soft delete",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
                 },
                 "id": {
-                    "type": "integer",
-                    "example": 1
+                    "type": "integer"
                 },
                 "name": {
-                    "type": "string",
-                    "example": "Bug"
+                    "description": "Added not null constraint",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.MessageResponse": {
+        "models.ValidationError": {
             "type": "object",
             "properties": {
+                "field": {
+                    "type": "string"
+                },
                 "message": {
-                    "type": "string",
-                    "example": "Operation successful"
+                    "type": "string"
                 }
             }
         },
-        "handlers.MoveCardInput": {
+        "models.WIPLimitError": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "column_id": {
                     "type": "integer"
                 },
-                "position": {
+                "count": {
+                    "type": "integer"
+                },
+                "limit": {
                     "type": "integer"
+                },
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.UpdateDueDateInput": {
+        "models.Webhook": {
             "type": "object",
             "properties": {
-                "due_date": {
+                "active": {
+                    "type": "boolean"
+                },
+                "board_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failure_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "handlers.authResponse": {
+        "models.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "token": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "redelivery_of": {
+                    "type": "integer"
+                },
+                "response_body": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
+                "webhook_id": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.changePasswordRequest": {
+        "models.Workspace": {
             "type": "object",
-            "required": [
-                "new_password",
-                "old_password"
-            ],
             "properties": {
-                "new_password": {
-                    "type": "string",
-                    "minLength": 6
+                "created_at": {
+                    "type": "string"
                 },
-                "old_password": {
+                "description": {
                     "type": "string"
-                }
-            }
-        },
-        "handlers.errorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "personal": {
+                    "type": "boolean"
+                },
+                "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.loginRequest": {
+        "models.WorkspaceMember": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
-                "password": {
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
                     "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "workspace_id": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.refreshTokenRequest": {
+        "service.BoardEvent": {
             "type": "object",
-            "required": [
-                "token"
-            ],
             "properties": {
-                "token": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "board_id": {
+                    "type": "integer"
+                },
+                "data": {},
+                "id": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "handlers.registerRequest": {
+        "service.BoardLanes": {
             "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "board_id": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Column"
+                    }
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "lanes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.LaneRow"
+                    }
                 }
             }
         },
-        "handlers.updateUserRequest": {
+        "service.BoardSnapshot": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "board": {
+                    "$ref": "#/definitions/models.Board"
                 },
-                "name": {
-                    "type": "string"
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.SnapshotColumn"
+                    }
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "swimlanes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Swimlane"
+                    }
                 }
             }
         },
-        "models.Board": {
+        "service.GuestAccess": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "board_id": {
+                    "type": "integer"
                 },
-                "description": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Card"
+                    }
+                },
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "owner": {
+                "role": {
+                    "type": "string"
+                },
+                "user": {
                     "$ref": "#/definitions/models.User"
                 },
-                "owner_id": {
+                "user_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "service.LaneCell": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Card"
+                    }
                 },
-                "title": {
-                    "type": "string"
+                "column_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.LaneRow": {
+            "type": "object",
+            "properties": {
+                "cells": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.LaneCell"
+                    }
                 },
-                "updated_at": {
-                    "type": "string"
+                "swimlane": {
+                    "$ref": "#/definitions/models.Swimlane"
                 }
             }
         },
-        "models.Card": {
+        "service.SnapshotCard": {
             "type": "object",
             "properties": {
                 "assigned_to": {
@@ -2662,21 +9021,54 @@ const docTemplate = `{
                 "column_id": {
                     "type": "integer"
                 },
+                "comment_count": {
+                    "type": "integer"
+                },
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.SnapshotComment"
+                    }
+                },
+                "completed_at": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
+                "description_html": {
+                    "type": "string"
+                },
                 "due_date": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Label"
+                    }
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MentionSpan"
+                    }
+                },
                 "position": {
                     "type": "integer"
                 },
+                "started_at": {
+                    "type": "string"
+                },
+                "swimlane_id": {
+                    "type": "integer"
+                },
                 "title": {
                     "type": "string"
                 },
@@ -2685,10 +9077,13 @@ const docTemplate = `{
                 },
                 "user": {
                     "$ref": "#/definitions/models.User"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Column": {
+        "service.SnapshotColumn": {
             "type": "object",
             "properties": {
                 "board": {
@@ -2697,108 +9092,62 @@ const docTemplate = `{
                 "board_id": {
                     "type": "integer"
                 },
+                "card_count": {
+                    "description": "CardCount и OverLimit заполняются при чтении колонок; гостю доски считаются только\nоткрытые ему карточки.",
+                    "type": "integer"
+                },
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.SnapshotCard"
+                    }
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "position": {
-                    "type": "integer"
-                },
-                "title": {
+                "kind": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.Comment": {
-            "type": "object",
-            "properties": {
-                "card": {
-                    "$ref": "#/definitions/models.Card"
+                "over_limit": {
+                    "type": "boolean"
                 },
-                "card_id": {
+                "position": {
                     "type": "integer"
                 },
-                "content": {
-                    "type": "string"
-                },
-                "created_at": {
+                "title": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
                 "updated_at": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
+                "version": {
+                    "type": "integer"
                 },
-                "user_id": {
+                "wip_limit": {
                     "type": "integer"
                 }
             }
         },
-        "models.ErrorResponse": {
+        "service.SnapshotComment": {
             "type": "object",
             "properties": {
-                "message": {
+                "author_email": {
                     "type": "string"
-                }
-            }
-        },
-        "models.Label": {
-            "type": "object",
-            "properties": {
-                "board": {
-                    "$ref": "#/definitions/models.Board"
                 },
-                "board_id": {
-                    "type": "integer"
-                },
-                "color": {
+                "author_name": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.User": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+                "content": {
                     "type": "string"
                 },
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.ValidationError": {
-            "type": "object",
-            "properties": {
-                "field": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
                 }
             }
         }
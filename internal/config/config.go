@@ -9,14 +9,21 @@ type Config struct {
 	HTTP     HTTPConfig
 	Database DatabaseConfig
 	JWT      JWTConfig
+	SMTP     SMTPConfig
+	Email    EmailConfig
+	Reminder ReminderConfig
+	Events   EventsConfig
 }
 
 type AppConfig struct {
 	Env string
 }
 
+// HTTPConfig задает порт сервера. AllowedOrigins — страницы других сайтов, с которых можно
+// открывать WebSocket; страницы того же хоста разрешены всегда.
 type HTTPConfig struct {
-	Port string
+	Port           string
+	AllowedOrigins []string
 }
 
 type DatabaseConfig struct {
@@ -32,4 +39,42 @@ type DatabaseConfig struct {
 type JWTConfig struct {
 	Secret    string
 	ExpiresIn time.Duration
-}
\ No newline at end of file
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// EmailConfig управляет отправкой уведомлений по почте.
+type EmailConfig struct {
+	Enabled      bool
+	BaseURL      string
+	PollInterval time.Duration
+	MaxAttempts  int
+	DigestHour   int
+}
+
+// ReminderConfig задает, за сколько до срока напоминать о карточках.
+type ReminderConfig struct {
+	Offsets  []time.Duration
+	Overdue  bool
+	Interval time.Duration
+}
+
+// EventsConfig управляет ретрансляцией событий досок из outbox.
+// Broker — local для одного экземпляра сервера или postgres для нескольких.
+// Публикация в NATS включается, если задан NATSURL.
+type EventsConfig struct {
+	Broker            string
+	RelayInterval     time.Duration
+	Retention         time.Duration
+	LogRetention      time.Duration
+	NATSURL           string
+	NATSSubjectPrefix string
+	NATSTimeout       time.Duration
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -16,7 +18,8 @@ func LoadConfig() (*Config, error) {
 			Env: getEnv("APP_ENV", "development"),
 		},
 		HTTP: HTTPConfig{
-			Port: getEnv("HTTP_PORT", "8080"),
+			Port:           getEnv("HTTP_PORT", "8080"),
+			AllowedOrigins: splitList(getEnv("HTTP_ALLOWED_ORIGINS", "")),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -49,9 +52,121 @@ func LoadConfig() (*Config, error) {
 		ExpiresIn: jwtExpiration,
 	}
 
+	smtpTimeout, err := time.ParseDuration(getEnv("SMTP_TIMEOUT", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP timeout duration: %w", err)
+	}
+
+	config.SMTP = SMTPConfig{
+		Host:     getEnv("SMTP_HOST", "localhost"),
+		Port:     getEnv("SMTP_PORT", "587"),
+		Username: getEnv("SMTP_USERNAME", ""),
+		Password: getEnv("SMTP_PASSWORD", ""),
+		From:     getEnv("SMTP_FROM", "Kanban Octaview <no-reply@localhost>"),
+		Timeout:  smtpTimeout,
+	}
+
+	emailEnabled, err := strconv.ParseBool(getEnv("EMAIL_ENABLED", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_ENABLED value: %w", err)
+	}
+
+	pollInterval, err := time.ParseDuration(getEnv("EMAIL_POLL_INTERVAL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid email poll interval: %w", err)
+	}
+
+	maxAttempts, err := strconv.Atoi(getEnv("EMAIL_MAX_ATTEMPTS", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_MAX_ATTEMPTS value: %w", err)
+	}
+
+	digestHour, err := strconv.Atoi(getEnv("EMAIL_DIGEST_HOUR", "8"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_DIGEST_HOUR value: %w", err)
+	}
+
+	config.Email = EmailConfig{
+		Enabled:      emailEnabled,
+		BaseURL:      getEnv("EMAIL_BASE_URL", "http://localhost:8080"),
+		PollInterval: pollInterval,
+		MaxAttempts:  maxAttempts,
+		DigestHour:   digestHour,
+	}
+
+	var offsets []time.Duration
+	for _, raw := range strings.Split(getEnv("REMINDER_OFFSETS", "24h,1h"), ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		offset, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reminder offset %q: %w", raw, err)
+		}
+		offsets = append(offsets, offset)
+	}
+
+	remindOverdue, err := strconv.ParseBool(getEnv("REMINDER_OVERDUE", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REMINDER_OVERDUE value: %w", err)
+	}
+
+	reminderInterval, err := time.ParseDuration(getEnv("REMINDER_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid reminder interval duration: %w", err)
+	}
+
+	config.Reminder = ReminderConfig{
+		Offsets:  offsets,
+		Overdue:  remindOverdue,
+		Interval: reminderInterval,
+	}
+
+	relayInterval, err := time.ParseDuration(getEnv("EVENTS_RELAY_INTERVAL", "2s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid events relay interval duration: %w", err)
+	}
+
+	retention, err := time.ParseDuration(getEnv("EVENTS_RETENTION", "168h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid events retention duration: %w", err)
+	}
+
+	logRetention, err := time.ParseDuration(getEnv("EVENTS_LOG_RETENTION", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid events log retention duration: %w", err)
+	}
+
+	natsTimeout, err := time.ParseDuration(getEnv("EVENTS_NATS_TIMEOUT", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS timeout duration: %w", err)
+	}
+
+	config.Events = EventsConfig{
+		Broker:            getEnv("EVENTS_BROKER", "local"),
+		RelayInterval:     relayInterval,
+		Retention:         retention,
+		LogRetention:      logRetention,
+		NATSURL:           getEnv("EVENTS_NATS_URL", ""),
+		NATSSubjectPrefix: getEnv("EVENTS_NATS_SUBJECT_PREFIX", "octaview"),
+		NATSTimeout:       natsTimeout,
+	}
+
 	return config, nil
 }
 
+// splitList разбирает список через запятую, пропуская пустые элементы.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -2,6 +2,8 @@ package config
 
 import (
 	"net"
+	"net/mail"
+	"net/url"
 	"slices"
 	"strconv"
 	"strings"
@@ -26,6 +28,24 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := validateReminderConfig(c.Reminder); err != nil {
+		return err
+	}
+
+	if err := validateEventsConfig(c.Events); err != nil {
+		return err
+	}
+
+	if c.Email.Enabled {
+		if err := validateEmailConfig(c.Email); err != nil {
+			return err
+		}
+
+		if err := validateSMTPConfig(c.SMTP); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -45,6 +65,13 @@ func validateHTTPConfig(http HTTPConfig) error {
 	if port < 1 || port > 65535 {
 		return models.NewValidationError("HTTP_PORT", "must be between 1 and 65535")
 	}
+
+	for _, origin := range http.AllowedOrigins {
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.TrimSuffix(u.Path, "/") != "" {
+			return models.NewValidationError("HTTP_ALLOWED_ORIGINS", "must be a comma-separated list of origins like https://app.example.com")
+		}
+	}
 	return nil
 }
 
@@ -95,3 +122,94 @@ func validateJWTConfig(jwt JWTConfig) error {
 
 	return nil
 }
+
+func validateEmailConfig(email EmailConfig) error {
+	if u, err := url.Parse(email.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return models.NewValidationError("EMAIL_BASE_URL", "must be an absolute URL")
+	}
+
+	if email.PollInterval <= 0 {
+		return models.NewValidationError("EMAIL_POLL_INTERVAL", "must be a positive duration")
+	}
+
+	if email.MaxAttempts < 1 {
+		return models.NewValidationError("EMAIL_MAX_ATTEMPTS", "must be at least 1")
+	}
+
+	if email.DigestHour < 0 || email.DigestHour > 23 {
+		return models.NewValidationError("EMAIL_DIGEST_HOUR", "must be between 0 and 23")
+	}
+
+	return nil
+}
+
+func validateSMTPConfig(smtp SMTPConfig) error {
+	if strings.TrimSpace(smtp.Host) == "" {
+		return models.NewValidationError("SMTP_HOST", "cannot be empty")
+	}
+
+	port, err := strconv.Atoi(smtp.Port)
+	if err != nil {
+		return models.NewValidationError("SMTP_PORT", "must be a valid integer")
+	}
+	if port < 1 || port > 65535 {
+		return models.NewValidationError("SMTP_PORT", "must be between 1 and 65535")
+	}
+
+	if _, err := mail.ParseAddress(smtp.From); err != nil {
+		return models.NewValidationError("SMTP_FROM", "must be a valid email address")
+	}
+
+	if smtp.Timeout <= 0 {
+		return models.NewValidationError("SMTP_TIMEOUT", "must be a positive duration")
+	}
+
+	return nil
+}
+
+func validateReminderConfig(reminder ReminderConfig) error {
+	for _, offset := range reminder.Offsets {
+		if offset <= 0 {
+			return models.NewValidationError("REMINDER_OFFSETS", "must contain only positive durations")
+		}
+	}
+
+	if reminder.Interval <= 0 {
+		return models.NewValidationError("REMINDER_INTERVAL", "must be a positive duration")
+	}
+
+	return nil
+}
+
+func validateEventsConfig(events EventsConfig) error {
+	if !slices.Contains([]string{"local", "postgres"}, events.Broker) {
+		return models.NewValidationError("EVENTS_BROKER", "must be one of: local, postgres")
+	}
+
+	if events.RelayInterval <= 0 {
+		return models.NewValidationError("EVENTS_RELAY_INTERVAL", "must be a positive duration")
+	}
+
+	if events.Retention <= 0 {
+		return models.NewValidationError("EVENTS_RETENTION", "must be a positive duration")
+	}
+
+	if events.LogRetention <= 0 {
+		return models.NewValidationError("EVENTS_LOG_RETENTION", "must be a positive duration")
+	}
+
+	if events.NATSURL != "" {
+		u, err := url.Parse(events.NATSURL)
+		if err != nil || u.Scheme != "nats" || u.Host == "" {
+			return models.NewValidationError("EVENTS_NATS_URL", "must be a nats://host:port URL")
+		}
+		if events.NATSSubjectPrefix == "" || strings.ContainsAny(events.NATSSubjectPrefix, " \t*>") {
+			return models.NewValidationError("EVENTS_NATS_SUBJECT_PREFIX", "must be a non-empty subject without spaces or wildcards")
+		}
+		if events.NATSTimeout <= 0 {
+			return models.NewValidationError("EVENTS_NATS_TIMEOUT", "must be a positive duration")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package config
+
+import "testing"
+
+func TestValidateHTTPConfigAllowedOrigins(t *testing.T) {
+	tests := []struct {
+		origins []string
+		valid   bool
+	}{
+		{origins: nil, valid: true},
+		{origins: []string{"https://app.example.com", "http://localhost:3000/"}, valid: true},
+		{origins: []string{"app.example.com"}, valid: false},
+		{origins: []string{"ftp://app.example.com"}, valid: false},
+		{origins: []string{"https://app.example.com/board"}, valid: false},
+		{origins: []string{"*"}, valid: false},
+	}
+	for _, tt := range tests {
+		err := validateHTTPConfig(HTTPConfig{Port: "8080", AllowedOrigins: tt.origins})
+		if (err == nil) != tt.valid {
+			t.Errorf("validateHTTPConfig(%q) error = %v, want valid %v", tt.origins, err, tt.valid)
+		}
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	got := splitList(" https://a.example , ,https://b.example,")
+	if len(got) != 2 || got[0] != "https://a.example" || got[1] != "https://b.example" {
+		t.Errorf("splitList = %q", got)
+	}
+	if got := splitList(""); got != nil {
+		t.Errorf("splitList(\"\") = %q, want nil", got)
+	}
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/service"
+)
+
+// SharePasswordHeader — заголовок, в котором передается пароль публичной ссылки.
+const SharePasswordHeader = "X-Share-Password"
+
+type BoardShareHandler struct {
+	shareService  service.BoardShareServiceInterface
+	memberService service.BoardMemberServiceInterface
+}
+
+func NewBoardShareHandler(shareService service.BoardShareServiceInterface, memberService service.BoardMemberServiceInterface) *BoardShareHandler {
+	return &BoardShareHandler{
+		shareService:  shareService,
+		memberService: memberService,
+	}
+}
+
+// ShareLinkInput представляет параметры новой публичной ссылки.
+type ShareLinkInput struct {
+	Password     string     `json:"password" example:"s3cr3t"`
+	ExpiresAt    *time.Time `json:"expires_at" example:"2030-01-01T00:00:00Z"`
+	ShowComments bool       `json:"show_comments"`
+	ShowEmails   bool       `json:"show_emails"`
+}
+
+// GetShareLinks godoc
+// @Summary Получить публичные ссылки доски
+// @Description Возвращает все ссылки доски, включая отозванные и истекшие. Доступно владельцу и администраторам доски
+// @Tags share-links
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Success 200 {array} models.BoardShareLink "Список ссылок"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/share-links [get]
+func (h *BoardShareHandler) GetShareLinks(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, true)
+	if !ok {
+		return
+	}
+
+	links, err := h.shareService.GetByBoardID(c.Request.Context(), boardID)
+	if err != nil {
+		h.writeError(c, err, "failed to get share links")
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// CreateShareLink godoc
+// @Summary Создать публичную ссылку
+// @Description Выпускает ссылку на просмотр доски без учетной записи. Ссылку можно ограничить сроком действия и защитить паролем.
+// @Description Комментарии и адреса почты по ссылке скрыты, если их не разрешить явно
+// @Tags share-links
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param input body ShareLinkInput true "Параметры ссылки"
+// @Success 201 {object} models.BoardShareLink "Ссылка создана"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/share-links [post]
+func (h *BoardShareHandler) CreateShareLink(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, true)
+	if !ok {
+		return
+	}
+
+	var input ShareLinkInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	link := &models.BoardShareLink{
+		BoardID:      boardID,
+		ExpiresAt:    input.ExpiresAt,
+		ShowComments: input.ShowComments,
+		ShowEmails:   input.ShowEmails,
+	}
+
+	if err := h.shareService.Create(c.Request.Context(), link, input.Password); err != nil {
+		h.writeError(c, err, "failed to create share link")
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
+// RevokeShareLink godoc
+// @Summary Отозвать публичную ссылку
+// @Description После отзыва снимок доски по ссылке недоступен. Отозванная ссылка остается в списке
+// @Tags share-links
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param link_id path int true "ID ссылки"
+// @Success 200 {object} models.BoardShareLink "Ссылка отозвана"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Ссылка не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/share-links/{link_id} [delete]
+func (h *BoardShareHandler) RevokeShareLink(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, true)
+	if !ok {
+		return
+	}
+
+	linkID, err := strconv.ParseUint(c.Param("link_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid share link ID"})
+		return
+	}
+
+	link, err := h.shareService.Revoke(c.Request.Context(), boardID, uint(linkID))
+	if err != nil {
+		h.writeError(c, err, "failed to revoke share link")
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// GetPublicBoard godoc
+// @Summary Просмотр доски по публичной ссылке
+// @Description Возвращает снимок доски только для чтения. Учетная запись не нужна; пароль защищенной ссылки передается в заголовке X-Share-Password.
+// @Description Пользователю с доступом к доске пароль не нужен. Комментарии и адреса почты скрыты, если ссылка их не показывает
+// @Tags share-links
+// @Produce json
+// @Param token path string true "Токен ссылки"
+// @Param X-Share-Password header string false "Пароль ссылки"
+// @Success 200 {object} service.BoardSnapshot "Снимок доски"
+// @Failure 401 {object} map[string]string "Неверный или отсутствующий пароль"
+// @Failure 404 {object} map[string]string "Ссылка не найдена, отозвана или истекла"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /public/boards/{token} [get]
+func (h *BoardShareHandler) GetPublicBoard(c *gin.Context) {
+	snapshot, err := h.shareService.PublicSnapshot(c.Request.Context(), c.Param("token"), c.GetHeader(SharePasswordHeader))
+	if err != nil {
+		h.writeError(c, err, "failed to get board")
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.JSON(http.StatusOK, snapshot)
+}
+
+func (h *BoardShareHandler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, models.ErrBoardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "board not found"})
+	case errors.Is(err, models.ErrShareLinkNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrSharePasswordInvalid):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case models.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
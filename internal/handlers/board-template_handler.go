@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/service"
+)
+
+type BoardTemplateHandler struct {
+	templateService service.BoardTemplateServiceInterface
+	memberService   service.BoardMemberServiceInterface
+}
+
+func NewBoardTemplateHandler(templateService service.BoardTemplateServiceInterface, memberService service.BoardMemberServiceInterface) *BoardTemplateHandler {
+	return &BoardTemplateHandler{
+		templateService: templateService,
+		memberService:   memberService,
+	}
+}
+
+// CloneBoardInput задает название копии и то, что в нее переносится.
+type CloneBoardInput struct {
+	Title       string `json:"title" example:"Project B"`
+	Description string `json:"description"`
+	WorkspaceID uint   `json:"workspace_id" example:"1"`
+	Columns     bool   `json:"columns" example:"true"`
+	Swimlanes   bool   `json:"swimlanes" example:"true"`
+	Labels      bool   `json:"labels" example:"true"`
+	Cards       bool   `json:"cards" example:"false"`
+	Members     bool   `json:"members" example:"false"`
+}
+
+// SaveTemplateInput представляет входные данные для сохранения доски как шаблона.
+type SaveTemplateInput struct {
+	Name        string `json:"name" example:"Sprint board"`
+	Description string `json:"description"`
+}
+
+// CreateBoardFromTemplateInput представляет входные данные для создания доски из шаблона.
+type CreateBoardFromTemplateInput struct {
+	Title       string `json:"title" example:"Project C"`
+	Description string `json:"description"`
+	WIPMode     string `json:"wip_mode" example:"warn"`
+	WorkspaceID uint   `json:"workspace_id" binding:"required" example:"1"`
+}
+
+// CloneBoard godoc
+// @Summary Скопировать доску
+// @Description Создает новую доску текущего пользователя по образцу существующей в одной транзакции.
+// @Description Карточки копируются только вместе с колонками, исполнитель карточки сохраняется, если у него есть доступ к копии.
+// @Description Без workspace_id копия создается в рабочем пространстве исходной доски
+// @Tags board-templates
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID исходной доски"
+// @Param input body CloneBoardInput true "Параметры копирования"
+// @Success 201 {object} models.Board "Копия создана"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска или рабочее пространство не найдены"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/clone [post]
+func (h *BoardTemplateHandler) CloneBoard(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, false)
+	if !ok {
+		return
+	}
+	userID, _ := c.Get("userID")
+
+	var input CloneBoardInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	board, err := h.templateService.Clone(c.Request.Context(), boardID, userID.(uint), service.CloneOptions{
+		Title:       input.Title,
+		Description: input.Description,
+		WorkspaceID: input.WorkspaceID,
+		Columns:     input.Columns,
+		Swimlanes:   input.Swimlanes,
+		Labels:      input.Labels,
+		Cards:       input.Cards,
+		Members:     input.Members,
+	})
+	if err != nil {
+		h.writeError(c, err, "failed to clone board")
+		return
+	}
+
+	c.JSON(http.StatusCreated, board)
+}
+
+// SaveBoardTemplate godoc
+// @Summary Сохранить доску как шаблон
+// @Description Сохраняет колонки, дорожки и метки доски как шаблон текущего пользователя
+// @Tags board-templates
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param input body SaveTemplateInput true "Название шаблона"
+// @Success 201 {object} models.BoardTemplate "Шаблон сохранен"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/template [post]
+func (h *BoardTemplateHandler) SaveBoardTemplate(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, false)
+	if !ok {
+		return
+	}
+	userID, _ := c.Get("userID")
+
+	var input SaveTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	template, err := h.templateService.SaveTemplate(c.Request.Context(), boardID, userID.(uint), input.Name, input.Description)
+	if err != nil {
+		h.writeError(c, err, "failed to save template")
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// GetBoardTemplates godoc
+// @Summary Получить галерею шаблонов
+// @Description Возвращает встроенные шаблоны (scrum, kanban, bug-triage) и шаблоны текущего пользователя
+// @Tags board-templates
+// @Produce json
+// @Success 200 {array} models.BoardTemplate "Шаблоны"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/board-templates [get]
+func (h *BoardTemplateHandler) GetBoardTemplates(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	templates, err := h.templateService.Gallery(c.Request.Context(), userID.(uint))
+	if err != nil {
+		h.writeError(c, err, "failed to get templates")
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// CreateBoardFromTemplate godoc
+// @Summary Создать доску из шаблона
+// @Description Создает доску текущего пользователя с колонками, дорожками и метками шаблона.
+// @Description template_id — ID сохраненного шаблона или ключ встроенного
+// @Tags board-templates
+// @Accept json
+// @Produce json
+// @Param template_id path string true "ID или ключ шаблона"
+// @Param input body CreateBoardFromTemplateInput true "Параметры доски"
+// @Success 201 {object} models.Board "Доска создана"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 404 {object} map[string]string "Шаблон или рабочее пространство не найдены"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/board-templates/{template_id}/boards [post]
+func (h *BoardTemplateHandler) CreateBoardFromTemplate(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var input CreateBoardFromTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	board := &models.Board{Title: input.Title, Description: input.Description, WIPMode: input.WIPMode, WorkspaceID: input.WorkspaceID}
+	if err := h.templateService.CreateFromTemplate(c.Request.Context(), userID.(uint), c.Param("template_id"), board); err != nil {
+		h.writeError(c, err, "failed to create board")
+		return
+	}
+
+	c.JSON(http.StatusCreated, board)
+}
+
+// DeleteBoardTemplate godoc
+// @Summary Удалить шаблон
+// @Description Удаляет сохраненный шаблон текущего пользователя. Встроенные шаблоны удалить нельзя
+// @Tags board-templates
+// @Produce json
+// @Param template_id path string true "ID шаблона"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Встроенный шаблон"
+// @Failure 404 {object} map[string]string "Шаблон не найден"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/board-templates/{template_id} [delete]
+func (h *BoardTemplateHandler) DeleteBoardTemplate(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(c.Request.Context(), userID.(uint), c.Param("template_id")); err != nil {
+		h.writeError(c, err, "failed to delete template")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *BoardTemplateHandler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, models.ErrBoardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "board not found"})
+	case errors.Is(err, models.ErrTemplateNotFound), errors.Is(err, models.ErrWorkspaceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrUserNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+	case models.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
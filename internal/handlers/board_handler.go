@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
@@ -11,18 +12,26 @@ import (
 )
 
 type BoardHandler struct {
-	boardService service.BoardServiceInterface
+	boardService    service.BoardServiceInterface
+	snapshotService service.BoardSnapshotServiceInterface
+	memberService   service.BoardMemberServiceInterface
 }
 
-func NewBoardHandler(boardService service.BoardServiceInterface) *BoardHandler {
+func NewBoardHandler(
+	boardService service.BoardServiceInterface,
+	snapshotService service.BoardSnapshotServiceInterface,
+	memberService service.BoardMemberServiceInterface,
+) *BoardHandler {
 	return &BoardHandler{
-		boardService: boardService,
+		boardService:    boardService,
+		snapshotService: snapshotService,
+		memberService:   memberService,
 	}
 }
 
 // CreateBoard godoc
 // @Summary Создать доску
-// @Description Создает новую доску для авторизованного пользователя
+// @Description Создает новую доску для авторизованного пользователя в рабочем пространстве workspace_id, в котором он состоит
 // @Tags board
 // @Accept json
 // @Produce json
@@ -30,6 +39,7 @@ func NewBoardHandler(boardService service.BoardServiceInterface) *BoardHandler {
 // @Success 201 {object} models.Board "Доска успешно создана"
 // @Failure 400 {object} map[string]string "Ошибка запроса или пользователь не найден"
 // @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 404 {object} map[string]string "Рабочее пространство не найдено"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /boards [post]
 func (h *BoardHandler) CreateBoard(c *gin.Context) {
@@ -53,6 +63,14 @@ func (h *BoardHandler) CreateBoard(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
 			return
 		}
+		if errors.Is(err, models.ErrWorkspaceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if models.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create board"})
 		return
 	}
@@ -66,7 +84,10 @@ func (h *BoardHandler) CreateBoard(c *gin.Context) {
 // @Tags board
 // @Produce json
 // @Param board_id path int true "ID доски"
+// @Param If-None-Match header string false "ETag полученной ранее версии"
 // @Success 200 {object} models.Board "Доска успешно найдена"
+// @Success 304 "Доска не изменилась"
+// @Header 200 {string} ETag "Версия доски"
 // @Failure 400 {object} map[string]string "Неверный формат ID"
 // @Failure 404 {object} map[string]string "Доска не найдена"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
@@ -88,17 +109,86 @@ func (h *BoardHandler) GetBoard(c *gin.Context) {
 		return
 	}
 
+	h.recordView(c, board.ID)
+	if notModified(c, etag(board.Version)) {
+		return
+	}
 	c.JSON(http.StatusOK, board)
 }
 
+// GetBoardFull godoc
+// @Summary Получить доску целиком
+// @Description Возвращает доску с колонками, дорожками и карточками, а у карточек — метки, исполнителя и число комментариев.
+// @Description Гость доски получает только открытые ему карточки.
+// @Description Параметр fields ограничивает разделы ответа: swimlanes, cards, labels, assignees, comment_counts; доска и колонки возвращаются всегда.
+// @Description ETag меняется при любом изменении содержимого, поэтому повторный запрос с If-None-Match обходится одним запросом к базе
+// @Tags board
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param fields query string false "Разделы через запятую (по умолчанию все)"
+// @Param If-None-Match header string false "ETag полученного ранее снимка"
+// @Success 200 {object} service.BoardSnapshot "Снимок доски"
+// @Success 304 "Доска не изменилась"
+// @Header 200 {string} ETag "Версия снимка"
+// @Failure 400 {object} map[string]string "Неверный формат ID или неизвестный раздел"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/full [get]
+func (h *BoardHandler) GetBoardFull(c *gin.Context) {
+	boardID, ok := authorizeBoardView(c, h.memberService)
+	if !ok {
+		return
+	}
+
+	fields, err := service.ParseSnapshotFields(c.Query("fields"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	revision, err := h.snapshotService.Revision(c.Request.Context(), boardID, fields)
+	if err != nil {
+		h.snapshotError(c, err)
+		return
+	}
+	h.recordView(c, boardID)
+	if notModified(c, `"`+revision+`"`) {
+		return
+	}
+
+	snapshot, err := h.snapshotService.Snapshot(c.Request.Context(), boardID, fields)
+	if err != nil {
+		h.snapshotError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot)
+}
+
+func (h *BoardHandler) snapshotError(c *gin.Context, err error) {
+	if errors.Is(err, models.ErrBoardNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "board not found"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get board"})
+}
+
 // GetUserBoards godoc
 // @Summary Получить доски пользователя
-// @Description Возвращает все доски, принадлежащие авторизованному пользователю
+// @Description Возвращает доски, которыми пользователь владеет или в которых состоит. Архивные доски по умолчанию скрыты.
+// @Description Если есть следующая страница, ее курсор возвращается в заголовке X-Next-Cursor
 // @Tags board
 // @Produce json
+// @Param sort query string false "Сортировка: name (по умолчанию), updated_at или activity"
+// @Param archived query string false "Архивные доски: false (по умолчанию), true — только архивные, all — все"
+// @Param starred query bool false "Только доски со звездочкой"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param limit query int false "Размер страницы (по умолчанию 50, максимум 200)"
 // @Success 200 {array} models.Board "Список досок"
+// @Header 200 {string} X-Next-Cursor "Курсор следующей страницы"
+// @Failure 400 {object} map[string]string "Неверные параметры запроса"
 // @Failure 401 {object} map[string]string "Неавторизованный запрос"
-// @Failure 400 {object} map[string]string "Пользователь не найден"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /boards [get]
 func (h *BoardHandler) GetUserBoards(c *gin.Context) {
@@ -108,32 +198,223 @@ func (h *BoardHandler) GetUserBoards(c *gin.Context) {
 		return
 	}
 
-	boards, err := h.boardService.GetByOwnerID(c.Request.Context(), userID.(uint))
+	listBoards(c, h.boardService, userID.(uint), 0)
+}
+
+// listBoards отдает страницу досок пользователя по параметрам запроса: всех доступных
+// или, если workspaceID задан, досок одного рабочего пространства.
+func listBoards(c *gin.Context, boardService service.BoardServiceInterface, userID, workspaceID uint) {
+	query := service.BoardListQuery{
+		WorkspaceID: workspaceID,
+		Sort:        c.Query("sort"),
+		Starred:     c.Query("starred") == "true",
+		Cursor:      c.Query("cursor"),
+	}
+	switch c.DefaultQuery("archived", "false") {
+	case "false":
+		query.Archived = new(bool)
+	case "true":
+		archived := true
+		query.Archived = &archived
+	case "all":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid archived, must be true, false or all"})
+		return
+	}
+	if limit := c.Query("limit"); limit != "" {
+		var err error
+		if query.Limit, err = strconv.Atoi(limit); err != nil || query.Limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+
+	page, err := boardService.List(c.Request.Context(), userID, query)
 	if err != nil {
-		if errors.Is(err, models.ErrUserNotFound) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		if models.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, models.ErrWorkspaceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get boards"})
 		return
 	}
 
+	if page.NextCursor != "" {
+		c.Header("X-Next-Cursor", page.NextCursor)
+	}
+	c.JSON(http.StatusOK, page.Boards)
+}
+
+// GetRecentBoards godoc
+// @Summary Получить недавние доски
+// @Description Возвращает доски, которые пользователь недавно открывал, начиная с последней. Архивные доски не включаются
+// @Tags board
+// @Produce json
+// @Param limit query int false "Количество (по умолчанию и максимум 20)"
+// @Success 200 {array} models.Board "Недавние доски"
+// @Failure 400 {object} map[string]string "Неверные параметры запроса"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/recent [get]
+func (h *BoardHandler) GetRecentBoards(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var limit int
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+
+	boards, err := h.boardService.GetRecent(c.Request.Context(), userID.(uint), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get recent boards"})
+		return
+	}
+
 	c.JSON(http.StatusOK, boards)
 }
 
+// ArchiveBoard godoc
+// @Summary Архивировать доску
+// @Description Переносит доску в архив. Архивная доска скрыта из списков по умолчанию, ее содержимое доступно только для чтения
+// @Tags board
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param If-Match header string false "ETag доски"
+// @Success 200 {object} models.Board "Доска в архиве"
+// @Header 200 {string} ETag "Новая версия доски"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав администратора доски"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 412 {object} map[string]string "Доска изменена другим запросом"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/archive [post]
+func (h *BoardHandler) ArchiveBoard(c *gin.Context) {
+	h.setArchived(c, h.boardService.Archive)
+}
+
+// UnarchiveBoard godoc
+// @Summary Восстановить доску из архива
+// @Tags board
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param If-Match header string false "ETag доски"
+// @Success 200 {object} models.Board "Доска восстановлена"
+// @Header 200 {string} ETag "Новая версия доски"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав администратора доски"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 412 {object} map[string]string "Доска изменена другим запросом"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/unarchive [post]
+func (h *BoardHandler) UnarchiveBoard(c *gin.Context) {
+	h.setArchived(c, h.boardService.Unarchive)
+}
+
+func (h *BoardHandler) setArchived(c *gin.Context, apply func(ctx context.Context, id uint) (*models.Board, error)) {
+	boardID, ok := authorizeBoard(c, h.memberService, true)
+	if !ok {
+		return
+	}
+
+	board, err := apply(ifMatch(c), boardID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrBoardNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "board not found"})
+		case errors.Is(err, models.ErrVersionConflict):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update board"})
+		}
+		return
+	}
+
+	setETag(c, board.Version)
+	c.JSON(http.StatusOK, board)
+}
+
+// StarBoard godoc
+// @Summary Отметить доску звездочкой
+// @Tags board
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/star [post]
+func (h *BoardHandler) StarBoard(c *gin.Context) {
+	h.setStarred(c, h.boardService.Star)
+}
+
+// UnstarBoard godoc
+// @Summary Снять звездочку с доски
+// @Tags board
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/star [delete]
+func (h *BoardHandler) UnstarBoard(c *gin.Context) {
+	h.setStarred(c, h.boardService.Unstar)
+}
+
+func (h *BoardHandler) setStarred(c *gin.Context, apply func(ctx context.Context, boardID, userID uint) error) {
+	boardID, ok := authorizeBoard(c, h.memberService, false)
+	if !ok {
+		return
+	}
+	userID, _ := c.Get("userID")
+
+	if err := apply(c.Request.Context(), boardID, userID.(uint)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update star"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// recordView запоминает доску в недавних у текущего пользователя.
+func (h *BoardHandler) recordView(c *gin.Context, boardID uint) {
+	if userID, exists := c.Get("userID"); exists {
+		h.boardService.RecordView(c.Request.Context(), boardID, userID.(uint))
+	}
+}
+
 // UpdateBoard godoc
 // @Summary Обновить доску
-// @Description Обновляет данные доски по ID, если пользователь является её владельцем
+// @Description Обновляет данные доски по ID, если пользователь является её владельцем.
+// @Description Другой workspace_id переносит доску в это рабочее пространство; общие метки прежнего пространства снимаются с ее карточек
 // @Tags board
 // @Accept json
 // @Produce json
 // @Param board_id path int true "ID доски"
 // @Param board body models.Board true "Новые данные доски"
+// @Param If-Match header string false "ETag версии, которую изменяет клиент"
 // @Success 200 {object} models.Board "Доска успешно обновлена"
+// @Header 200 {string} ETag "Новая версия доски"
 // @Failure 400 {object} map[string]string "Неверный формат ID или ошибка данных"
 // @Failure 401 {object} map[string]string "Неавторизованный запрос"
 // @Failure 403 {object} map[string]string "Нет прав на обновление доски"
-// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 404 {object} map[string]string "Доска или рабочее пространство не найдены"
+// @Failure 409 {object} map[string]string "Доска в архиве"
+// @Failure 412 {object} map[string]string "Доска изменена другим запросом"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /boards/{board_id} [put]
 func (h *BoardHandler) UpdateBoard(c *gin.Context) {
@@ -174,25 +455,44 @@ func (h *BoardHandler) UpdateBoard(c *gin.Context) {
 
 	input.OwnerID = existingBoard.OwnerID
 
-	if err := h.boardService.Update(c.Request.Context(), &input); err != nil {
+	if err := h.boardService.Update(ifMatch(c), &input); err != nil {
+		if errors.Is(err, models.ErrVersionConflict) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, models.ErrBoardArchived) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, models.ErrWorkspaceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if models.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update board"})
 		return
 	}
 
+	setETag(c, input.Version)
 	c.JSON(http.StatusOK, input)
 }
 
 // DeleteBoard godoc
 // @Summary Удалить доску
-// @Description Удаляет доску по ID, если пользователь является её владельцем
+// @Description Удаляет доску по ID, если пользователь является её владельцем. Колонки, дорожки, карточки и комментарии доски удаляются вместе с ней; доску можно восстановить
 // @Tags board
 // @Produce json
 // @Param board_id path int true "ID доски"
+// @Param If-Match header string false "ETag версии, которую удаляет клиент"
 // @Success 200 {object} map[string]string "Сообщение об успешном удалении"
 // @Failure 400 {object} map[string]string "Неверный формат ID"
 // @Failure 401 {object} map[string]string "Неавторизованный запрос"
 // @Failure 403 {object} map[string]string "Нет прав на удаление доски"
 // @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 412 {object} map[string]string "Доска изменена другим запросом"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /boards/{board_id} [delete]
 func (h *BoardHandler) DeleteBoard(c *gin.Context) {
@@ -223,10 +523,82 @@ func (h *BoardHandler) DeleteBoard(c *gin.Context) {
 		return
 	}
 
-	if err := h.boardService.Delete(c.Request.Context(), uint(id)); err != nil {
+	if err := h.boardService.Delete(ifMatch(c), uint(id)); err != nil {
+		if errors.Is(err, models.ErrVersionConflict) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete board"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "board deleted successfully"})
 }
+
+// RestoreBoard godoc
+// @Summary Восстановить удаленную доску
+// @Description Восстанавливает доску вместе с колонками, дорожками, карточками и комментариями, удаленными вместе с ней. Доступно владельцу и администраторам доски
+// @Tags board
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Success 200 {object} models.Board "Восстановленная доска"
+// @Header 200 {string} ETag "Новая версия доски"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав администратора доски"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/restore [post]
+func (h *BoardHandler) RestoreBoard(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("board_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid board ID"})
+		return
+	}
+
+	board, err := h.boardService.Restore(c.Request.Context(), uint(id))
+	if err != nil {
+		writeDeletedBoardError(c, err, "failed to restore board")
+		return
+	}
+
+	setETag(c, board.Version)
+	c.JSON(http.StatusOK, board)
+}
+
+// PurgeBoard godoc
+// @Summary Удалить доску безвозвратно
+// @Description Безвозвратно удаляет доску (в том числе ранее удаленную) со всеми колонками, дорожками, карточками, комментариями, метками, участниками, вебхуками и уведомлениями. Доступно владельцу и администраторам доски
+// @Tags board
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Success 204 "Доска удалена"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав администратора доски"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/purge [delete]
+func (h *BoardHandler) PurgeBoard(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("board_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid board ID"})
+		return
+	}
+
+	if err := h.boardService.Purge(c.Request.Context(), uint(id)); err != nil {
+		writeDeletedBoardError(c, err, "failed to purge board")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func writeDeletedBoardError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrBoardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "board not found"})
+	case errors.Is(err, models.ErrInsufficientAccess):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
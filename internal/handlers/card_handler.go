@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -23,9 +24,16 @@ func NewCardHandler(cardService service.CardServiceInterface, cardLabelService *
 }
 
 // MoveCardInput представляет входные данные для перемещения карточки.
+// SwimlaneID не задан — карточка остается в своей дорожке, 0 — переходит в дорожку по умолчанию.
 type MoveCardInput struct {
-	ColumnID uint `json:"column_id"`
-	Position int  `json:"position"`
+	ColumnID   uint  `json:"column_id"`
+	SwimlaneID *uint `json:"swimlane_id"`
+	Position   int   `json:"position"`
+}
+
+// ReorderCardsInput представляет все карточки колонки в новом порядке.
+type ReorderCardsInput struct {
+	CardIDs []uint `json:"card_ids"`
 }
 
 // AssignCardInput представляет входной параметр для назначения карточки пользователю.
@@ -57,7 +65,9 @@ type AddLabelInput struct {
 // @Param input body models.Card true "Card data"
 // @Success 201 {object} models.Card
 // @Failure 400 {object} models.ValidationError
+// @Failure 403 {string} string "Board guests cannot create cards"
 // @Failure 404 {string} string
+// @Failure 409 {object} models.WIPLimitError "The column is full and the board blocks WIP limit overruns"
 // @Failure 500 {string} string
 // @Router /api/cards [post]
 func (h *CardHandler) CreateCard(c *gin.Context) {
@@ -81,6 +91,16 @@ func (h *CardHandler) CreateCard(c *gin.Context) {
 	}
 
 	if err := h.cardService.Create(c.Request.Context(), &card); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, err.Error())
+			return
+		}
+
+		if err == models.ErrInsufficientAccess {
+			c.JSON(http.StatusForbidden, err.Error())
+			return
+		}
+
 		if err == models.ErrColumnNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
@@ -91,6 +111,11 @@ func (h *CardHandler) CreateCard(c *gin.Context) {
 			return
 		}
 
+		if wipErr, ok := err.(*models.WIPLimitError); ok {
+			c.JSON(http.StatusConflict, wipErr)
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, "Failed to create card")
 		return
 	}
@@ -104,7 +129,10 @@ func (h *CardHandler) CreateCard(c *gin.Context) {
 // @Tags cards
 // @Produce json
 // @Param id path int true "Card ID"
+// @Param If-None-Match header string false "ETag of a previously received version"
 // @Success 200 {object} models.Card
+// @Success 304 "Not Modified"
+// @Header 200 {string} ETag "Card version"
 // @Failure 404 {string} string
 // @Failure 500 {string} string
 // @Router /api/cards/{id} [get]
@@ -126,6 +154,9 @@ func (h *CardHandler) GetCard(c *gin.Context) {
 		return
 	}
 
+	if notModified(c, etag(card.Version)) {
+		return
+	}
 	c.JSON(http.StatusOK, card)
 }
 
@@ -168,9 +199,13 @@ func (h *CardHandler) GetCardsByColumn(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Card ID"
 // @Param input body models.Card true "Updated card data"
+// @Param If-Match header string false "ETag of the version being updated"
 // @Success 200 {object} models.Card
+// @Header 200 {string} ETag "New card version"
 // @Failure 400 {object} models.ValidationError
 // @Failure 404 {string} string
+// @Failure 409 {object} models.WIPLimitError "The new column is full and the board blocks WIP limit overruns"
+// @Failure 412 {string} string
 // @Failure 500 {string} string
 // @Router /api/cards/{id} [put]
 func (h *CardHandler) UpdateCard(c *gin.Context) {
@@ -190,11 +225,20 @@ func (h *CardHandler) UpdateCard(c *gin.Context) {
 
 	card.ID = uint(id)
 
-	if err := h.cardService.Update(c.Request.Context(), &card); err != nil {
+	if err := h.cardService.Update(ifMatch(c), &card); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, err.Error())
+			return
+		}
+
 		if err == models.ErrCardNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
 		}
+		if err == models.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, err.Error())
+			return
+		}
 		if err == models.ErrColumnNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
@@ -205,10 +249,16 @@ func (h *CardHandler) UpdateCard(c *gin.Context) {
 			return
 		}
 
+		if wipErr, ok := err.(*models.WIPLimitError); ok {
+			c.JSON(http.StatusConflict, wipErr)
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, "Failed to update card")
 		return
 	}
 
+	setETag(c, card.Version)
 	c.JSON(http.StatusOK, card)
 }
 
@@ -218,8 +268,10 @@ func (h *CardHandler) UpdateCard(c *gin.Context) {
 // @Tags cards
 // @Produce json
 // @Param id path int true "Card ID"
+// @Param If-Match header string false "ETag of the version being deleted"
 // @Success 204 "No Content"
 // @Failure 404 {string} string
+// @Failure 412 {string} string
 // @Failure 500 {string} string
 // @Router /api/cards/{id} [delete]
 func (h *CardHandler) DeleteCard(c *gin.Context) {
@@ -230,11 +282,20 @@ func (h *CardHandler) DeleteCard(c *gin.Context) {
 		return
 	}
 
-	if err := h.cardService.Delete(c.Request.Context(), uint(id)); err != nil {
+	if err := h.cardService.Delete(ifMatch(c), uint(id)); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, err.Error())
+			return
+		}
+
 		if err == models.ErrCardNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
 		}
+		if err == models.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, err.Error())
+			return
+		}
 		c.JSON(http.StatusInternalServerError, "Failed to delete card")
 		return
 	}
@@ -244,14 +305,15 @@ func (h *CardHandler) DeleteCard(c *gin.Context) {
 
 // UpdateCardPositions godoc
 // @Summary Update card positions
-// @Description Update the positions of multiple cards within a column
+// @Description Reorder the cards of a column. The list must contain exactly the cards currently in the column
 // @Tags cards
 // @Accept json
 // @Produce json
-// @Param input body []models.Card true "Cards with new positions"
+// @Param input body []models.Card true "All cards of the column in the new order"
 // @Success 204 "No Content"
 // @Failure 400 {object} models.ValidationError
 // @Failure 404 {string} string
+// @Failure 409 {object} models.OrderConflictError
 // @Failure 500 {string} string
 // @Router /api/cards/positions [put]
 func (h *CardHandler) UpdateCardPositions(c *gin.Context) {
@@ -268,21 +330,85 @@ func (h *CardHandler) UpdateCardPositions(c *gin.Context) {
 		return
 	}
 
-	if err := h.cardService.UpdatePositions(c.Request.Context(), cards); err != nil {
-		if err == models.ErrColumnNotFound {
-			c.JSON(http.StatusNotFound, err.Error())
+	columnID := cards[0].ColumnID
+	ids := make([]uint, len(cards))
+	for i, card := range cards {
+		if card.ColumnID != columnID {
+			validErr := models.NewValidationError("column_id", "All cards must belong to the same column")
+			c.JSON(http.StatusBadRequest, validErr)
 			return
 		}
-		c.JSON(http.StatusInternalServerError, "Failed to update card positions")
+		ids[i] = card.ID
+	}
+
+	if _, err := h.cardService.Reorder(c.Request.Context(), columnID, ids); err != nil {
+		h.reorderError(c, err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// ReorderCards godoc
+// @Summary Reorder cards in a column
+// @Description Atomically reorder the cards of a column. The list must contain exactly the cards currently in the column, otherwise 409 is returned with the current order
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param column_id path int true "Column ID"
+// @Param input body ReorderCardsInput true "All card IDs of the column in the new order"
+// @Success 200 {array} models.Card
+// @Failure 400 {object} models.ValidationError
+// @Failure 403 {string} string "Board guests cannot reorder cards"
+// @Failure 404 {string} string
+// @Failure 409 {object} models.OrderConflictError
+// @Failure 500 {string} string
+// @Router /api/columns/{column_id}/cards/order [put]
+func (h *CardHandler) ReorderCards(c *gin.Context) {
+	columnID, err := strconv.ParseUint(c.Param("column_id"), 10, 32)
+	if err != nil {
+		validErr := models.NewValidationError("column_id", "Invalid column ID")
+		c.JSON(http.StatusBadRequest, validErr)
+		return
+	}
+
+	var input ReorderCardsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		validErr := models.NewValidationError("request_body", "Invalid request body")
+		c.JSON(http.StatusBadRequest, validErr)
+		return
+	}
+
+	cards, err := h.cardService.Reorder(c.Request.Context(), uint(columnID), input.CardIDs)
+	if err != nil {
+		h.reorderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cards)
+}
+
+func (h *CardHandler) reorderError(c *gin.Context, err error) {
+	var conflict *models.OrderConflictError
+	switch {
+	case errors.As(err, &conflict):
+		c.JSON(http.StatusConflict, conflict)
+	case models.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, err)
+	case err == models.ErrColumnNotFound:
+		c.JSON(http.StatusNotFound, err.Error())
+	case err == models.ErrBoardArchived:
+		c.JSON(http.StatusConflict, err.Error())
+	case err == models.ErrInsufficientAccess:
+		c.JSON(http.StatusForbidden, err.Error())
+	default:
+		c.JSON(http.StatusInternalServerError, "Failed to reorder cards")
+	}
+}
+
 // MoveCardToColumn godoc
 // @Summary Move a card to another column
-// @Description Move a card to another column with a specific position
+// @Description Move a card to a specific position in the cell of a column and a swimlane
 // @Tags cards
 // @Accept json
 // @Produce json
@@ -291,6 +417,7 @@ func (h *CardHandler) UpdateCardPositions(c *gin.Context) {
 // @Success 204 "No Content"
 // @Failure 400 {object} models.ValidationError
 // @Failure 404 {string} string
+// @Failure 409 {object} models.WIPLimitError "The target column is full and the board blocks WIP limit overruns"
 // @Failure 500 {string} string
 // @Router /api/cards/{id}/move [post]
 func (h *CardHandler) MoveCardToColumn(c *gin.Context) {
@@ -301,11 +428,7 @@ func (h *CardHandler) MoveCardToColumn(c *gin.Context) {
 		return
 	}
 
-	var input struct {
-		ColumnID uint `json:"column_id"`
-		Position int  `json:"position"`
-	}
-
+	var input MoveCardInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		validErr := models.NewValidationError("request_body", "Invalid request body")
 		c.JSON(http.StatusBadRequest, validErr)
@@ -318,7 +441,12 @@ func (h *CardHandler) MoveCardToColumn(c *gin.Context) {
 		return
 	}
 
-	if err := h.cardService.MoveCard(c.Request.Context(), uint(id), input.ColumnID, input.Position); err != nil {
+	if err := h.cardService.MoveCard(c.Request.Context(), uint(id), input.ColumnID, input.SwimlaneID, input.Position); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, err.Error())
+			return
+		}
+
 		if err == models.ErrCardNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
@@ -327,6 +455,14 @@ func (h *CardHandler) MoveCardToColumn(c *gin.Context) {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
 		}
+		if validationErr, ok := err.(*models.ValidationError); ok {
+			c.JSON(http.StatusBadRequest, validationErr)
+			return
+		}
+		if wipErr, ok := err.(*models.WIPLimitError); ok {
+			c.JSON(http.StatusConflict, wipErr)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, "Failed to move card")
 		return
 	}
@@ -372,6 +508,11 @@ func (h *CardHandler) AssignCard(c *gin.Context) {
 	}
 
 	if err := h.cardService.AssignCard(c.Request.Context(), uint(id), input.UserID); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, err.Error())
+			return
+		}
+
 		if err == models.ErrCardNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
@@ -407,6 +548,11 @@ func (h *CardHandler) UnassignCard(c *gin.Context) {
 	}
 
 	if err := h.cardService.UnassignCard(c.Request.Context(), uint(id)); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, err.Error())
+			return
+		}
+
 		if err == models.ErrCardNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
@@ -426,9 +572,11 @@ func (h *CardHandler) UnassignCard(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Card ID"
 // @Param input body UpdateDueDateInput true "Due date (null to remove)"
+// @Param If-Match header string false "ETag of the version being updated"
 // @Success 204 "No Content"
 // @Failure 400 {object} models.ValidationError
 // @Failure 404 {string} string
+// @Failure 412 {string} string
 // @Failure 500 {string} string
 // @Router /api/cards/{id}/due-date [put]
 func (h *CardHandler) UpdateDueDate(c *gin.Context) {
@@ -449,11 +597,20 @@ func (h *CardHandler) UpdateDueDate(c *gin.Context) {
 		return
 	}
 
-	if err := h.cardService.UpdateDueDate(c.Request.Context(), uint(id), input.DueDate); err != nil {
+	if err := h.cardService.UpdateDueDate(ifMatch(c), uint(id), input.DueDate); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, err.Error())
+			return
+		}
+
 		if err == models.ErrCardNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
 		}
+		if err == models.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, err.Error())
+			return
+		}
 
 		if validationErr, ok := err.(*models.ValidationError); ok {
 			c.JSON(http.StatusBadRequest, validationErr)
@@ -505,6 +662,11 @@ func (h *CardHandler) AddLabelToCard(c *gin.Context) {
 	}
 
 	if err := h.cardLabelService.AddLabelToCard(c.Request.Context(), uint(cardID), input.LabelID); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, err.Error())
+			return
+		}
+
 		if err == models.ErrCardNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
@@ -549,6 +711,11 @@ func (h *CardHandler) RemoveLabelFromCard(c *gin.Context) {
 	}
 
 	if err := h.cardLabelService.RemoveLabelFromCard(c.Request.Context(), uint(cardID), uint(labelID)); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, err.Error())
+			return
+		}
+
 		if err == models.ErrCardNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
@@ -633,6 +800,11 @@ func (h *CardHandler) BatchAddLabelsToCard(c *gin.Context) {
 	}
 
 	if err := h.cardLabelService.BatchAddLabelsToCard(c.Request.Context(), uint(cardID), input.LabelIDs); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, err.Error())
+			return
+		}
+
 		if err == models.ErrCardNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
@@ -663,6 +835,11 @@ func (h *CardHandler) RemoveAllLabelsFromCard(c *gin.Context) {
 	}
 
 	if err := h.cardLabelService.RemoveAllLabelsFromCard(c.Request.Context(), uint(cardID)); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, err.Error())
+			return
+		}
+
 		if err == models.ErrCardNotFound {
 			c.JSON(http.StatusNotFound, err.Error())
 			return
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -9,6 +10,11 @@ import (
 	"github.com/octaview/kanban-octaview/internal/service"
 )
 
+// ReorderColumnsInput — все колонки доски в новом порядке.
+type ReorderColumnsInput struct {
+	ColumnIDs []uint `json:"column_ids"`
+}
+
 type ColumnHandler struct {
 	columnService service.ColumnServiceInterface
 }
@@ -49,10 +55,18 @@ func (h *ColumnHandler) CreateColumn(c *gin.Context) {
 	}
 
 	if err := h.columnService.Create(c.Request.Context(), &input); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		if err == models.ErrBoardNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Board not found"})
 			return
 		}
+		if models.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -66,7 +80,10 @@ func (h *ColumnHandler) CreateColumn(c *gin.Context) {
 // @Tags columns
 // @Produce json
 // @Param column_id path int true "ID колонки"
+// @Param If-None-Match header string false "ETag полученной ранее версии"
 // @Success 200 {object} models.Column "Колонка успешно найдена"
+// @Success 304 "Колонка не изменилась"
+// @Header 200 {string} ETag "Версия колонки"
 // @Failure 400 {object} map[string]string "Неверный формат ID колонки"
 // @Failure 404 {object} map[string]string "Колонка не найдена"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
@@ -88,6 +105,9 @@ func (h *ColumnHandler) GetColumn(c *gin.Context) {
 		return
 	}
 
+	if notModified(c, etag(column.Version)) {
+		return
+	}
 	c.JSON(http.StatusOK, column)
 }
 
@@ -131,9 +151,12 @@ func (h *ColumnHandler) GetBoardColumns(c *gin.Context) {
 // @Produce json
 // @Param column_id path int true "ID колонки"
 // @Param column body models.Column true "Новые данные колонки"
+// @Param If-Match header string false "ETag версии, которую изменяет клиент"
 // @Success 200 {object} models.Column "Колонка успешно обновлена"
+// @Header 200 {string} ETag "Новая версия колонки"
 // @Failure 400 {object} map[string]string "Неверный формат ID или входные данные"
 // @Failure 404 {object} map[string]string "Колонка не найдена"
+// @Failure 412 {object} map[string]string "Колонка изменена другим запросом"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /columns/{column_id} [put]
 func (h *ColumnHandler) UpdateColumn(c *gin.Context) {
@@ -151,11 +174,23 @@ func (h *ColumnHandler) UpdateColumn(c *gin.Context) {
 
 	input.ID = uint(id)
 
-	if err := h.columnService.Update(c.Request.Context(), &input); err != nil {
+	if err := h.columnService.Update(ifMatch(c), &input); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		if err == models.ErrColumnNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Column not found"})
 			return
 		}
+		if err == models.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
+		if models.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -166,6 +201,7 @@ func (h *ColumnHandler) UpdateColumn(c *gin.Context) {
 		return
 	}
 
+	setETag(c, updatedColumn.Version)
 	c.JSON(http.StatusOK, updatedColumn)
 }
 
@@ -175,9 +211,11 @@ func (h *ColumnHandler) UpdateColumn(c *gin.Context) {
 // @Tags columns
 // @Produce json
 // @Param column_id path int true "ID колонки"
+// @Param If-Match header string false "ETag версии, которую удаляет клиент"
 // @Success 204 {string} string "Колонка успешно удалена"
 // @Failure 400 {object} map[string]string "Неверный формат ID колонки"
 // @Failure 404 {object} map[string]string "Колонка не найдена"
+// @Failure 412 {object} map[string]string "Колонка изменена другим запросом"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /columns/{column_id} [delete]
 func (h *ColumnHandler) DeleteColumn(c *gin.Context) {
@@ -187,11 +225,19 @@ func (h *ColumnHandler) DeleteColumn(c *gin.Context) {
 		return
 	}
 
-	if err := h.columnService.Delete(c.Request.Context(), uint(id)); err != nil {
+	if err := h.columnService.Delete(ifMatch(c), uint(id)); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		if err == models.ErrColumnNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Column not found"})
 			return
 		}
+		if err == models.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -201,14 +247,15 @@ func (h *ColumnHandler) DeleteColumn(c *gin.Context) {
 
 // UpdateColumnPositions godoc
 // @Summary Обновить позиции колонок
-// @Description Обновляет позиции нескольких колонок в рамках одной доски.
+// @Description Меняет порядок колонок доски. Список должен содержать ровно все колонки доски.
 // @Tags columns
 // @Accept json
 // @Produce json
-// @Param input body []models.Column true "Список колонок с новыми позициями"
+// @Param input body []models.Column true "Все колонки доски в новом порядке"
 // @Success 200 {object} map[string]string "Positions updated successfully"
 // @Failure 400 {object} map[string]string "Неверные входные данные или отсутствуют колонки"
 // @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 409 {object} models.OrderConflictError "Состав колонок доски изменился"
 // @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
 // @Router /api/columns/positions [put]
 func (h *ColumnHandler) UpdateColumnPositions(c *gin.Context) {
@@ -223,14 +270,72 @@ func (h *ColumnHandler) UpdateColumnPositions(c *gin.Context) {
 		return
 	}
 
-	if err := h.columnService.UpdatePositions(c.Request.Context(), columns); err != nil {
-		if err == models.ErrBoardNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Board not found"})
+	boardID := columns[0].BoardID
+	ids := make([]uint, len(columns))
+	for i, column := range columns {
+		if column.BoardID != boardID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "All columns must belong to the same board"})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ids[i] = column.ID
+	}
+
+	if _, err := h.columnService.Reorder(c.Request.Context(), boardID, ids); err != nil {
+		reorderColumnsError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Positions updated successfully"})
 }
+
+// ReorderColumns godoc
+// @Summary Изменить порядок колонок доски
+// @Description Атомарно меняет порядок колонок. Список должен содержать ровно все колонки доски, иначе возвращается 409 с текущим порядком.
+// @Tags columns
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param input body ReorderColumnsInput true "ID всех колонок доски в новом порядке"
+// @Success 200 {array} models.Column "Колонки в новом порядке"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 409 {object} models.OrderConflictError "Состав колонок доски изменился"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /boards/{board_id}/columns/order [put]
+func (h *ColumnHandler) ReorderColumns(c *gin.Context) {
+	boardID, err := strconv.ParseUint(c.Param("board_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid board ID"})
+		return
+	}
+
+	var input ReorderColumnsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	columns, err := h.columnService.Reorder(c.Request.Context(), uint(boardID), input.ColumnIDs)
+	if err != nil {
+		reorderColumnsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, columns)
+}
+
+func reorderColumnsError(c *gin.Context, err error) {
+	var conflict *models.OrderConflictError
+	switch {
+	case errors.As(err, &conflict):
+		c.JSON(http.StatusConflict, conflict)
+	case models.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err == models.ErrBoardNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Board not found"})
+	case err == models.ErrBoardArchived:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
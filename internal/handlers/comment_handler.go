@@ -50,7 +50,9 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 
 	if err := h.commentService.Create(c.Request.Context(), &input); err != nil {
 		statusCode := http.StatusInternalServerError
-		if err == models.ErrCardNotFound {
+		if err == models.ErrBoardArchived {
+			statusCode = http.StatusConflict
+		} else if err == models.ErrCardNotFound {
 			statusCode = http.StatusNotFound
 		} else if err == models.ErrUserNotFound {
 			statusCode = http.StatusNotFound
@@ -72,7 +74,10 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 // @Tags comments
 // @Produce json
 // @Param comment_id path int true "Comment ID"
+// @Param If-None-Match header string false "ETag of a previously received version"
 // @Success 200 {object} models.Comment
+// @Success 304 "Not Modified"
+// @Header 200 {string} ETag "Comment version"
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /api/comments/{comment_id} [get]
@@ -97,6 +102,9 @@ func (h *CommentHandler) GetCommentByID(c *gin.Context) {
 		return
 	}
 
+	if notModified(c, etag(comment.Version)) {
+		return
+	}
 	c.JSON(http.StatusOK, comment)
 }
 
@@ -142,9 +150,12 @@ func (h *CommentHandler) GetCommentsByCard(c *gin.Context) {
 // @Produce json
 // @Param comment_id path int true "Comment ID"
 // @Param input body models.Comment true "Updated comment data"
+// @Param If-Match header string false "ETag of the version being updated"
 // @Success 200 {object} models.Comment
+// @Header 200 {string} ETag "New comment version"
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
+// @Failure 412 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /api/comments/{comment_id} [put]
 func (h *CommentHandler) UpdateComment(c *gin.Context) {
@@ -194,10 +205,14 @@ func (h *CommentHandler) UpdateComment(c *gin.Context) {
 	input.UserID = existingComment.UserID
 	input.CardID = existingComment.CardID
 
-	if err := h.commentService.Update(c.Request.Context(), &input); err != nil {
+	if err := h.commentService.Update(ifMatch(c), &input); err != nil {
 		statusCode := http.StatusInternalServerError
-		if err == models.ErrCommentNotFound {
+		if err == models.ErrBoardArchived {
+			statusCode = http.StatusConflict
+		} else if err == models.ErrCommentNotFound {
 			statusCode = http.StatusNotFound
+		} else if err == models.ErrVersionConflict {
+			statusCode = http.StatusPreconditionFailed
 		} else if models.IsValidationError(err) || models.IsAuthError(err) {
 			statusCode = http.StatusBadRequest
 		} else if models.IsDatabaseError(err) {
@@ -207,19 +222,22 @@ func (h *CommentHandler) UpdateComment(c *gin.Context) {
 		return
 	}
 
+	setETag(c, input.Version)
 	c.JSON(http.StatusOK, input)
 }
 
 // DeleteComment godoc
 // @Summary Delete a comment
-// @Description Delete a comment by ID
+// @Description Soft-delete a comment by ID. The comment stays in the card thread as a tombstone
 // @Tags comments
 // @Produce json
 // @Param comment_id path int true "Comment ID"
+// @Param If-Match header string false "ETag of the version being deleted"
 // @Success 204 "No Content"
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
+// @Failure 412 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /api/comments/{comment_id} [delete]
 func (h *CommentHandler) DeleteComment(c *gin.Context) {
@@ -257,10 +275,14 @@ func (h *CommentHandler) DeleteComment(c *gin.Context) {
 		return
 	}
 
-	if err := h.commentService.Delete(c.Request.Context(), uint(id)); err != nil {
+	if err := h.commentService.Delete(ifMatch(c), uint(id)); err != nil {
 		statusCode := http.StatusInternalServerError
-		if err == models.ErrCommentNotFound {
+		if err == models.ErrBoardArchived {
+			statusCode = http.StatusConflict
+		} else if err == models.ErrCommentNotFound {
 			statusCode = http.StatusNotFound
+		} else if err == models.ErrVersionConflict {
+			statusCode = http.StatusPreconditionFailed
 		} else if models.IsValidationError(err) || models.IsAuthError(err) {
 			statusCode = http.StatusBadRequest
 		} else if models.IsDatabaseError(err) {
@@ -271,4 +293,72 @@ func (h *CommentHandler) DeleteComment(c *gin.Context) {
 	}
 
 	c.Status(http.StatusNoContent)
-}
\ No newline at end of file
+}
+
+// GetCommentRevisions godoc
+// @Summary Get comment edit history
+// @Description Get all revisions of a comment, oldest first. Revisions of a deleted comment are available to board admins only
+// @Tags comments
+// @Produce json
+// @Param comment_id path int true "Comment ID"
+// @Success 200 {array} models.CommentRevision
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /api/comments/{comment_id}/revisions [get]
+func (h *CommentHandler) GetCommentRevisions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("comment_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid comment ID"})
+		return
+	}
+
+	revisions, err := h.commentService.GetRevisions(c.Request.Context(), uint(id))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == models.ErrCommentNotFound {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// PurgeComment godoc
+// @Summary Permanently delete a comment
+// @Description Permanently delete a comment and its edit history. Only board admins can purge comments
+// @Tags comments
+// @Produce json
+// @Param comment_id path int true "Comment ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /api/comments/{comment_id}/purge [delete]
+func (h *CommentHandler) PurgeComment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("comment_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid comment ID"})
+		return
+	}
+
+	if err := h.commentService.Purge(c.Request.Context(), uint(id)); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == models.ErrBoardArchived {
+			statusCode = http.StatusConflict
+		} else if err == models.ErrCommentNotFound || err == models.ErrCardNotFound {
+			statusCode = http.StatusNotFound
+		} else if err == models.ErrInsufficientAccess {
+			statusCode = http.StatusForbidden
+		} else if models.IsAuthError(err) {
+			statusCode = http.StatusUnauthorized
+		}
+		c.JSON(statusCode, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/service"
+)
+
+// ETag ресурса — его версия в кавычках, например "3". Версия увеличивается при каждом изменении.
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", etag(version))
+}
+
+// notModified выставляет ETag и сообщает, совпал ли он с If-None-Match. В этом случае
+// клиенту отвечено 304 и тело писать не нужно.
+func notModified(c *gin.Context, tag string) bool {
+	c.Header("ETag", tag)
+
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		// If-None-Match сравнивается слабо: префикс W/ не учитывается.
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatch возвращает контекст запроса с версиями из заголовка If-Match. Без заголовка
+// и для "*" версия не проверяется. Слабые и некорректные ETag не совпадают ни с одной версией,
+// поэтому запрос с ними завершится 412.
+func ifMatch(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return ctx
+	}
+
+	versions := []int{}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if len(candidate) < 2 || candidate[0] != '"' || candidate[len(candidate)-1] != '"' {
+			continue
+		}
+		version, err := strconv.Atoi(candidate[1 : len(candidate)-1])
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	return service.WithExpectedVersions(ctx, versions)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: "", want: false},
+		{header: `"3"`, want: true},
+		{header: `W/"3"`, want: true},
+		{header: `"1", "3"`, want: true},
+		{header: `*`, want: true},
+		{header: `"4"`, want: false},
+		{header: `3`, want: false},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.header != "" {
+			c.Request.Header.Set("If-None-Match", tt.header)
+		}
+
+		got := notModified(c, etag(3))
+		c.Writer.WriteHeaderNow()
+		if got != tt.want {
+			t.Errorf("If-None-Match %q: notModified = %v, want %v", tt.header, got, tt.want)
+		}
+		if tag := w.Header().Get("ETag"); tag != `"3"` {
+			t.Errorf("If-None-Match %q: ETag = %q, want %q", tt.header, tag, `"3"`)
+		}
+		if wantStatus := map[bool]int{true: http.StatusNotModified, false: http.StatusOK}[tt.want]; w.Code != wantStatus {
+			t.Errorf("If-None-Match %q: status = %d, want %d", tt.header, w.Code, wantStatus)
+		}
+	}
+}
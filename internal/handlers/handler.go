@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/config"
 	"github.com/octaview/kanban-octaview/internal/service"
-	"github.com/octaview/kanban-octaview/internal/repository"
 )
 
 type Handler struct {
@@ -12,32 +12,44 @@ type Handler struct {
 	Board     *BoardHandler
 	Column    *ColumnHandler
 	Card      *CardHandler
+	Swimlane  *SwimlaneHandler
+	Template  *BoardTemplateHandler
+	Share     *BoardShareHandler
+	Workspace *WorkspaceHandler
 	Label     *LabelHandler
 	Comment   *CommentHandler
+	Member    *MemberHandler
+	Watcher   *WatcherHandler
+	Notification *NotificationHandler
+	Webhook   *WebhookHandler
+	Realtime  *RealtimeHandler
+	Presence  *PresenceHandler
 }
 
-func NewHandler(services *service.Services, repos *repository.Repositories) *Handler {
-	cardLabelService := service.NewCardLabelService(
-		repos.CardLabel,
-		repos.Card,
-		repos.Label,
-		repos.Board,
-		repos.Column,
-	)
-
+func NewHandler(services *service.Services, cfg *config.Config) *Handler {
 	return &Handler{
 		Auth:      NewAuthHandler(services.Auth, services.User),
 		User:      NewUserHandler(services.User),
-		Board:     NewBoardHandler(services.Board),
+		Board:     NewBoardHandler(services.Board, services.Snapshot, services.Member),
 		Column:    NewColumnHandler(services.Column),
-		Card:      NewCardHandler(services.Card, cardLabelService),
+		Card:      NewCardHandler(services.Card, services.CardLabel),
+		Swimlane:  NewSwimlaneHandler(services.Swimlane, services.Member),
+		Template:  NewBoardTemplateHandler(services.Template, services.Member),
+		Share:     NewBoardShareHandler(services.Share, services.Member),
+		Workspace: NewWorkspaceHandler(services.Workspace, services.Board, services.Label),
 		Label:     NewLabelHandler(services.Label),
 		Comment:   NewCommentHandler(services.Comment), // Initialize CommentHandler
+		Member:    NewMemberHandler(services.Member),
+		Watcher:   NewWatcherHandler(services.Watcher),
+		Notification: NewNotificationHandler(services.Notification),
+		Webhook:   NewWebhookHandler(services.Webhook, services.Member),
+		Realtime:  NewRealtimeHandler(services.Hub, services.BoardEvents, services.Presence, services.Member, cfg.HTTP.AllowedOrigins),
+		Presence:  NewPresenceHandler(services.Presence, services.Member),
 		// Initialize other handlers
 	}
 }
 
-func (h *Handler) InitRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc) {
+func (h *Handler) InitRoutes(router *gin.Engine, authMiddleware, optionalAuth gin.HandlerFunc) {
     // Public routes remain unchanged
     auth := router.Group("/auth")
     {
@@ -47,6 +59,12 @@ func (h *Handler) InitRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc)
         auth.GET("/me", authMiddleware, h.Auth.GetMe)
     }
 
+    // Public read-only board snapshots by share token
+    public := router.Group("/public", optionalAuth)
+    {
+        public.GET("/boards/:token", h.Share.GetPublicBoard)
+    }
+
     // Protected routes
     api := router.Group("/api", authMiddleware)
     {
@@ -62,6 +80,7 @@ func (h *Handler) InitRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc)
         {
             boards.POST("", h.Board.CreateBoard)
             boards.GET("", h.Board.GetUserBoards)
+            boards.GET("/recent", h.Board.GetRecentBoards)
             
             // Individual board operations
             boardID := boards.Group("/:board_id")  // Changed from ":id" to ":board_id"
@@ -69,12 +88,78 @@ func (h *Handler) InitRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc)
                 boardID.GET("", h.Board.GetBoard)
                 boardID.PUT("", h.Board.UpdateBoard)
                 boardID.DELETE("", h.Board.DeleteBoard)
+                boardID.POST("/restore", h.Board.RestoreBoard)
+                boardID.DELETE("/purge", h.Board.PurgeBoard)
+                boardID.GET("/full", h.Board.GetBoardFull)
+                boardID.POST("/archive", h.Board.ArchiveBoard)
+                boardID.POST("/unarchive", h.Board.UnarchiveBoard)
+                boardID.POST("/star", h.Board.StarBoard)
+                boardID.DELETE("/star", h.Board.UnstarBoard)
+                boardID.POST("/clone", h.Template.CloneBoard)
+                boardID.POST("/template", h.Template.SaveBoardTemplate)
                 
                 // Now using ":board_id" consistently
                 boardID.GET("/columns", h.Column.GetBoardColumns)
+                boardID.PUT("/columns/order", h.Column.ReorderColumns)
+
+                boardID.GET("/swimlanes", h.Swimlane.GetBoardSwimlanes)
+                boardID.POST("/swimlanes", h.Swimlane.CreateSwimlane)
+                boardID.PUT("/swimlanes/order", h.Swimlane.ReorderSwimlanes)
+                boardID.PUT("/swimlanes/:swimlane_id", h.Swimlane.UpdateSwimlane)
+                boardID.DELETE("/swimlanes/:swimlane_id", h.Swimlane.DeleteSwimlane)
+                boardID.GET("/lanes", h.Swimlane.GetBoardLanes)
+
+                boardID.GET("/members", h.Member.GetBoardMembers)
+                boardID.POST("/members", h.Member.AddBoardMember)
+                boardID.PUT("/members/:user_id", h.Member.UpdateBoardMemberRole)
+                boardID.DELETE("/members/:user_id", h.Member.RemoveBoardMember)
+                boardID.GET("/guests", h.Member.GetBoardGuests)
+                boardID.PUT("/guests/:user_id/cards/:card_id", h.Member.AddGuestCard)
+                boardID.DELETE("/guests/:user_id/cards/:card_id", h.Member.RemoveGuestCard)
+
+                boardID.GET("/webhooks", h.Webhook.GetBoardWebhooks)
+                boardID.POST("/webhooks", h.Webhook.CreateWebhook)
+                boardID.GET("/webhooks/:webhook_id", h.Webhook.GetWebhook)
+                boardID.PUT("/webhooks/:webhook_id", h.Webhook.UpdateWebhook)
+                boardID.DELETE("/webhooks/:webhook_id", h.Webhook.DeleteWebhook)
+                boardID.GET("/webhooks/:webhook_id/deliveries", h.Webhook.GetWebhookDeliveries)
+                boardID.POST("/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", h.Webhook.RedeliverWebhook)
+
+                boardID.GET("/share-links", h.Share.GetShareLinks)
+                boardID.POST("/share-links", h.Share.CreateShareLink)
+                boardID.DELETE("/share-links/:link_id", h.Share.RevokeShareLink)
+
+                boardID.GET("/ws", h.Realtime.BoardSocket)
+                boardID.GET("/events", h.Realtime.BoardEvents)
+
+                boardID.GET("/presence", h.Presence.GetBoardPresence)
+                boardID.PUT("/presence", h.Presence.UpdatePresence)
+                boardID.DELETE("/presence", h.Presence.LeaveBoard)
             }
         }
         
+        templates := api.Group("/board-templates")
+        {
+            templates.GET("", h.Template.GetBoardTemplates)
+            templates.POST("/:template_id/boards", h.Template.CreateBoardFromTemplate)
+            templates.DELETE("/:template_id", h.Template.DeleteBoardTemplate)
+        }
+
+        workspaces := api.Group("/workspaces")
+        {
+            workspaces.GET("", h.Workspace.GetWorkspaces)
+            workspaces.POST("", h.Workspace.CreateWorkspace)
+            workspaces.GET("/:workspace_id", h.Workspace.GetWorkspace)
+            workspaces.PUT("/:workspace_id", h.Workspace.UpdateWorkspace)
+            workspaces.DELETE("/:workspace_id", h.Workspace.DeleteWorkspace)
+            workspaces.GET("/:workspace_id/members", h.Workspace.GetWorkspaceMembers)
+            workspaces.POST("/:workspace_id/members", h.Workspace.AddWorkspaceMember)
+            workspaces.PUT("/:workspace_id/members/:user_id", h.Workspace.UpdateWorkspaceMemberRole)
+            workspaces.DELETE("/:workspace_id/members/:user_id", h.Workspace.RemoveWorkspaceMember)
+            workspaces.GET("/:workspace_id/boards", h.Workspace.GetWorkspaceBoards)
+            workspaces.GET("/:workspace_id/labels", h.Workspace.GetWorkspaceLabels)
+        }
+
         columns := api.Group("/columns")
         {
             columns.POST("", h.Column.CreateColumn)
@@ -85,6 +170,7 @@ func (h *Handler) InitRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc)
             
             // Column cards routes - already using specific parameter name
             columns.GET("/:column_id/cards", h.Card.GetCardsByColumn)
+            columns.PUT("/:column_id/cards/order", h.Card.ReorderCards)
         }
 
         // Rest of the routes remain unchanged
@@ -111,6 +197,12 @@ func (h *Handler) InitRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc)
             
             // Card comments - add routes for comments
             cards.GET("/:card_id/comments", h.Comment.GetCommentsByCard)
+
+            // Card watchers
+            cards.GET("/watched", h.Watcher.GetWatchedCards)
+            cards.GET("/:card_id/watchers", h.Watcher.GetCardWatchers)
+            cards.POST("/:card_id/watch", h.Watcher.WatchCard)
+            cards.DELETE("/:card_id/watch", h.Watcher.UnwatchCard)
         }
         
         labels := api.Group("/labels")
@@ -119,6 +211,7 @@ func (h *Handler) InitRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc)
             labels.GET("/:label_id", h.Label.GetLabel)
             labels.PUT("/:label_id", h.Label.UpdateLabel)
             labels.DELETE("/:label_id", h.Label.DeleteLabel)
+            labels.PUT("/:label_id/share", h.Label.ShareLabel)
         }
         
         // Add comment routes
@@ -128,6 +221,21 @@ func (h *Handler) InitRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc)
             comments.GET("/:comment_id", h.Comment.GetCommentByID)
             comments.PUT("/:comment_id", h.Comment.UpdateComment)
             comments.DELETE("/:comment_id", h.Comment.DeleteComment)
+            comments.GET("/:comment_id/revisions", h.Comment.GetCommentRevisions)
+            comments.DELETE("/:comment_id/purge", h.Comment.PurgeComment)
+        }
+
+        notifications := api.Group("/notifications")
+        {
+            notifications.GET("", h.Notification.GetNotifications)
+            notifications.GET("/unread-count", h.Notification.GetUnreadCount)
+            notifications.POST("/read-all", h.Notification.MarkAllNotificationsRead)
+            notifications.POST("/:notification_id/read", h.Notification.MarkNotificationRead)
+            notifications.POST("/:notification_id/unread", h.Notification.MarkNotificationUnread)
+            notifications.GET("/preferences", h.Notification.GetNotificationPreferences)
+            notifications.PUT("/preferences", h.Notification.UpdateNotificationPreferences)
+            notifications.GET("/email-preferences", h.Notification.GetEmailPreferences)
+            notifications.PUT("/email-preferences", h.Notification.UpdateEmailPreferences)
         }
     }
 }
\ No newline at end of file
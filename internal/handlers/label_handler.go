@@ -27,6 +27,14 @@ type LabelResponse struct {
 	Name    string `json:"name" example:"Bug"`
 	Color   string `json:"color" example:"#FF0000"`
 	BoardID uint   `json:"board_id" example:"1"`
+	Version int    `json:"version" example:"1"`
+	// WorkspaceID is set for labels shared with every board of the workspace
+	WorkspaceID *uint `json:"workspace_id,omitempty" example:"1"`
+}
+
+// ShareLabelRequest represents the request body for sharing a label with the workspace
+type ShareLabelRequest struct {
+	Shared bool `json:"shared" example:"true"`
 }
 
 // LabelsResponse represents the response for multiple labels
@@ -68,15 +76,21 @@ func (h *LabelHandler) CreateLabel(c *gin.Context) {
 	}
 
 	if err := h.labelService.Create(c.Request.Context(), &label); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusCreated, LabelResponse{
-		ID:      label.ID,
-		Name:    label.Name,
-		Color:   label.Color,
-		BoardID: label.BoardID,
+		ID:          label.ID,
+		Name:        label.Name,
+		Color:       label.Color,
+		BoardID:     label.BoardID,
+		Version:     label.Version,
+		WorkspaceID: label.WorkspaceID,
 	})
 }
 
@@ -86,7 +100,10 @@ func (h *LabelHandler) CreateLabel(c *gin.Context) {
 // @Tags labels
 // @Produce json
 // @Param label_id path int true "Label ID"
+// @Param If-None-Match header string false "ETag of a previously received version"
 // @Success 200 {object} LabelResponse
+// @Success 304 "Not Modified"
+// @Header 200 {string} ETag "Label version"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
@@ -109,17 +126,22 @@ func (h *LabelHandler) GetLabel(c *gin.Context) {
 		return
 	}
 
+	if notModified(c, etag(label.Version)) {
+		return
+	}
 	c.JSON(http.StatusOK, LabelResponse{
-		ID:      label.ID,
-		Name:    label.Name,
-		Color:   label.Color,
-		BoardID: label.BoardID,
+		ID:          label.ID,
+		Name:        label.Name,
+		Color:       label.Color,
+		BoardID:     label.BoardID,
+		Version:     label.Version,
+		WorkspaceID: label.WorkspaceID,
 	})
 }
 
 // GetBoardLabels godoc
 // @Summary Get labels by board ID
-// @Description Get all labels for a specific board
+// @Description Get all labels for a specific board, including labels shared with its workspace
 // @Tags labels
 // @Produce json
 // @Param board_id path int true "Board ID"
@@ -149,10 +171,12 @@ func (h *LabelHandler) GetBoardLabels(c *gin.Context) {
 	response := make(LabelsResponse, 0, len(labels))
 	for _, label := range labels {
 		response = append(response, LabelResponse{
-			ID:      label.ID,
-			Name:    label.Name,
-			Color:   label.Color,
-			BoardID: label.BoardID,
+			ID:          label.ID,
+			Name:        label.Name,
+			Color:       label.Color,
+			BoardID:     label.BoardID,
+			Version:     label.Version,
+			WorkspaceID: label.WorkspaceID,
 		})
 	}
 
@@ -167,10 +191,13 @@ func (h *LabelHandler) GetBoardLabels(c *gin.Context) {
 // @Produce json
 // @Param label_id path int true "Label ID"
 // @Param request body LabelRequest true "Label information"
+// @Param If-Match header string false "ETag of the version being updated"
 // @Success 200 {object} LabelResponse
+// @Header 200 {string} ETag "New label version"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/labels/{label_id} [put]
 func (h *LabelHandler) UpdateLabel(c *gin.Context) {
@@ -193,20 +220,31 @@ func (h *LabelHandler) UpdateLabel(c *gin.Context) {
 		BoardID: labelReq.BoardID,
 	}
 
-	if err := h.labelService.Update(c.Request.Context(), &label); err != nil {
+	if err := h.labelService.Update(ifMatch(c), &label); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+			return
+		}
 		if err.Error() == "record not found" {
 			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Label not found"})
 			return
 		}
+		if err == models.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
 
+	setETag(c, label.Version)
 	c.JSON(http.StatusOK, LabelResponse{
-		ID:      label.ID,
-		Name:    label.Name,
-		Color:   label.Color,
-		BoardID: label.BoardID,
+		ID:          label.ID,
+		Name:        label.Name,
+		Color:       label.Color,
+		BoardID:     label.BoardID,
+		Version:     label.Version,
+		WorkspaceID: label.WorkspaceID,
 	})
 }
 
@@ -216,10 +254,12 @@ func (h *LabelHandler) UpdateLabel(c *gin.Context) {
 // @Tags labels
 // @Produce json
 // @Param label_id path int true "Label ID"
+// @Param If-Match header string false "ETag of the version being deleted"
 // @Success 204 "No Content"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/labels/{label_id} [delete]
 func (h *LabelHandler) DeleteLabel(c *gin.Context) {
@@ -229,14 +269,82 @@ func (h *LabelHandler) DeleteLabel(c *gin.Context) {
 		return
 	}
 
-	if err := h.labelService.Delete(c.Request.Context(), uint(id)); err != nil {
+	if err := h.labelService.Delete(ifMatch(c), uint(id)); err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+			return
+		}
 		if err.Error() == "record not found" {
 			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Label not found"})
 			return
 		}
+		if err == models.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// ShareLabel godoc
+// @Summary Share label with workspace
+// @Description Share a label with every board of its board's workspace, or make it a board label again. Unsharing removes the label from cards of other boards
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Param label_id path int true "Label ID"
+// @Param request body ShareLabelRequest true "Sharing state"
+// @Param If-Match header string false "ETag of the version being updated"
+// @Success 200 {object} LabelResponse
+// @Header 200 {string} ETag "New label version"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/labels/{label_id}/share [put]
+func (h *LabelHandler) ShareLabel(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("label_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid label ID"})
+		return
+	}
+
+	var shareReq ShareLabelRequest
+	if err := c.ShouldBindJSON(&shareReq); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	label, err := h.labelService.Share(ifMatch(c), uint(id), shareReq.Shared)
+	if err != nil {
+		if err == models.ErrBoardArchived {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+			return
+		}
+		if err == models.ErrLabelNotFound || err == models.ErrBoardNotFound {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Label not found"})
+			return
+		}
+		if err == models.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	setETag(c, label.Version)
+	c.JSON(http.StatusOK, LabelResponse{
+		ID:          label.ID,
+		Name:        label.Name,
+		Color:       label.Color,
+		BoardID:     label.BoardID,
+		Version:     label.Version,
+		WorkspaceID: label.WorkspaceID,
+	})
+}
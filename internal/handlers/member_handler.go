@@ -0,0 +1,348 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/service"
+)
+
+type MemberHandler struct {
+	memberService service.BoardMemberServiceInterface
+}
+
+func NewMemberHandler(memberService service.BoardMemberServiceInterface) *MemberHandler {
+	return &MemberHandler{
+		memberService: memberService,
+	}
+}
+
+// AddMemberInput представляет входные данные для добавления участника доски.
+type AddMemberInput struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	Role   string `json:"role" example:"member"`
+}
+
+// UpdateMemberRoleInput представляет входные данные для изменения роли участника.
+type UpdateMemberRoleInput struct {
+	Role string `json:"role" binding:"required" example:"admin"`
+}
+
+// GetBoardMembers godoc
+// @Summary Получить участников доски
+// @Description Возвращает участников доски (без владельца)
+// @Tags members
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Success 200 {array} models.BoardMember "Список участников"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/members [get]
+func (h *MemberHandler) GetBoardMembers(c *gin.Context) {
+	boardID, ok := h.authorize(c, false)
+	if !ok {
+		return
+	}
+
+	members, err := h.memberService.GetMembers(c.Request.Context(), boardID)
+	if err != nil {
+		if errors.Is(err, models.ErrBoardNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "board not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get board members"})
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// AddBoardMember godoc
+// @Summary Добавить участника доски
+// @Description Добавляет пользователя на доску. Доступно владельцу и администраторам доски.
+// @Description Роль guest дает доступ только к карточкам, открытым гостю через /guests/{user_id}/cards/{card_id}
+// @Tags members
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param input body AddMemberInput true "Пользователь и роль"
+// @Success 201 {object} models.BoardMember "Участник добавлен"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Доска или пользователь не найдены"
+// @Failure 409 {object} map[string]string "Пользователь уже участник доски"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/members [post]
+func (h *MemberHandler) AddBoardMember(c *gin.Context) {
+	boardID, ok := h.authorize(c, true)
+	if !ok {
+		return
+	}
+
+	var input AddMemberInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := h.memberService.AddMember(c.Request.Context(), boardID, input.UserID, input.Role)
+	if err != nil {
+		h.writeError(c, err, "failed to add board member")
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// UpdateBoardMemberRole godoc
+// @Summary Изменить роль участника
+// @Description Изменяет роль участника доски. Доступно владельцу и администраторам доски
+// @Tags members
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param user_id path int true "ID пользователя"
+// @Param input body UpdateMemberRoleInput true "Новая роль"
+// @Success 200 {object} map[string]string "Роль обновлена"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Участник не найден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/members/{user_id} [put]
+func (h *MemberHandler) UpdateBoardMemberRole(c *gin.Context) {
+	boardID, ok := h.authorize(c, true)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	var input UpdateMemberRoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.memberService.UpdateRole(c.Request.Context(), boardID, uint(userID), input.Role); err != nil {
+		h.writeError(c, err, "failed to update board member role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "board member role updated successfully"})
+}
+
+// RemoveBoardMember godoc
+// @Summary Удалить участника доски
+// @Description Удаляет пользователя из участников доски. Доступно владельцу и администраторам доски
+// @Tags members
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param user_id path int true "ID пользователя"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Участник не найден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/members/{user_id} [delete]
+func (h *MemberHandler) RemoveBoardMember(c *gin.Context) {
+	boardID, ok := h.authorize(c, true)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	if err := h.memberService.RemoveMember(c.Request.Context(), boardID, uint(userID)); err != nil {
+		h.writeError(c, err, "failed to remove board member")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetBoardGuests godoc
+// @Summary Получить гостей доски
+// @Description Возвращает гостей доски и для каждого — карточки, которые он видит. Доступно владельцу и администраторам доски
+// @Tags members
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Success 200 {array} service.GuestAccess "Гости и их карточки"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/guests [get]
+func (h *MemberHandler) GetBoardGuests(c *gin.Context) {
+	boardID, ok := h.authorize(c, true)
+	if !ok {
+		return
+	}
+
+	guests, err := h.memberService.GetGuests(c.Request.Context(), boardID)
+	if err != nil {
+		h.writeError(c, err, "failed to get board guests")
+		return
+	}
+
+	c.JSON(http.StatusOK, guests)
+}
+
+// AddGuestCard godoc
+// @Summary Открыть карточку гостю
+// @Description Делает карточку доски видимой гостю. Повторный вызов ничего не меняет. Доступно владельцу и администраторам доски
+// @Tags members
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param user_id path int true "ID гостя"
+// @Param card_id path int true "ID карточки"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверный формат ID или пользователь не гость"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Участник или карточка не найдены"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/guests/{user_id}/cards/{card_id} [put]
+func (h *MemberHandler) AddGuestCard(c *gin.Context) {
+	boardID, userID, cardID, ok := h.parseGuestCard(c)
+	if !ok {
+		return
+	}
+
+	if err := h.memberService.AddGuestCard(c.Request.Context(), boardID, userID, cardID); err != nil {
+		h.writeError(c, err, "failed to add guest card")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveGuestCard godoc
+// @Summary Закрыть карточку от гостя
+// @Description Убирает карточку из видимых гостю. Доступно владельцу и администраторам доски
+// @Tags members
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param user_id path int true "ID гостя"
+// @Param card_id path int true "ID карточки"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверный формат ID или пользователь не гость"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Участник не найден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/guests/{user_id}/cards/{card_id} [delete]
+func (h *MemberHandler) RemoveGuestCard(c *gin.Context) {
+	boardID, userID, cardID, ok := h.parseGuestCard(c)
+	if !ok {
+		return
+	}
+
+	if err := h.memberService.RemoveGuestCard(c.Request.Context(), boardID, userID, cardID); err != nil {
+		h.writeError(c, err, "failed to remove guest card")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *MemberHandler) parseGuestCard(c *gin.Context) (uint, uint, uint, bool) {
+	boardID, ok := h.authorize(c, true)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return 0, 0, 0, false
+	}
+
+	cardID, err := strconv.ParseUint(c.Param("card_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card ID"})
+		return 0, 0, 0, false
+	}
+
+	return boardID, uint(userID), uint(cardID), true
+}
+
+func (h *MemberHandler) authorize(c *gin.Context, adminOnly bool) (uint, bool) {
+	return authorizeBoard(c, h.memberService, adminOnly)
+}
+
+// authorizeBoard разбирает board_id и проверяет, что текущий пользователь имеет доступ к доске
+// (или права администратора, если adminOnly). Гости доски доступа не получают.
+func authorizeBoard(c *gin.Context, memberService service.BoardMemberServiceInterface, adminOnly bool) (uint, bool) {
+	check := memberService.HasAccess
+	if adminOnly {
+		check = memberService.IsAdmin
+	}
+	return authorizeBoardWith(c, check)
+}
+
+// authorizeBoardView пускает и гостей доски: ответ должен содержать только открытые им карточки.
+func authorizeBoardView(c *gin.Context, memberService service.BoardMemberServiceInterface) (uint, bool) {
+	return authorizeBoardWith(c, memberService.CanView)
+}
+
+func authorizeBoardWith(c *gin.Context, check func(ctx context.Context, boardID, userID uint) (bool, error)) (uint, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return 0, false
+	}
+
+	boardID, err := strconv.ParseUint(c.Param("board_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid board ID"})
+		return 0, false
+	}
+
+	allowed, err := check(c.Request.Context(), uint(boardID), userID.(uint))
+	if err != nil {
+		if errors.Is(err, models.ErrBoardNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "board not found"})
+			return 0, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check board access"})
+		return 0, false
+	}
+
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you don't have permission to manage this board"})
+		return 0, false
+	}
+
+	return uint(boardID), true
+}
+
+func (h *MemberHandler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, models.ErrBoardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "board not found"})
+	case errors.Is(err, models.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	case errors.Is(err, models.ErrMemberNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrMemberAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidBoardRole), errors.Is(err, models.ErrNotBoardGuest):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrCardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
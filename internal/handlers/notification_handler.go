@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+	"github.com/octaview/kanban-octaview/internal/service"
+)
+
+type NotificationHandler struct {
+	notificationService service.NotificationServiceInterface
+}
+
+func NewNotificationHandler(notificationService service.NotificationServiceInterface) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// UnreadCountResponse содержит количество непрочитанных уведомлений.
+type UnreadCountResponse struct {
+	UnreadCount int64 `json:"unread_count" example:"3"`
+}
+
+// NotificationPreferencesInput содержит список отключенных уведомлений.
+// Правило с type отключает тип уведомлений, с board_id — уведомления по доске.
+type NotificationPreferencesInput struct {
+	Muted []models.NotificationPreference `json:"muted"`
+}
+
+// GetNotifications godoc
+// @Summary Получить уведомления
+// @Description Возвращает входящие уведомления текущего пользователя, новые сначала. С group=card уведомления группируются по карточкам
+// @Tags notifications
+// @Produce json
+// @Param unread query bool false "Только непрочитанные"
+// @Param group query string false "Группировка (card)"
+// @Param limit query int false "Количество (по умолчанию 50, максимум 200)"
+// @Param offset query int false "Смещение"
+// @Success 200 {array} models.Notification "Список уведомлений"
+// @Failure 400 {object} map[string]string "Неверные параметры запроса"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	filter := repository.NotificationFilter{
+		UnreadOnly: c.Query("unread") == "true",
+	}
+
+	var err error
+	if limit := c.Query("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if filter.Offset, err = strconv.Atoi(offset); err != nil || filter.Offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+			return
+		}
+	}
+
+	switch c.Query("group") {
+	case "":
+		notifications, err := h.notificationService.GetInbox(c.Request.Context(), userID.(uint), filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get notifications"})
+			return
+		}
+		c.JSON(http.StatusOK, notifications)
+	case "card":
+		groups, err := h.notificationService.GetInboxGrouped(c.Request.Context(), userID.(uint), filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get notifications"})
+			return
+		}
+		c.JSON(http.StatusOK, groups)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group, must be card"})
+	}
+}
+
+// GetUnreadCount godoc
+// @Summary Количество непрочитанных уведомлений
+// @Description Возвращает количество непрочитанных уведомлений текущего пользователя
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} UnreadCountResponse "Количество непрочитанных"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/notifications/unread-count [get]
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	count, err := h.notificationService.CountUnread(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, UnreadCountResponse{UnreadCount: count})
+}
+
+// MarkNotificationRead godoc
+// @Summary Отметить уведомление прочитанным
+// @Tags notifications
+// @Produce json
+// @Param notification_id path int true "ID уведомления"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 404 {object} map[string]string "Уведомление не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/notifications/{notification_id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	h.setRead(c, true)
+}
+
+// MarkNotificationUnread godoc
+// @Summary Отметить уведомление непрочитанным
+// @Tags notifications
+// @Produce json
+// @Param notification_id path int true "ID уведомления"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 404 {object} map[string]string "Уведомление не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/notifications/{notification_id}/unread [post]
+func (h *NotificationHandler) MarkNotificationUnread(c *gin.Context) {
+	h.setRead(c, false)
+}
+
+func (h *NotificationHandler) setRead(c *gin.Context, read bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("notification_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification ID"})
+		return
+	}
+
+	if read {
+		err = h.notificationService.MarkRead(c.Request.Context(), uint(id), userID.(uint))
+	} else {
+		err = h.notificationService.MarkUnread(c.Request.Context(), uint(id), userID.(uint))
+	}
+	if err != nil {
+		if errors.Is(err, models.ErrNotificationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "notification not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update notification"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Отметить все уведомления прочитанными
+// @Tags notifications
+// @Produce json
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/notifications/read-all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if _, err := h.notificationService.MarkAllRead(c.Request.Context(), userID.(uint)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update notifications"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetNotificationPreferences godoc
+// @Summary Получить настройки уведомлений
+// @Description Возвращает отключенные типы уведомлений и доски
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} NotificationPreferencesInput "Настройки уведомлений"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/notifications/preferences [get]
+func (h *NotificationHandler) GetNotificationPreferences(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	prefs, err := h.notificationService.GetPreferences(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get notification preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, NotificationPreferencesInput{Muted: prefs})
+}
+
+// UpdateNotificationPreferences godoc
+// @Summary Обновить настройки уведомлений
+// @Description Заменяет список отключенных типов уведомлений и досок
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param input body NotificationPreferencesInput true "Настройки уведомлений"
+// @Success 200 {object} NotificationPreferencesInput "Настройки обновлены"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/notifications/preferences [put]
+func (h *NotificationHandler) UpdateNotificationPreferences(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var input NotificationPreferencesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.notificationService.UpdatePreferences(c.Request.Context(), userID.(uint), input.Muted); err != nil {
+		if models.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update notification preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, input)
+}
+
+// GetEmailPreferences godoc
+// @Summary Получить настройки писем
+// @Description Возвращает, приходят ли письма о назначениях и упоминаниях сразу и как часто отправляется дайджест
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} models.EmailPreference "Настройки писем"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/notifications/email-preferences [get]
+func (h *NotificationHandler) GetEmailPreferences(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	pref, err := h.notificationService.GetEmailPreference(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get email preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, pref)
+}
+
+// UpdateEmailPreferences godoc
+// @Summary Обновить настройки писем
+// @Description Включает или отключает немедленные письма и задает периодичность дайджеста (none, daily, weekly)
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param input body models.EmailPreference true "Настройки писем"
+// @Success 200 {object} models.EmailPreference "Настройки обновлены"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/notifications/email-preferences [put]
+func (h *NotificationHandler) UpdateEmailPreferences(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var input models.EmailPreference
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.notificationService.UpdateEmailPreference(c.Request.Context(), userID.(uint), &input); err != nil {
+		if models.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update email preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, input)
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/service"
+)
+
+type PresenceHandler struct {
+	presenceService service.PresenceServiceInterface
+	memberService   service.BoardMemberServiceInterface
+}
+
+func NewPresenceHandler(presenceService service.PresenceServiceInterface, memberService service.BoardMemberServiceInterface) *PresenceHandler {
+	return &PresenceHandler{
+		presenceService: presenceService,
+		memberService:   memberService,
+	}
+}
+
+// PresenceInput представляет heartbeat сессии. Пустой card_id означает, что пользователь
+// только просматривает доску.
+type PresenceInput struct {
+	SessionID string `json:"session_id" binding:"required" example:"3f2c9a"`
+	CardID    *uint  `json:"card_id" example:"42"`
+}
+
+// GetBoardPresence godoc
+// @Summary Кто сейчас на доске
+// @Description Возвращает активные сессии доски: пользователя и карточку, которую он редактирует.
+// @Description Изменения приходят в realtime-каналы событиями presence.joined, presence.updated и presence.left.
+// @Tags presence
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Success 200 {array} models.BoardPresence "Активные сессии"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/presence [get]
+func (h *PresenceHandler) GetBoardPresence(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, false)
+	if !ok {
+		return
+	}
+
+	presences, err := h.presenceService.Snapshot(c.Request.Context(), boardID)
+	if err != nil {
+		h.writeError(c, err, "failed to get board presence")
+		return
+	}
+
+	c.JSON(http.StatusOK, presences)
+}
+
+// UpdatePresence godoc
+// @Summary Heartbeat присутствия
+// @Description Отмечает сессию на доске и задает карточку, которую пользователь редактирует.
+// @Description Сессия истекает через 60 секунд без heartbeat. Клиенты WebSocket и SSE отмечаются автоматически,
+// @Description а для SSE редактируемую карточку задают этим запросом с тем же session_id, что и у потока.
+// @Tags presence
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param input body PresenceInput true "Сессия и редактируемая карточка"
+// @Success 200 {object} models.BoardPresence "Сессия отмечена"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска или карточка не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/presence [put]
+func (h *PresenceHandler) UpdatePresence(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, false)
+	if !ok {
+		return
+	}
+	userID := c.MustGet("userID").(uint)
+
+	var input PresenceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	presence, err := h.presenceService.Touch(c.Request.Context(), boardID, userID, input.SessionID, input.CardID)
+	if err != nil {
+		h.writeError(c, err, "failed to update presence")
+		return
+	}
+
+	c.JSON(http.StatusOK, presence)
+}
+
+// LeaveBoard godoc
+// @Summary Покинуть доску
+// @Description Снимает отметку сессии, например при закрытии вкладки.
+// @Tags presence
+// @Param board_id path int true "ID доски"
+// @Param session_id query string true "ID сессии"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/presence [delete]
+func (h *PresenceHandler) LeaveBoard(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, false)
+	if !ok {
+		return
+	}
+	userID := c.MustGet("userID").(uint)
+
+	sessionID := c.Query("session_id")
+	if sessionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "session_id is required"})
+		return
+	}
+
+	if err := h.presenceService.Leave(c.Request.Context(), boardID, userID, sessionID); err != nil {
+		h.writeError(c, err, "failed to leave board")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PresenceHandler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, models.ErrCardNotFound), errors.Is(err, models.ErrColumnNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case models.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
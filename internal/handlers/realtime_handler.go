@@ -0,0 +1,412 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/service"
+	"github.com/octaview/kanban-octaview/pkg/websocket"
+)
+
+const (
+	wsSendBuffer   = 64
+	wsReadLimit    = 4 << 10
+	wsWriteTimeout = 10 * time.Second
+	wsPongTimeout  = 60 * time.Second
+	wsPingInterval = 25 * time.Second
+
+	sseSendBuffer  = 256
+	sseKeepAlive   = 20 * time.Second
+	sseRetryMillis = 3000
+
+	presenceLeaveTimeout = 5 * time.Second
+)
+
+type RealtimeHandler struct {
+	hub             *service.BoardHub
+	eventService    service.BoardEventServiceInterface
+	presenceService service.PresenceServiceInterface
+	memberService   service.BoardMemberServiceInterface
+	upgrader        websocket.Upgrader
+}
+
+func NewRealtimeHandler(
+	hub *service.BoardHub,
+	eventService service.BoardEventServiceInterface,
+	presenceService service.PresenceServiceInterface,
+	memberService service.BoardMemberServiceInterface,
+	allowedOrigins []string,
+) *RealtimeHandler {
+	return &RealtimeHandler{
+		hub:             hub,
+		eventService:    eventService,
+		presenceService: presenceService,
+		memberService:   memberService,
+		upgrader:        websocket.Upgrader{CheckOrigin: websocket.AllowOrigins(allowedOrigins...)},
+	}
+}
+
+// RealtimeMessage — служебное сообщение канала. События доски передаются в формате service.BoardEvent,
+// тип события указан в поле type (например, card.moved).
+type RealtimeMessage struct {
+	Type      string `json:"type" example:"subscribed"`
+	BoardID   uint   `json:"board_id,omitempty" example:"1"`
+	SessionID string `json:"session_id,omitempty" example:"3f2c9a"`
+	CardID    *uint  `json:"card_id,omitempty" example:"42"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BoardSocket godoc
+// @Summary WebSocket с событиями доски
+// @Description Открывает WebSocket и передает изменения карточек, колонок, меток и комментариев доски в виде JSON-сообщений.
+// @Description Первым приходит сообщение {"type":"subscribed"}, затем события с полями id, type, board_id, actor_id, occurred_at, data.
+// @Description Сервер отправляет ping каждые 25 секунд и закрывает соединение, если клиент не отвечает 60 секунд.
+// @Description Клиент может отправить {"type":"ping"} и получить {"type":"pong"}. Клиент, не успевающий читать события, отключается с кодом 1008.
+// @Description Соединение отмечает пользователя на доске на время подключения (session_id приходит в сообщении subscribed).
+// @Description Сообщение {"type":"presence","card_id":42} сообщает, что пользователь редактирует карточку, без card_id — что закончил.
+// @Description Если браузер не позволяет передать заголовок Authorization, токен передается параметром access_token.
+// @Description Подключение со страниц других сайтов (заголовок Origin) отклоняется с кодом 403, если сайт не указан в HTTP_ALLOWED_ORIGINS.
+// @Tags realtime
+// @Param board_id path int true "ID доски"
+// @Param session_id query string false "ID сессии присутствия; по умолчанию создается новый"
+// @Param access_token query string false "JWT-токен"
+// @Success 101 {object} service.BoardEvent "Соединение переключено на WebSocket"
+// @Failure 400 {object} map[string]string "Неверный запрос"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к доске или запрос с чужого сайта"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Router /api/boards/{board_id}/ws [get]
+func (h *RealtimeHandler) BoardSocket(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, false)
+	if !ok {
+		return
+	}
+	userID := c.MustGet("userID").(uint)
+	ctx := c.Request.Context()
+
+	sessionID, ok := presenceSessionID(c)
+	if !ok {
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	sub := h.hub.Subscribe(boardID, userID, wsSendBuffer)
+	defer sub.Close()
+
+	presence := &socketPresence{
+		ctx:       ctx,
+		service:   h.presenceService,
+		boardID:   boardID,
+		userID:    userID,
+		sessionID: sessionID,
+	}
+	presence.refresh()
+	defer h.leave(ctx, boardID, userID, sessionID)
+
+	replies := make(chan RealtimeMessage, 1)
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		readSocket(conn, replies, presence)
+	}()
+
+	writeSocket(conn, sub, sessionID, replies, readerDone)
+}
+
+// BoardEvents godoc
+// @Summary Поток событий доски (SSE)
+// @Description Server-Sent Events с теми же событиями, что и WebSocket. Поле id события — его порядковый номер seq,
+// @Description имя события — тип (например, card.moved). При переподключении заголовок Last-Event-ID (или параметр last_event_id)
+// @Description позволяет получить пропущенные события. Если их уже нет в журнале, приходит событие reset: доску нужно загрузить заново.
+// @Description Клиент, не успевающий читать события, отключается и может переподключиться с Last-Event-ID.
+// @Description Поток отмечает пользователя на доске; события присутствия приходят без id и не восстанавливаются из журнала.
+// @Description Редактируемую карточку задают запросом PUT /api/boards/{board_id}/presence с session_id потока.
+// @Tags realtime
+// @Produce text/event-stream
+// @Param board_id path int true "ID доски"
+// @Param session_id query string false "ID сессии присутствия; по умолчанию создается новый"
+// @Param Last-Event-ID header int false "Номер последнего полученного события"
+// @Param last_event_id query int false "Номер последнего полученного события"
+// @Param access_token query string false "JWT-токен"
+// @Success 200 {object} service.BoardEvent "Поток событий"
+// @Failure 400 {object} map[string]string "Неверный запрос"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/events [get]
+func (h *RealtimeHandler) BoardEvents(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, false)
+	if !ok {
+		return
+	}
+	userID := c.MustGet("userID").(uint)
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var lastSeq uint64
+	if lastEventID != "" {
+		seq, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Last-Event-ID"})
+			return
+		}
+		lastSeq = seq
+	}
+
+	sessionID, ok := presenceSessionID(c)
+	if !ok {
+		return
+	}
+
+	// Подписка оформляется до чтения журнала, чтобы не потерять события между ними.
+	sub := h.hub.Subscribe(boardID, userID, sseSendBuffer)
+	defer sub.Close()
+
+	var replay *service.EventReplay
+	if lastSeq > 0 {
+		var err error
+		replay, err = h.eventService.Replay(c.Request.Context(), boardID, lastSeq)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to replay board events"})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.presenceService.Refresh(ctx, boardID, userID, sessionID); err != nil {
+		slog.ErrorContext(ctx, "failed to register board presence", slog.Any("error", err))
+	}
+	defer h.leave(ctx, boardID, userID, sessionID)
+
+	c.Header("Content-Type", sse.ContentType)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	c.Render(-1, sse.Event{
+		Event: "subscribed",
+		Retry: sseRetryMillis,
+		Data:  RealtimeMessage{Type: "subscribed", BoardID: boardID, SessionID: sessionID},
+	})
+	if replay != nil {
+		if replay.Reset {
+			lastSeq = replay.LatestSeq
+			c.Render(-1, sse.Event{
+				Event: "reset",
+				Id:    strconv.FormatUint(lastSeq, 10),
+				Data:  RealtimeMessage{Type: "reset", BoardID: boardID},
+			})
+		}
+		for _, event := range replay.Events {
+			writeStreamEvent(c, event)
+			lastSeq = event.Seq
+		}
+	}
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event := <-sub.Events():
+			// Эфемерные события (Seq == 0) передаются всегда. Остальные могли уже прийти
+			// из журнала или повторно из outbox.
+			if event.Seq == 0 {
+				writeStreamEvent(c, event)
+				c.Writer.Flush()
+				continue
+			}
+			if event.Seq <= lastSeq {
+				continue
+			}
+			writeStreamEvent(c, event)
+			c.Writer.Flush()
+			lastSeq = event.Seq
+		case <-ticker.C:
+			_, _ = c.Writer.WriteString(": keepalive\n\n")
+			c.Writer.Flush()
+			if _, err := h.presenceService.Refresh(ctx, boardID, userID, sessionID); err != nil {
+				slog.ErrorContext(ctx, "failed to refresh board presence", slog.Any("error", err))
+			}
+		case <-sub.Done():
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+func writeStreamEvent(c *gin.Context, event service.BoardEvent) {
+	var id string
+	if event.Seq > 0 {
+		id = strconv.FormatUint(event.Seq, 10)
+	}
+	c.Render(-1, sse.Event{
+		Event: event.Type,
+		Id:    id,
+		Data:  event,
+	})
+}
+
+// presenceSessionID возвращает ID сессии из параметра session_id или создает новый.
+func presenceSessionID(c *gin.Context) (string, bool) {
+	sessionID := c.Query("session_id")
+	if sessionID == "" {
+		b := make([]byte, 16)
+		_, _ = rand.Read(b)
+		return hex.EncodeToString(b), true
+	}
+	if len(sessionID) > 64 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "session_id must be at most 64 characters long"})
+		return "", false
+	}
+	return sessionID, true
+}
+
+// leave снимает отметку присутствия после отключения клиента. Контекст запроса к этому
+// моменту может быть отменен, поэтому используется отдельный дедлайн.
+func (h *RealtimeHandler) leave(ctx context.Context, boardID, userID uint, sessionID string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), presenceLeaveTimeout)
+	defer cancel()
+
+	if err := h.presenceService.Leave(ctx, boardID, userID, sessionID); err != nil {
+		slog.ErrorContext(ctx, "failed to remove board presence", slog.Any("error", err))
+	}
+}
+
+// socketPresence — присутствие пользователя, подключенного по WebSocket. Используется
+// только из горутины чтения.
+type socketPresence struct {
+	ctx       context.Context
+	service   service.PresenceServiceInterface
+	boardID   uint
+	userID    uint
+	sessionID string
+}
+
+func (p *socketPresence) refresh() {
+	if _, err := p.service.Refresh(p.ctx, p.boardID, p.userID, p.sessionID); err != nil {
+		slog.ErrorContext(p.ctx, "failed to refresh board presence", slog.Any("error", err))
+	}
+}
+
+func (p *socketPresence) edit(cardID *uint) error {
+	_, err := p.service.Touch(p.ctx, p.boardID, p.userID, p.sessionID, cardID)
+	return err
+}
+
+// readSocket читает сообщения клиента, пока соединение живо. Каждое сообщение или pong
+// продлевает дедлайн чтения, pong также продлевает присутствие.
+func readSocket(conn *websocket.Conn, replies chan<- RealtimeMessage, presence *socketPresence) {
+	conn.SetReadLimit(wsReadLimit)
+	extend := func() {
+		_ = conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	}
+	extend()
+	conn.SetPongHandler(func() {
+		extend()
+		presence.refresh()
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		extend()
+
+		var message RealtimeMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			continue
+		}
+		var reply *RealtimeMessage
+		switch message.Type {
+		case "ping":
+			reply = &RealtimeMessage{Type: "pong"}
+		case "presence":
+			if err := presence.edit(message.CardID); err != nil {
+				reply = &RealtimeMessage{Type: "error", Error: presenceErrorText(err)}
+			}
+		}
+		if reply != nil {
+			select {
+			case replies <- *reply:
+			default:
+			}
+		}
+	}
+}
+
+func presenceErrorText(err error) string {
+	switch {
+	case errors.Is(err, models.ErrCardNotFound), errors.Is(err, models.ErrColumnNotFound), models.IsValidationError(err):
+		return err.Error()
+	default:
+		return "failed to update presence"
+	}
+}
+
+func writeSocket(conn *websocket.Conn, sub *service.BoardSubscription, sessionID string, replies <-chan RealtimeMessage, readerDone <-chan struct{}) {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+
+	if err := writeSocketJSON(conn, RealtimeMessage{Type: "subscribed", BoardID: sub.BoardID, SessionID: sessionID}); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case event := <-sub.Events():
+			if err := writeSocketJSON(conn, event); err != nil {
+				return
+			}
+		case reply := <-replies:
+			if err := writeSocketJSON(conn, reply); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		case <-sub.Done():
+			if sub.Dropped() {
+				_ = conn.WriteClose(websocket.ClosePolicyViolation, "client is too slow")
+			} else {
+				_ = conn.WriteClose(websocket.CloseGoingAway, "server is shutting down")
+			}
+			return
+		case <-readerDone:
+			return
+		}
+	}
+}
+
+func writeSocketJSON(conn *websocket.Conn, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
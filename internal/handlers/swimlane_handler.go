@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/service"
+)
+
+type SwimlaneHandler struct {
+	swimlaneService service.SwimlaneServiceInterface
+	memberService   service.BoardMemberServiceInterface
+}
+
+func NewSwimlaneHandler(swimlaneService service.SwimlaneServiceInterface, memberService service.BoardMemberServiceInterface) *SwimlaneHandler {
+	return &SwimlaneHandler{
+		swimlaneService: swimlaneService,
+		memberService:   memberService,
+	}
+}
+
+// SwimlaneInput представляет входные данные для создания и изменения дорожки.
+type SwimlaneInput struct {
+	Title string `json:"title" example:"Expedite"`
+}
+
+// ReorderSwimlanesInput — все дорожки доски в новом порядке.
+type ReorderSwimlanesInput struct {
+	SwimlaneIDs []uint `json:"swimlane_ids"`
+}
+
+// GetBoardSwimlanes godoc
+// @Summary Получить дорожки доски
+// @Tags swimlanes
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Success 200 {array} models.Swimlane "Дорожки в порядке отображения"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/swimlanes [get]
+func (h *SwimlaneHandler) GetBoardSwimlanes(c *gin.Context) {
+	boardID, ok := authorizeBoardView(c, h.memberService)
+	if !ok {
+		return
+	}
+
+	swimlanes, err := h.swimlaneService.GetByBoardID(c.Request.Context(), boardID)
+	if err != nil {
+		h.writeError(c, err, "failed to get swimlanes")
+		return
+	}
+
+	c.JSON(http.StatusOK, swimlanes)
+}
+
+// CreateSwimlane godoc
+// @Summary Создать дорожку
+// @Description Добавляет дорожку в конец доски
+// @Tags swimlanes
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param input body SwimlaneInput true "Параметры дорожки"
+// @Success 201 {object} models.Swimlane "Дорожка создана"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/swimlanes [post]
+func (h *SwimlaneHandler) CreateSwimlane(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, false)
+	if !ok {
+		return
+	}
+
+	var input SwimlaneInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	swimlane := &models.Swimlane{BoardID: boardID, Title: input.Title}
+	if err := h.swimlaneService.Create(c.Request.Context(), swimlane); err != nil {
+		h.writeError(c, err, "failed to create swimlane")
+		return
+	}
+
+	setETag(c, swimlane.Version)
+	c.JSON(http.StatusCreated, swimlane)
+}
+
+// UpdateSwimlane godoc
+// @Summary Обновить дорожку
+// @Description Переименовывает дорожку. С заголовком If-Match изменение применяется, только если версия дорожки совпадает
+// @Tags swimlanes
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param swimlane_id path int true "ID дорожки"
+// @Param If-Match header string false "ETag дорожки"
+// @Param input body SwimlaneInput true "Параметры дорожки"
+// @Success 200 {object} models.Swimlane "Дорожка обновлена"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Дорожка не найдена"
+// @Failure 412 {object} map[string]string "Дорожка изменена другим запросом"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/swimlanes/{swimlane_id} [put]
+func (h *SwimlaneHandler) UpdateSwimlane(c *gin.Context) {
+	boardID, swimlaneID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	var input SwimlaneInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	swimlane := &models.Swimlane{ID: swimlaneID, BoardID: boardID, Title: input.Title}
+	if err := h.swimlaneService.Update(ifMatch(c), swimlane); err != nil {
+		h.writeError(c, err, "failed to update swimlane")
+		return
+	}
+
+	setETag(c, swimlane.Version)
+	c.JSON(http.StatusOK, swimlane)
+}
+
+// DeleteSwimlane godoc
+// @Summary Удалить дорожку
+// @Description Удаляет дорожку. Ее карточки остаются в своих колонках и переходят в дорожку по умолчанию
+// @Tags swimlanes
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param swimlane_id path int true "ID дорожки"
+// @Param If-Match header string false "ETag дорожки"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Дорожка не найдена"
+// @Failure 412 {object} map[string]string "Дорожка изменена другим запросом"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/swimlanes/{swimlane_id} [delete]
+func (h *SwimlaneHandler) DeleteSwimlane(c *gin.Context) {
+	boardID, swimlaneID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.swimlaneService.Delete(ifMatch(c), boardID, swimlaneID); err != nil {
+		h.writeError(c, err, "failed to delete swimlane")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReorderSwimlanes godoc
+// @Summary Изменить порядок дорожек доски
+// @Description Атомарно меняет порядок дорожек. Список должен содержать ровно все дорожки доски, иначе возвращается 409 с текущим порядком.
+// @Tags swimlanes
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param input body ReorderSwimlanesInput true "ID всех дорожек доски в новом порядке"
+// @Success 200 {array} models.Swimlane "Дорожки в новом порядке"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 409 {object} models.OrderConflictError "Состав дорожек доски изменился"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/swimlanes/order [put]
+func (h *SwimlaneHandler) ReorderSwimlanes(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, false)
+	if !ok {
+		return
+	}
+
+	var input ReorderSwimlanesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+		return
+	}
+
+	swimlanes, err := h.swimlaneService.Reorder(c.Request.Context(), boardID, input.SwimlaneIDs)
+	if err != nil {
+		h.writeError(c, err, "failed to reorder swimlanes")
+		return
+	}
+
+	c.JSON(http.StatusOK, swimlanes)
+}
+
+// GetBoardLanes godoc
+// @Summary Получить карточки доски по дорожкам
+// @Description Возвращает колонки доски и ее дорожки, в каждой дорожке — ячейки всех колонок с карточками.
+// @Description Карточки без дорожки лежат в дорожке по умолчанию (swimlane равен null), она идет последней
+// @Tags swimlanes
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Success 200 {object} service.BoardLanes "Карточки по дорожкам и колонкам"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет доступа к доске"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/lanes [get]
+func (h *SwimlaneHandler) GetBoardLanes(c *gin.Context) {
+	boardID, ok := authorizeBoardView(c, h.memberService)
+	if !ok {
+		return
+	}
+
+	lanes, err := h.swimlaneService.GetBoardLanes(c.Request.Context(), boardID)
+	if err != nil {
+		h.writeError(c, err, "failed to get board lanes")
+		return
+	}
+
+	c.JSON(http.StatusOK, lanes)
+}
+
+func (h *SwimlaneHandler) parseIDs(c *gin.Context) (uint, uint, bool) {
+	boardID, ok := authorizeBoard(c, h.memberService, false)
+	if !ok {
+		return 0, 0, false
+	}
+
+	swimlaneID, err := strconv.ParseUint(c.Param("swimlane_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid swimlane ID"})
+		return 0, 0, false
+	}
+
+	return boardID, uint(swimlaneID), true
+}
+
+func (h *SwimlaneHandler) writeError(c *gin.Context, err error, fallback string) {
+	var conflict *models.OrderConflictError
+	switch {
+	case errors.As(err, &conflict):
+		c.JSON(http.StatusConflict, conflict)
+	case errors.Is(err, models.ErrBoardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "board not found"})
+	case errors.Is(err, models.ErrSwimlaneNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrBoardArchived):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case models.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/service"
+)
+
+type WatcherHandler struct {
+	watcherService service.CardWatcherServiceInterface
+}
+
+func NewWatcherHandler(watcherService service.CardWatcherServiceInterface) *WatcherHandler {
+	return &WatcherHandler{
+		watcherService: watcherService,
+	}
+}
+
+// WatchCard godoc
+// @Summary Watch a card
+// @Description Subscribe the current user to changes of a card
+// @Tags cards,watchers
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ValidationError
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Router /api/cards/{card_id}/watch [post]
+func (h *WatcherHandler) WatchCard(c *gin.Context) {
+	cardID, err := strconv.ParseUint(c.Param("card_id"), 10, 32)
+	if err != nil {
+		validErr := models.NewValidationError("card_id", "Invalid card ID")
+		c.JSON(http.StatusBadRequest, validErr)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.watcherService.Watch(c.Request.Context(), uint(cardID), userID.(uint)); err != nil {
+		if err == models.ErrCardNotFound {
+			c.JSON(http.StatusNotFound, err.Error())
+			return
+		}
+		c.JSON(http.StatusInternalServerError, "Failed to watch card")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnwatchCard godoc
+// @Summary Unwatch a card
+// @Description Unsubscribe the current user from changes of a card
+// @Tags cards,watchers
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ValidationError
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Router /api/cards/{card_id}/watch [delete]
+func (h *WatcherHandler) UnwatchCard(c *gin.Context) {
+	cardID, err := strconv.ParseUint(c.Param("card_id"), 10, 32)
+	if err != nil {
+		validErr := models.NewValidationError("card_id", "Invalid card ID")
+		c.JSON(http.StatusBadRequest, validErr)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.watcherService.Unwatch(c.Request.Context(), uint(cardID), userID.(uint)); err != nil {
+		if err == models.ErrCardNotFound || err == models.ErrNotWatching {
+			c.JSON(http.StatusNotFound, err.Error())
+			return
+		}
+		c.JSON(http.StatusInternalServerError, "Failed to unwatch card")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCardWatchers godoc
+// @Summary Get card watchers
+// @Description Get all users watching a card
+// @Tags cards,watchers
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Success 200 {array} models.CardWatcher
+// @Failure 400 {object} models.ValidationError
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Router /api/cards/{card_id}/watchers [get]
+func (h *WatcherHandler) GetCardWatchers(c *gin.Context) {
+	cardID, err := strconv.ParseUint(c.Param("card_id"), 10, 32)
+	if err != nil {
+		validErr := models.NewValidationError("card_id", "Invalid card ID")
+		c.JSON(http.StatusBadRequest, validErr)
+		return
+	}
+
+	watchers, err := h.watcherService.GetWatchers(c.Request.Context(), uint(cardID))
+	if err != nil {
+		if err == models.ErrCardNotFound {
+			c.JSON(http.StatusNotFound, err.Error())
+			return
+		}
+		c.JSON(http.StatusInternalServerError, "Failed to get card watchers")
+		return
+	}
+
+	c.JSON(http.StatusOK, watchers)
+}
+
+// GetWatchedCards godoc
+// @Summary Get watched cards
+// @Description Get all cards the current user is watching, most recently updated first
+// @Tags cards,watchers
+// @Produce json
+// @Success 200 {array} models.Card
+// @Failure 401 {string} string
+// @Failure 500 {string} string
+// @Router /api/cards/watched [get]
+func (h *WatcherHandler) GetWatchedCards(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	cards, err := h.watcherService.GetWatchedCards(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, "Failed to get watched cards")
+		return
+	}
+
+	c.JSON(http.StatusOK, cards)
+}
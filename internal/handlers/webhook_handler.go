@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/service"
+)
+
+type WebhookHandler struct {
+	webhookService service.WebhookServiceInterface
+	memberService  service.BoardMemberServiceInterface
+}
+
+func NewWebhookHandler(webhookService service.WebhookServiceInterface, memberService service.BoardMemberServiceInterface) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		memberService:  memberService,
+	}
+}
+
+// WebhookInput представляет входные данные для создания и изменения вебхука.
+type WebhookInput struct {
+	URL    string   `json:"url" binding:"required" example:"https://example.com/hooks/kanban"`
+	Secret string   `json:"secret" example:"s3cr3t"`
+	Events []string `json:"events" example:"card.created,card.moved"`
+	Active *bool    `json:"active"`
+}
+
+// WebhookWithSecret возвращается при создании вебхука: секрет показывается только один раз.
+type WebhookWithSecret struct {
+	models.Webhook
+	Secret string `json:"secret"`
+}
+
+// GetBoardWebhooks godoc
+// @Summary Получить вебхуки доски
+// @Description Возвращает вебхуки доски. Доступно владельцу и администраторам доски
+// @Tags webhooks
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Success 200 {array} models.Webhook "Список вебхуков"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/webhooks [get]
+func (h *WebhookHandler) GetBoardWebhooks(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, true)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.webhookService.GetByBoardID(c.Request.Context(), boardID)
+	if err != nil {
+		h.writeError(c, err, "failed to get webhooks")
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// CreateWebhook godoc
+// @Summary Создать вебхук
+// @Description Подписывает URL на события доски. Пустой список events означает все события.
+// @Description Запросы подписываются заголовком X-Octaview-Signature: t=<unix>,v1=<HMAC-SHA256 от "<unix>.<тело>">
+// @Description URL не может указывать на локальные, link-local и частные адреса: такие доставки отклоняются
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param input body WebhookInput true "Параметры вебхука"
+// @Success 201 {object} WebhookWithSecret "Вебхук создан"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Доска не найдена"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	boardID, ok := authorizeBoard(c, h.memberService, true)
+	if !ok {
+		return
+	}
+
+	var input WebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook := &models.Webhook{
+		BoardID: boardID,
+		URL:     input.URL,
+		Secret:  input.Secret,
+		Events:  input.Events,
+	}
+
+	if err := h.webhookService.Create(c.Request.Context(), webhook); err != nil {
+		h.writeError(c, err, "failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusCreated, WebhookWithSecret{Webhook: *webhook, Secret: webhook.Secret})
+}
+
+// GetWebhook godoc
+// @Summary Получить вебхук
+// @Tags webhooks
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param webhook_id path int true "ID вебхука"
+// @Success 200 {object} models.Webhook "Вебхук"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Вебхук не найден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/webhooks/{webhook_id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	boardID, webhookID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.GetByID(c.Request.Context(), boardID, webhookID)
+	if err != nil {
+		h.writeError(c, err, "failed to get webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary Обновить вебхук
+// @Description Меняет адрес, фильтр событий, секрет (если передан) и состояние вебхука.
+// @Description Повторное включение отключенного вебхука сбрасывает счетчик неудач
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param webhook_id path int true "ID вебхука"
+// @Param input body WebhookInput true "Параметры вебхука"
+// @Success 200 {object} models.Webhook "Вебхук обновлен"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Вебхук не найден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/webhooks/{webhook_id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	boardID, webhookID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	var input WebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook := &models.Webhook{
+		ID:      webhookID,
+		BoardID: boardID,
+		URL:     input.URL,
+		Secret:  input.Secret,
+		Events:  input.Events,
+		Active:  input.Active == nil || *input.Active,
+	}
+
+	if err := h.webhookService.Update(c.Request.Context(), webhook); err != nil {
+		h.writeError(c, err, "failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Удалить вебхук
+// @Description Удаляет вебхук вместе с журналом доставок
+// @Tags webhooks
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param webhook_id path int true "ID вебхука"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Вебхук не найден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/webhooks/{webhook_id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	boardID, webhookID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.Delete(c.Request.Context(), boardID, webhookID); err != nil {
+		h.writeError(c, err, "failed to delete webhook")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Журнал доставок вебхука
+// @Description Возвращает последние доставки вебхука, новые сначала
+// @Tags webhooks
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param webhook_id path int true "ID вебхука"
+// @Param limit query int false "Количество (по умолчанию 50, максимум 200)"
+// @Success 200 {array} models.WebhookDelivery "Доставки"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Вебхук не найден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/webhooks/{webhook_id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	boardID, webhookID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	var limit int
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(c.Request.Context(), boardID, webhookID, limit)
+	if err != nil {
+		h.writeError(c, err, "failed to get webhook deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// RedeliverWebhook godoc
+// @Summary Повторить доставку
+// @Description Ставит в очередь повторную отправку события с тем же телом
+// @Tags webhooks
+// @Produce json
+// @Param board_id path int true "ID доски"
+// @Param webhook_id path int true "ID вебхука"
+// @Param delivery_id path int true "ID доставки"
+// @Success 202 {object} models.WebhookDelivery "Доставка поставлена в очередь"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 403 {object} map[string]string "Нет прав на управление доской"
+// @Failure 404 {object} map[string]string "Вебхук или доставка не найдены"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/boards/{board_id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) RedeliverWebhook(c *gin.Context) {
+	boardID, webhookID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := strconv.ParseUint(c.Param("delivery_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid delivery ID"})
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(c.Request.Context(), boardID, webhookID, uint(deliveryID))
+	if err != nil {
+		h.writeError(c, err, "failed to redeliver webhook")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+func (h *WebhookHandler) parseIDs(c *gin.Context) (uint, uint, bool) {
+	boardID, ok := authorizeBoard(c, h.memberService, true)
+	if !ok {
+		return 0, 0, false
+	}
+
+	webhookID, err := strconv.ParseUint(c.Param("webhook_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook ID"})
+		return 0, 0, false
+	}
+
+	return boardID, uint(webhookID), true
+}
+
+func (h *WebhookHandler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, models.ErrBoardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "board not found"})
+	case errors.Is(err, models.ErrWebhookNotFound), errors.Is(err, models.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case models.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
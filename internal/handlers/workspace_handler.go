@@ -0,0 +1,451 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/service"
+)
+
+type WorkspaceHandler struct {
+	workspaceService service.WorkspaceServiceInterface
+	boardService     service.BoardServiceInterface
+	labelService     service.LabelServiceInterface
+}
+
+func NewWorkspaceHandler(
+	workspaceService service.WorkspaceServiceInterface,
+	boardService service.BoardServiceInterface,
+	labelService service.LabelServiceInterface,
+) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaceService: workspaceService,
+		boardService:     boardService,
+		labelService:     labelService,
+	}
+}
+
+// WorkspaceInput представляет входные данные для создания и изменения рабочего пространства.
+type WorkspaceInput struct {
+	Name        string `json:"name" binding:"required" example:"Platform team"`
+	Description string `json:"description"`
+}
+
+// AddWorkspaceMemberInput представляет входные данные для добавления участника рабочего пространства.
+type AddWorkspaceMemberInput struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	Role   string `json:"role" example:"member"`
+}
+
+// GetWorkspaces godoc
+// @Summary Получить рабочие пространства пользователя
+// @Description Возвращает рабочие пространства, в которых состоит пользователь: сначала личное, затем остальные по названию
+// @Tags workspaces
+// @Produce json
+// @Success 200 {array} models.Workspace "Рабочие пространства"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/workspaces [get]
+func (h *WorkspaceHandler) GetWorkspaces(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	workspaces, err := h.workspaceService.GetUserWorkspaces(c.Request.Context(), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get workspaces"})
+		return
+	}
+
+	c.JSON(http.StatusOK, workspaces)
+}
+
+// CreateWorkspace godoc
+// @Summary Создать рабочее пространство
+// @Description Создает рабочее пространство; текущий пользователь становится его владельцем и администратором
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param input body WorkspaceInput true "Название и описание"
+// @Success 201 {object} models.Workspace "Рабочее пространство создано"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 401 {object} map[string]string "Неавторизованный запрос"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/workspaces [post]
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var input WorkspaceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspace := &models.Workspace{
+		Name:        input.Name,
+		Description: input.Description,
+		OwnerID:     userID.(uint),
+	}
+	if err := h.workspaceService.Create(c.Request.Context(), workspace); err != nil {
+		h.writeError(c, err, "failed to create workspace")
+		return
+	}
+
+	c.JSON(http.StatusCreated, workspace)
+}
+
+// GetWorkspace godoc
+// @Summary Получить рабочее пространство
+// @Tags workspaces
+// @Produce json
+// @Param workspace_id path int true "ID рабочего пространства"
+// @Success 200 {object} models.Workspace "Рабочее пространство"
+// @Header 200 {string} ETag "Версия рабочего пространства"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 404 {object} map[string]string "Рабочее пространство не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/workspaces/{workspace_id} [get]
+func (h *WorkspaceHandler) GetWorkspace(c *gin.Context) {
+	workspaceID, ok := h.authorize(c, false)
+	if !ok {
+		return
+	}
+
+	workspace, err := h.workspaceService.GetByID(c.Request.Context(), workspaceID)
+	if err != nil {
+		h.writeError(c, err, "failed to get workspace")
+		return
+	}
+
+	setETag(c, workspace.Version)
+	c.JSON(http.StatusOK, workspace)
+}
+
+// UpdateWorkspace godoc
+// @Summary Изменить рабочее пространство
+// @Description Меняет название и описание. Доступно администраторам рабочего пространства
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param workspace_id path int true "ID рабочего пространства"
+// @Param input body WorkspaceInput true "Название и описание"
+// @Param If-Match header string false "ETag рабочего пространства"
+// @Success 200 {object} models.Workspace "Рабочее пространство обновлено"
+// @Header 200 {string} ETag "Новая версия рабочего пространства"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет прав администратора"
+// @Failure 404 {object} map[string]string "Рабочее пространство не найдено"
+// @Failure 412 {object} map[string]string "Рабочее пространство изменено другим запросом"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/workspaces/{workspace_id} [put]
+func (h *WorkspaceHandler) UpdateWorkspace(c *gin.Context) {
+	workspaceID, ok := h.authorize(c, true)
+	if !ok {
+		return
+	}
+
+	var input WorkspaceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspace := &models.Workspace{
+		ID:          workspaceID,
+		Name:        input.Name,
+		Description: input.Description,
+	}
+	if err := h.workspaceService.Update(ifMatch(c), workspace); err != nil {
+		h.writeError(c, err, "failed to update workspace")
+		return
+	}
+
+	setETag(c, workspace.Version)
+	c.JSON(http.StatusOK, workspace)
+}
+
+// DeleteWorkspace godoc
+// @Summary Удалить рабочее пространство
+// @Description Удаляет рабочее пространство без досок. Личное пространство удалить нельзя. Доступно администраторам
+// @Tags workspaces
+// @Produce json
+// @Param workspace_id path int true "ID рабочего пространства"
+// @Param If-Match header string false "ETag рабочего пространства"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверный формат ID или личное пространство"
+// @Failure 403 {object} map[string]string "Нет прав администратора"
+// @Failure 404 {object} map[string]string "Рабочее пространство не найдено"
+// @Failure 409 {object} map[string]string "В рабочем пространстве есть доски"
+// @Failure 412 {object} map[string]string "Рабочее пространство изменено другим запросом"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/workspaces/{workspace_id} [delete]
+func (h *WorkspaceHandler) DeleteWorkspace(c *gin.Context) {
+	workspaceID, ok := h.authorize(c, true)
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceService.Delete(ifMatch(c), workspaceID); err != nil {
+		h.writeError(c, err, "failed to delete workspace")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWorkspaceMembers godoc
+// @Summary Получить участников рабочего пространства
+// @Tags workspaces
+// @Produce json
+// @Param workspace_id path int true "ID рабочего пространства"
+// @Success 200 {array} models.WorkspaceMember "Участники, включая владельца"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 404 {object} map[string]string "Рабочее пространство не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/workspaces/{workspace_id}/members [get]
+func (h *WorkspaceHandler) GetWorkspaceMembers(c *gin.Context) {
+	workspaceID, ok := h.authorize(c, false)
+	if !ok {
+		return
+	}
+
+	members, err := h.workspaceService.GetMembers(c.Request.Context(), workspaceID)
+	if err != nil {
+		h.writeError(c, err, "failed to get workspace members")
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// AddWorkspaceMember godoc
+// @Summary Добавить участника рабочего пространства
+// @Description Добавляет пользователя в рабочее пространство. Доступно администраторам; в личное пространство добавить нельзя
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param workspace_id path int true "ID рабочего пространства"
+// @Param input body AddWorkspaceMemberInput true "Пользователь и роль"
+// @Success 201 {object} models.WorkspaceMember "Участник добавлен"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет прав администратора"
+// @Failure 404 {object} map[string]string "Рабочее пространство или пользователь не найдены"
+// @Failure 409 {object} map[string]string "Пользователь уже участник"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/workspaces/{workspace_id}/members [post]
+func (h *WorkspaceHandler) AddWorkspaceMember(c *gin.Context) {
+	workspaceID, ok := h.authorize(c, true)
+	if !ok {
+		return
+	}
+
+	var input AddWorkspaceMemberInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := h.workspaceService.AddMember(c.Request.Context(), workspaceID, input.UserID, input.Role)
+	if err != nil {
+		h.writeError(c, err, "failed to add workspace member")
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// UpdateWorkspaceMemberRole godoc
+// @Summary Изменить роль участника рабочего пространства
+// @Description Роль владельца не меняется. Доступно администраторам
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param workspace_id path int true "ID рабочего пространства"
+// @Param user_id path int true "ID пользователя"
+// @Param input body UpdateMemberRoleInput true "Новая роль"
+// @Success 200 {object} map[string]string "Роль обновлена"
+// @Failure 400 {object} map[string]string "Неверные входные данные"
+// @Failure 403 {object} map[string]string "Нет прав администратора"
+// @Failure 404 {object} map[string]string "Участник не найден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/workspaces/{workspace_id}/members/{user_id} [put]
+func (h *WorkspaceHandler) UpdateWorkspaceMemberRole(c *gin.Context) {
+	workspaceID, ok := h.authorize(c, true)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	var input UpdateMemberRoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.workspaceService.UpdateRole(c.Request.Context(), workspaceID, uint(userID), input.Role); err != nil {
+		h.writeError(c, err, "failed to update workspace member role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "workspace member role updated successfully"})
+}
+
+// RemoveWorkspaceMember godoc
+// @Summary Исключить участника рабочего пространства
+// @Description Администраторы исключают любого участника, кроме владельца; участник может выйти сам
+// @Tags workspaces
+// @Produce json
+// @Param workspace_id path int true "ID рабочего пространства"
+// @Param user_id path int true "ID пользователя"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Неверный формат ID или владелец"
+// @Failure 403 {object} map[string]string "Нет прав администратора"
+// @Failure 404 {object} map[string]string "Участник не найден"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/workspaces/{workspace_id}/members/{user_id} [delete]
+func (h *WorkspaceHandler) RemoveWorkspaceMember(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	currentUserID, _ := c.Get("userID")
+	workspaceID, ok := h.authorize(c, currentUserID != uint(userID))
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceService.RemoveMember(c.Request.Context(), workspaceID, uint(userID)); err != nil {
+		h.writeError(c, err, "failed to remove workspace member")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWorkspaceBoards godoc
+// @Summary Получить доски рабочего пространства
+// @Description Возвращает доски рабочего пространства, которыми пользователь владеет или в которых состоит. Параметры те же, что у списка досок пользователя
+// @Tags workspaces
+// @Produce json
+// @Param workspace_id path int true "ID рабочего пространства"
+// @Param sort query string false "Сортировка: name (по умолчанию), updated_at или activity"
+// @Param archived query string false "Архивные доски: false (по умолчанию), true — только архивные, all — все"
+// @Param starred query bool false "Только доски со звездочкой"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param limit query int false "Размер страницы (по умолчанию 50, максимум 200)"
+// @Success 200 {array} models.Board "Список досок"
+// @Header 200 {string} X-Next-Cursor "Курсор следующей страницы"
+// @Failure 400 {object} map[string]string "Неверные параметры запроса"
+// @Failure 404 {object} map[string]string "Рабочее пространство не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/workspaces/{workspace_id}/boards [get]
+func (h *WorkspaceHandler) GetWorkspaceBoards(c *gin.Context) {
+	workspaceID, ok := h.authorize(c, false)
+	if !ok {
+		return
+	}
+	userID, _ := c.Get("userID")
+
+	listBoards(c, h.boardService, userID.(uint), workspaceID)
+}
+
+// GetWorkspaceLabels godoc
+// @Summary Получить общие метки рабочего пространства
+// @Description Возвращает метки, которые можно назначать карточкам любой доски рабочего пространства
+// @Tags workspaces
+// @Produce json
+// @Param workspace_id path int true "ID рабочего пространства"
+// @Success 200 {array} LabelResponse "Общие метки"
+// @Failure 400 {object} map[string]string "Неверный формат ID"
+// @Failure 404 {object} map[string]string "Рабочее пространство не найдено"
+// @Failure 500 {object} map[string]string "Внутренняя ошибка сервера"
+// @Router /api/workspaces/{workspace_id}/labels [get]
+func (h *WorkspaceHandler) GetWorkspaceLabels(c *gin.Context) {
+	workspaceID, ok := h.authorize(c, false)
+	if !ok {
+		return
+	}
+
+	labels, err := h.labelService.GetByWorkspaceID(c.Request.Context(), workspaceID)
+	if err != nil {
+		h.writeError(c, err, "failed to get workspace labels")
+		return
+	}
+
+	response := make(LabelsResponse, 0, len(labels))
+	for _, label := range labels {
+		response = append(response, LabelResponse{
+			ID:          label.ID,
+			Name:        label.Name,
+			Color:       label.Color,
+			BoardID:     label.BoardID,
+			Version:     label.Version,
+			WorkspaceID: label.WorkspaceID,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// authorize разбирает workspace_id и проверяет, что текущий пользователь состоит в рабочем
+// пространстве (или является его администратором, если adminOnly).
+func (h *WorkspaceHandler) authorize(c *gin.Context, adminOnly bool) (uint, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return 0, false
+	}
+
+	workspaceID, err := strconv.ParseUint(c.Param("workspace_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return 0, false
+	}
+
+	role, err := h.workspaceService.Role(c.Request.Context(), uint(workspaceID), userID.(uint))
+	if err != nil {
+		h.writeError(c, err, "failed to check workspace access")
+		return 0, false
+	}
+	if adminOnly && role != models.WorkspaceRoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you don't have permission to manage this workspace"})
+		return 0, false
+	}
+
+	return uint(workspaceID), true
+}
+
+func (h *WorkspaceHandler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, models.ErrWorkspaceNotFound), errors.Is(err, models.ErrWorkspaceMemberNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	case errors.Is(err, models.ErrWorkspaceMemberExists), errors.Is(err, models.ErrWorkspaceNotEmpty):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidWorkspaceRole), errors.Is(err, models.ErrPersonalWorkspace):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	case models.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -22,7 +22,10 @@ func NewAuthMiddleware(authService service.AuthServiceInterface) *AuthMiddleware
 func (m *AuthMiddleware) AuthRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		header := c.GetHeader("Authorization")
-		if header == "" {
+		if header == "" && isStreamRequest(c) {
+			header = "Bearer " + c.Query("access_token")
+		}
+		if header == "" || header == "Bearer " {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "Authorization header is required",
 			})
@@ -57,6 +60,7 @@ func (m *AuthMiddleware) AuthRequired() gin.HandlerFunc {
 		}
 
 		c.Set("userID", userID)
+		c.Request = c.Request.WithContext(service.WithActor(c.Request.Context(), userID))
 		c.Next()
 	}
 }
@@ -83,6 +87,13 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 		}
 
 		c.Set("userID", userID)
+		c.Request = c.Request.WithContext(service.WithActor(c.Request.Context(), userID))
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+// isStreamRequest сообщает, что запрос открывает WebSocket или поток SSE. Браузеры не позволяют
+// задать для них заголовок Authorization, поэтому токен можно передать параметром access_token.
+func isStreamRequest(c *gin.Context) bool {
+	return strings.EqualFold(c.GetHeader("Upgrade"), "websocket") ||
+		strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+}
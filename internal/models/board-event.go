@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// BoardEventLog — опубликованное событие доски. ID выдается по порядку публикации
+// и служит курсором для возобновления потока событий. Таблица хранит события
+// ограниченное время.
+type BoardEventLog struct {
+	ID        uint64    `gorm:"primaryKey;index:idx_board_event_log_board,priority:2"`
+	EventID   string    `gorm:"not null;uniqueIndex"`
+	BoardID   uint      `gorm:"not null;index:idx_board_event_log_board,priority:1"`
+	EventType string    `gorm:"not null"`
+	Payload   string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+func (BoardEventLog) TableName() string {
+	return "board_event_log"
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+const (
+	BoardRoleAdmin  = "admin"
+	BoardRoleMember = "member"
+	// BoardRoleGuest — внешний участник, который видит только открытые ему карточки.
+	BoardRoleGuest = "guest"
+)
+
+type BoardMember struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	BoardID   uint      `gorm:"not null;uniqueIndex:idx_board_members_board_user" json:"board_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_board_members_board_user" json:"user_id"`
+	User      User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Role      string    `gorm:"not null;default:member" json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// BoardPresence — отметка о том, что пользователь открыл доску в одной из сессий (вкладке или
+// соединении). CardID задан, пока пользователь редактирует карточку. Запись действительна до ExpiresAt
+// и продлевается, пока клиент на связи.
+type BoardPresence struct {
+	BoardID    uint      `gorm:"primaryKey;autoIncrement:false" json:"board_id"`
+	UserID     uint      `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	SessionID  string    `gorm:"primaryKey;size:64" json:"session_id"`
+	User       User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CardID     *uint     `json:"card_id,omitempty"`
+	LastSeenAt time.Time `gorm:"not null" json:"last_seen_at"`
+	ExpiresAt  time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// BoardShareLink — публичная ссылка на просмотр доски без учетной записи. Ссылку можно
+// отозвать, ограничить сроком действия и защитить паролем. По умолчанию публичный снимок
+// не содержит комментариев и адресов электронной почты.
+type BoardShareLink struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	BoardID      uint       `gorm:"not null;index" json:"board_id"`
+	Token        string     `gorm:"not null;uniqueIndex" json:"token"`
+	PasswordHash string     `gorm:"not null" json:"-"`
+	ShowComments bool       `gorm:"not null" json:"show_comments"`
+	ShowEmails   bool       `gorm:"not null" json:"show_emails"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy    uint       `gorm:"not null" json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+
+	HasPassword bool `gorm:"-" json:"has_password"`
+}
+
+// Active сообщает, что ссылка не отозвана и не истекла к моменту now.
+func (l *BoardShareLink) Active(now time.Time) bool {
+	return l.RevokedAt == nil && (l.ExpiresAt == nil || now.Before(*l.ExpiresAt))
+}
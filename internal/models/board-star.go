@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// BoardStar — доска, отмеченная пользователем звездочкой.
+type BoardStar struct {
+	UserID    uint      `gorm:"primaryKey" json:"user_id"`
+	BoardID   uint      `gorm:"primaryKey;index" json:"board_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BoardView — последний просмотр доски пользователем. Для каждого пользователя
+// хранится ограниченное число недавних досок.
+type BoardView struct {
+	UserID   uint      `gorm:"primaryKey;index:idx_board_views_user_viewed,priority:1" json:"user_id"`
+	BoardID  uint      `gorm:"primaryKey;index" json:"board_id"`
+	ViewedAt time.Time `gorm:"not null;index:idx_board_views_user_viewed,priority:2" json:"viewed_at"`
+}
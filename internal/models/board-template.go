@@ -0,0 +1,69 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// BoardLayout — структура доски без карточек: колонки, дорожки и метки в порядке отображения.
+type BoardLayout struct {
+	Columns   []TemplateColumn   `json:"columns"`
+	Swimlanes []TemplateSwimlane `json:"swimlanes,omitempty"`
+	Labels    []TemplateLabel    `json:"labels,omitempty"`
+}
+
+type TemplateColumn struct {
+	Title    string `json:"title"`
+	Kind     string `json:"kind"`
+	WIPLimit *int   `json:"wip_limit,omitempty"`
+}
+
+type TemplateSwimlane struct {
+	Title string `json:"title"`
+}
+
+type TemplateLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// Value хранит макет в текстовой колонке в виде JSON.
+func (l BoardLayout) Value() (driver.Value, error) {
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *BoardLayout) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = BoardLayout{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into BoardLayout", value)
+	}
+	return json.Unmarshal(data, l)
+}
+
+// BoardTemplate — сохраненный макет доски, из которого создаются новые доски. Встроенные
+// шаблоны не хранятся в базе: у них нет ID, и они различаются по Key.
+type BoardTemplate struct {
+	ID          uint        `gorm:"primaryKey" json:"id,omitempty"`
+	Key         string      `gorm:"-" json:"key,omitempty"`
+	Name        string      `gorm:"not null" json:"name"`
+	Description string      `json:"description"`
+	Layout      BoardLayout `gorm:"type:text;not null" json:"layout"`
+	OwnerID     uint        `gorm:"not null;index" json:"owner_id,omitempty"`
+	BuiltIn     bool        `gorm:"-" json:"built_in"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
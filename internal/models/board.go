@@ -6,13 +6,38 @@ import (
 	"gorm.io/gorm"
 )
 
+// Режимы соблюдения WIP-лимитов колонок доски.
+const (
+	// WIPModeWarn разрешает превышать лимит; переполненные колонки помечаются в списках.
+	WIPModeWarn = "warn"
+	// WIPModeBlock запрещает добавлять карточки в заполненную колонку.
+	WIPModeBlock = "block"
+)
+
 type Board struct {
 	ID          uint           `gorm:"primaryKey" json:"id"`
 	Title       string         `gorm:"not null" json:"title"`
 	Description string         `json:"description"`
 	OwnerID     uint           `gorm:"not null" json:"owner_id"`
 	Owner       User           `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
+	WorkspaceID uint           `gorm:"not null;index" json:"workspace_id"`
+	WIPMode     string         `gorm:"column:wip_mode;not null;default:'warn'" json:"wip_mode"`
+	Version     int            `gorm:"not null;default:1" json:"version"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// ArchivedAt задан у архивных досок: они скрыты из списков и доступны только для чтения.
+	// LastActivityAt обновляется при записи событий доски, а не при сохранении самой доски.
+	ArchivedAt     *time.Time `gorm:"index" json:"archived_at,omitempty"`
+	LastActivityAt time.Time  `gorm:"<-:false;not null;default:CURRENT_TIMESTAMP" json:"last_activity_at"`
+
+	// Starred и ViewedAt заполняются в списках досок текущего пользователя.
+	Starred  bool       `gorm:"->;-:migration" json:"starred"`
+	ViewedAt *time.Time `gorm:"->;-:migration" json:"viewed_at,omitempty"`
+}
+
+// Archived сообщает, что доска в архиве.
+func (b *Board) Archived() bool {
+	return b.ArchivedAt != nil
 }
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// CardGuest — карточка, открытая гостю доски. Гость видит только такие карточки.
+type CardGuest struct {
+	CardID    uint      `gorm:"primaryKey" json:"card_id"`
+	Card      Card      `gorm:"foreignKey:CardID" json:"card"`
+	UserID    uint      `gorm:"primaryKey;index" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
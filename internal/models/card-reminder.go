@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+const (
+	ReminderBeforeDue = "before_due"
+	ReminderOverdue   = "overdue"
+)
+
+// CardReminder — запланированное напоминание о сроке карточки. Напоминание привязано
+// к сроку, на который оно было запланировано, и после отправки хранится, чтобы
+// не отправить его повторно.
+type CardReminder struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	CardID    uint       `gorm:"not null;uniqueIndex:idx_card_reminders_unique" json:"card_id"`
+	Kind      string     `gorm:"not null;uniqueIndex:idx_card_reminders_unique" json:"kind"`
+	Offset    int64      `gorm:"column:offset_seconds;not null;uniqueIndex:idx_card_reminders_unique" json:"offset_seconds"`
+	DueDate   time.Time  `gorm:"not null;uniqueIndex:idx_card_reminders_unique" json:"due_date"`
+	FireAt    time.Time  `gorm:"not null;index" json:"fire_at"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
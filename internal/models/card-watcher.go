@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type CardWatcher struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CardID    uint      `gorm:"not null;uniqueIndex:idx_card_watchers_card_user" json:"card_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_card_watchers_card_user;index" json:"user_id"`
+	User      User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -10,13 +10,21 @@ type Card struct {
 	ID          uint           `gorm:"primaryKey" json:"id"`
 	Title       string         `gorm:"not null" json:"title"`
 	Description string         `json:"description"`
-	Position    int            `gorm:"not null" json:"position"`
-	ColumnID    uint           `gorm:"not null" json:"column_id"`
+	Position    int            `gorm:"->;-:migration" json:"position"`
+	Rank        string         `gorm:"<-:create;type:varchar(255) COLLATE \"C\";not null;default:'';index:idx_cards_column_rank,priority:2,where:deleted_at IS NULL" json:"-"`
+	ColumnID    uint           `gorm:"not null;index:idx_cards_column_rank,priority:1" json:"column_id"`
 	Column      Column         `gorm:"foreignKey:ColumnID" json:"column,omitempty"`
+	SwimlaneID  *uint          `gorm:"index" json:"swimlane_id"`
 	AssignedTo  *uint          `json:"assigned_to,omitempty"`
 	User        *User          `gorm:"foreignKey:AssignedTo" json:"user,omitempty"`
 	DueDate     *time.Time     `json:"due_date,omitempty"`
+	StartedAt   *time.Time     `json:"started_at,omitempty"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+	Version     int            `gorm:"not null;default:1" json:"version"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Mentions        []MentionSpan `gorm:"-" json:"mentions,omitempty"`
+	DescriptionHTML string        `gorm:"-" json:"description_html,omitempty"`
 }
@@ -6,13 +6,57 @@ import (
 	"gorm.io/gorm"
 )
 
+// Виды колонок задают этап работы, к которому относятся карточки колонки.
+const (
+	ColumnKindBacklog    = "backlog"
+	ColumnKindTodo       = "todo"
+	ColumnKindInProgress = "in_progress"
+	ColumnKindReview     = "review"
+	ColumnKindDone       = "done"
+)
+
+// ColumnKinds — все допустимые виды колонок в порядке прохождения карточки по доске.
+var ColumnKinds = []string{ColumnKindBacklog, ColumnKindTodo, ColumnKindInProgress, ColumnKindReview, ColumnKindDone}
+
 type Column struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	Title     string         `gorm:"not null" json:"title"`
-	Position  int            `gorm:"not null" json:"position"`
-	BoardID   uint           `gorm:"not null" json:"board_id"`
+	Position  int            `gorm:"->;-:migration" json:"position"`
+	Rank      string         `gorm:"<-:create;type:varchar(255) COLLATE \"C\";not null;default:'';index:idx_columns_board_rank,priority:2,where:deleted_at IS NULL" json:"-"`
+	BoardID   uint           `gorm:"not null;index:idx_columns_board_rank,priority:1" json:"board_id"`
 	Board     Board          `gorm:"foreignKey:BoardID" json:"board,omitempty"`
+	Kind      string         `gorm:"type:varchar(16);not null;default:'todo'" json:"kind"`
+	WIPLimit  *int           `gorm:"column:wip_limit" json:"wip_limit"`
+	Version   int            `gorm:"not null;default:1" json:"version"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// CardCount и OverLimit заполняются при чтении колонок; гостю доски считаются только
+	// открытые ему карточки.
+	CardCount int  `gorm:"->;-:migration" json:"card_count"`
+	OverLimit bool `gorm:"-" json:"over_limit"`
+}
+
+// Started сообщает, что работа над карточками колонки уже начата.
+func (c *Column) Started() bool {
+	return c.Kind == ColumnKindInProgress || c.Kind == ColumnKindReview || c.Kind == ColumnKindDone
+}
+
+// Done сообщает, что карточки колонки считаются завершенными.
+func (c *Column) Done() bool {
+	return c.Kind == ColumnKindDone
+}
+
+// WIPUsage — заполненность колонки относительно ее WIP-лимита.
+type WIPUsage struct {
+	ColumnID uint
+	Limit    *int
+	Mode     string
+	Count    int
+}
+
+// Full сообщает, что в колонку нельзя добавить еще одну карточку.
+func (u *WIPUsage) Full() bool {
+	return u.Mode == WIPModeBlock && u.Limit != nil && u.Count >= *u.Limit
 }
@@ -1,14 +1,35 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Comment struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Content   string         `gorm:"not null" json:"content"`
+	CardID    uint           `gorm:"not null" json:"card_id"`
+	Card      Card           `gorm:"foreignKey:CardID" json:"card,omitempty"`
+	UserID    uint           `gorm:"not null" json:"user_id"`
+	User      User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	EditedAt  *time.Time     `json:"edited_at,omitempty"`
+	Version   int            `gorm:"not null;default:1" json:"version"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Mentions    []MentionSpan `gorm:"-" json:"mentions,omitempty"`
+	ContentHTML string        `gorm:"-" json:"content_html,omitempty"`
+	// Deleted помечает удаленный комментарий, который отображается в ленте как заглушка.
+	Deleted bool `gorm:"-" json:"deleted,omitempty"`
+}
+
+type CommentRevision struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
+	CommentID uint      `gorm:"not null;uniqueIndex:idx_comment_revisions_version" json:"comment_id"`
+	Version   int       `gorm:"not null;uniqueIndex:idx_comment_revisions_version" json:"version"`
 	Content   string    `gorm:"not null" json:"content"`
-	CardID    uint      `gorm:"not null" json:"card_id"`
-	Card      Card      `gorm:"foreignKey:CardID" json:"card,omitempty"`
-	UserID    uint      `gorm:"not null" json:"user_id"`
-	User      User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	EditedBy  uint      `gorm:"not null" json:"edited_by"`
 	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSent    = "sent"
+	EmailStatusFailed  = "failed"
+
+	EmailKindImmediate = "immediate"
+	EmailKindDigest    = "digest"
+
+	DigestNone   = "none"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// OutboundEmail — письмо в очереди на отправку.
+type OutboundEmail struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	UserID        uint       `gorm:"not null;index" json:"user_id"`
+	Kind          string     `gorm:"not null" json:"kind"`
+	ToAddress     string     `gorm:"not null" json:"to_address"`
+	Subject       string     `gorm:"not null" json:"subject"`
+	TextBody      string     `gorm:"not null" json:"-"`
+	HTMLBody      string     `json:"-"`
+	Status        string     `gorm:"not null;default:pending" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `gorm:"not null" json:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func (OutboundEmail) TableName() string {
+	return "email_outbox"
+}
+
+// EmailPreference задает, какие письма получает пользователь.
+type EmailPreference struct {
+	ID           uint       `gorm:"primaryKey" json:"-"`
+	UserID       uint       `gorm:"not null;uniqueIndex" json:"-"`
+	Immediate    bool       `gorm:"not null" json:"immediate"`
+	Digest       string     `gorm:"not null" json:"digest"`
+	LastDigestAt *time.Time `json:"last_digest_at,omitempty"`
+	CreatedAt    time.Time  `json:"-"`
+	UpdatedAt    time.Time  `json:"-"`
+}
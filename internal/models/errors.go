@@ -42,17 +42,99 @@ var (
 	ErrUnauthorized        = &AuthError{Code: "AUTH_002", Message: "Unauthorized access"}
 
 	ErrBoardNotFound       = errors.New("board not found")
+	// ErrBoardArchived — доска в архиве, ее содержимое нельзя изменять.
+	ErrBoardArchived       = errors.New("board is archived")
+	ErrTemplateNotFound    = errors.New("board template not found")
 	ErrInsufficientAccess  = errors.New("insufficient access rights")
 
+	ErrWorkspaceNotFound        = errors.New("workspace not found")
+	ErrWorkspaceMemberNotFound  = errors.New("workspace member not found")
+	ErrWorkspaceMemberExists    = errors.New("user is already a workspace member")
+	ErrInvalidWorkspaceRole     = errors.New("invalid workspace role")
+	// ErrWorkspaceNotEmpty — в рабочем пространстве остались доски, поэтому его нельзя удалить.
+	ErrWorkspaceNotEmpty        = errors.New("workspace still has boards")
+	ErrPersonalWorkspace        = errors.New("personal workspace cannot be deleted or left")
+
 	ErrColumnNotFound      = errors.New("column not found")
 
 	ErrCardNotFound        = errors.New("card not found")
 
+	ErrSwimlaneNotFound    = errors.New("swimlane not found")
+
 	ErrCommentNotFound     = errors.New("comment not found")
 
 	ErrLabelNotFound       = errors.New("label not found")
+
+	ErrMemberNotFound      = errors.New("board member not found")
+	ErrMemberAlreadyExists = errors.New("user is already a board member")
+	ErrInvalidBoardRole    = errors.New("invalid board role")
+	ErrNotBoardGuest       = errors.New("user is not a guest of this board")
+
+	ErrNotWatching         = errors.New("user is not watching this card")
+
+	ErrNotificationNotFound = errors.New("notification not found")
+
+	ErrWebhookNotFound     = errors.New("webhook not found")
+	ErrDeliveryNotFound    = errors.New("webhook delivery not found")
+	ErrEventNotFound       = errors.New("event not found")
+
+	// ErrShareLinkNotFound — ссылки нет, она отозвана или истекла.
+	ErrShareLinkNotFound   = errors.New("share link not found")
+	ErrSharePasswordInvalid = errors.New("share link password is missing or invalid")
+
+	// ErrVersionConflict — ресурс изменен другим запросом после того, как клиент его прочитал.
+	ErrVersionConflict     = errors.New("resource was modified by another request")
+	// ErrOrderConflict — присланный порядок не совпадает с текущим составом списка.
+	ErrOrderConflict       = errors.New("submitted order does not match the current list")
+	// ErrWIPLimitExceeded — колонка заполнена, а доска запрещает превышать WIP-лимиты.
+	ErrWIPLimitExceeded    = errors.New("column WIP limit exceeded")
 )
 
+// OrderConflictError возвращается при изменении порядка, если клиент прислал не ровно
+// те записи, что сейчас в списке. Current — актуальный порядок, по которому клиент
+// может обновить свое представление.
+type OrderConflictError struct {
+	Missing    []uint `json:"missing"`
+	Unexpected []uint `json:"unexpected"`
+	Current    []uint `json:"current"`
+}
+
+func (e *OrderConflictError) Error() string {
+	return fmt.Sprintf("%v: %d missing, %d unexpected", ErrOrderConflict, len(e.Missing), len(e.Unexpected))
+}
+
+func (e *OrderConflictError) Is(target error) bool {
+	return target == ErrOrderConflict
+}
+
+// WIPLimitError возвращается, когда карточка не помещается в колонку из-за WIP-лимита
+// в режиме block. Code не меняется и предназначен для клиентов.
+type WIPLimitError struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	ColumnID uint   `json:"column_id"`
+	Limit    int    `json:"limit"`
+	Count    int    `json:"count"`
+}
+
+func NewWIPLimitError(columnID uint, limit, count int) error {
+	return &WIPLimitError{
+		Code:     "WIP_001",
+		Message:  ErrWIPLimitExceeded.Error(),
+		ColumnID: columnID,
+		Limit:    limit,
+		Count:    count,
+	}
+}
+
+func (e *WIPLimitError) Error() string {
+	return fmt.Sprintf("%v: column %d has %d of %d cards", ErrWIPLimitExceeded, e.ColumnID, e.Count, e.Limit)
+}
+
+func (e *WIPLimitError) Is(target error) bool {
+	return target == ErrWIPLimitExceeded
+}
+
 func IsValidationError(err error) bool {
 	_, ok := err.(*ValidationError)
 	return ok
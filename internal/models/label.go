@@ -6,4 +6,9 @@ type Label struct {
 	Color   string `gorm:"not null" json:"color"`
 	BoardID uint   `gorm:"not null" json:"board_id"`
 	Board   Board  `gorm:"foreignKey:BoardID" json:"board,omitempty"`
+	Version int    `gorm:"not null;default:1" json:"version"`
+
+	// WorkspaceID задан у общих меток: их можно назначать карточкам любой доски рабочего
+	// пространства. Событиями меток по-прежнему владеет доска BoardID.
+	WorkspaceID *uint `gorm:"index" json:"workspace_id,omitempty"`
 }
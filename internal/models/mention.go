@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+const (
+	MentionSourceComment = "comment"
+	MentionSourceCard    = "card"
+)
+
+type Mention struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	SourceType string    `gorm:"not null;index:idx_mentions_source" json:"source_type"`
+	SourceID   uint      `gorm:"not null;index:idx_mentions_source" json:"source_id"`
+	CardID     uint      `gorm:"not null;index" json:"card_id"`
+	UserID     uint      `gorm:"not null;index" json:"user_id"`
+	AuthorID   uint      `json:"author_id"`
+	Handle     string    `gorm:"not null" json:"handle"`
+	Start      int       `gorm:"column:span_start;not null" json:"start"`
+	End        int       `gorm:"column:span_end;not null" json:"end"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// MentionSpan описывает упоминание в тексте: Start и End задаются в символах (рунах),
+// End не включается.
+type MentionSpan struct {
+	UserID uint   `json:"user_id"`
+	Handle string `json:"handle"`
+	Start  int    `json:"start"`
+	End    int    `json:"end"`
+}
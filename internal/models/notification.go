@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type Notification struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	Type      string     `gorm:"not null" json:"type"`
+	ActorID   *uint      `json:"actor_id,omitempty"`
+	BoardID   *uint      `json:"board_id,omitempty"`
+	CardID    *uint      `gorm:"index" json:"card_id,omitempty"`
+	CommentID *uint      `json:"comment_id,omitempty"`
+	Message   string     `gorm:"not null" json:"message"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationPreference отключает уведомления пользователя определенного типа
+// или по определенной доске. Если заданы оба поля, правило действует только на их сочетание.
+type NotificationPreference struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	UserID    uint      `gorm:"not null;index" json:"-"`
+	Type      string    `json:"type,omitempty"`
+	BoardID   *uint     `json:"board_id,omitempty"`
+	CreatedAt time.Time `json:"-"`
+}
+
+// NotificationGroup объединяет уведомления по одной карточке.
+type NotificationGroup struct {
+	CardID        *uint          `json:"card_id"`
+	UnreadCount   int            `json:"unread_count"`
+	Notifications []Notification `json:"notifications"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// OutboxEvent — доменное событие, сохраненное в той же транзакции, что и изменение,
+// и ожидающее публикации. Порядок публикации определяется ID.
+type OutboxEvent struct {
+	ID            uint64 `gorm:"primaryKey"`
+	EventID       string `gorm:"not null;uniqueIndex"`
+	EventType     string `gorm:"not null"`
+	AggregateType string `gorm:"not null"`
+	AggregateID   uint   `gorm:"not null"`
+	BoardID       uint   `gorm:"not null"`
+	Payload       string `gorm:"type:text;not null"`
+	Attempts      int    `gorm:"not null"`
+	LastError     string
+	CreatedAt     time.Time
+	PublishedAt   *time.Time `gorm:"index"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox"
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Swimlane — горизонтальная дорожка доски. Карточка без дорожки попадает в дорожку
+// по умолчанию, которая идет после всех остальных.
+type Swimlane struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Title     string         `gorm:"not null" json:"title"`
+	Position  int            `gorm:"->;-:migration" json:"position"`
+	Rank      string         `gorm:"<-:create;type:varchar(255) COLLATE \"C\";not null;default:'';index:idx_swimlanes_board_rank,priority:2,where:deleted_at IS NULL" json:"-"`
+	BoardID   uint           `gorm:"not null;index:idx_swimlanes_board_rank,priority:1" json:"board_id"`
+	Version   int            `gorm:"not null;default:1" json:"version"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
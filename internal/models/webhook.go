@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// StringList хранит список строк в текстовой колонке в виде JSON.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into StringList", value)
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+// Webhook — подписка внешней системы на события доски. Пустой список Events означает все события.
+type Webhook struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	BoardID      uint       `gorm:"not null;index" json:"board_id"`
+	URL          string     `gorm:"not null" json:"url"`
+	Secret       string     `gorm:"not null" json:"-"`
+	Events       StringList `gorm:"type:text;not null" json:"events"`
+	Active       bool       `gorm:"not null" json:"active"`
+	FailureCount int        `gorm:"not null" json:"failure_count"`
+	DisabledAt   *time.Time `json:"disabled_at,omitempty"`
+	CreatedBy    uint       `gorm:"not null" json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// WebhookDelivery — попытки доставки одного события одному вебхуку.
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	WebhookID      uint       `gorm:"not null;index;uniqueIndex:idx_webhook_deliveries_event,where:redelivery_of IS NULL" json:"webhook_id"`
+	EventID        string     `gorm:"not null;uniqueIndex:idx_webhook_deliveries_event,where:redelivery_of IS NULL" json:"event_id"`
+	EventType      string     `gorm:"not null" json:"event_type"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Status         string     `gorm:"not null" json:"status"`
+	Attempts       int        `gorm:"not null" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null" json:"next_attempt_at"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	ResponseBody   string     `json:"response_body,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DurationMs     int64      `json:"duration_ms,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	RedeliveryOf   *uint      `json:"redelivery_of,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+)
+
+// Workspace объединяет доски команды и ее участников. Личное рабочее пространство создается
+// для каждого пользователя при регистрации, его нельзя удалить.
+type Workspace struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"not null" json:"name"`
+	Description string    `json:"description"`
+	OwnerID     uint      `gorm:"not null;index;uniqueIndex:idx_workspaces_personal,where:personal" json:"owner_id"`
+	Personal    bool      `gorm:"not null;default:false" json:"personal"`
+	Version     int       `gorm:"not null;default:1" json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WorkspaceMember — участник рабочего пространства. Владелец тоже хранится участником
+// с ролью администратора.
+type WorkspaceMember struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint      `gorm:"not null;uniqueIndex:idx_workspace_members_workspace_user" json:"workspace_id"`
+	UserID      uint      `gorm:"not null;uniqueIndex:idx_workspace_members_workspace_user;index" json:"user_id"`
+	User        User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Role        string    `gorm:"not null;default:member" json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
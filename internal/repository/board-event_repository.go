@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BoardEventRepo struct {
+	db *gorm.DB
+}
+
+func NewBoardEventRepo(db *gorm.DB) *BoardEventRepo {
+	return &BoardEventRepo{db: db}
+}
+
+// Append сохраняет событие и заполняет его ID. Если событие уже было сохранено
+// при предыдущей попытке публикации, возвращается прежний ID.
+func (r *BoardEventRepo) Append(ctx context.Context, event *models.BoardEventLog) error {
+	// Пустое обновление при конфликте нужно, чтобы RETURNING вернул ID существующей записи.
+	result := dbFromContext(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"event_id": gorm.Expr("EXCLUDED.event_id")}),
+	}).Create(event)
+	if result.Error != nil {
+		return models.NewDatabaseError("appending board event", result.Error)
+	}
+	return nil
+}
+
+func (r *BoardEventRepo) GetByID(ctx context.Context, id uint64) (*models.BoardEventLog, error) {
+	var event models.BoardEventLog
+	result := dbFromContext(ctx, r.db).First(&event, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrEventNotFound
+		}
+		return nil, models.NewDatabaseError("getting board event", result.Error)
+	}
+	return &event, nil
+}
+
+// GetAfter возвращает события доски с ID больше afterID в порядке публикации.
+func (r *BoardEventRepo) GetAfter(ctx context.Context, boardID uint, afterID uint64, limit int) ([]models.BoardEventLog, error) {
+	var events []models.BoardEventLog
+	result := dbFromContext(ctx, r.db).
+		Where("board_id = ? AND id > ?", boardID, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting board events", result.Error)
+	}
+	return events, nil
+}
+
+// GetBounds возвращает наименьший и наибольший хранящиеся ID. Для пустой таблицы оба равны нулю.
+func (r *BoardEventRepo) GetBounds(ctx context.Context) (uint64, uint64, error) {
+	var bounds struct {
+		MinID uint64
+		MaxID uint64
+	}
+	result := dbFromContext(ctx, r.db).Model(&models.BoardEventLog{}).
+		Select("COALESCE(MIN(id), 0) AS min_id, COALESCE(MAX(id), 0) AS max_id").
+		Scan(&bounds)
+	if result.Error != nil {
+		return 0, 0, models.NewDatabaseError("getting board event bounds", result.Error)
+	}
+	return bounds.MinID, bounds.MaxID, nil
+}
+
+func (r *BoardEventRepo) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Where("created_at < ?", before).
+		Delete(&models.BoardEventLog{})
+	if result.Error != nil {
+		return 0, models.NewDatabaseError("deleting old board events", result.Error)
+	}
+	return result.RowsAffected, nil
+}
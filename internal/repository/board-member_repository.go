@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+type BoardMemberRepo struct {
+	db *gorm.DB
+}
+
+func NewBoardMemberRepo(db *gorm.DB) *BoardMemberRepo {
+	return &BoardMemberRepo{db: db}
+}
+
+func (r *BoardMemberRepo) Add(ctx context.Context, member *models.BoardMember) error {
+	var existingCount int64
+	if err := dbFromContext(ctx, r.db).Model(&models.BoardMember{}).
+		Where("board_id = ? AND user_id = ?", member.BoardID, member.UserID).
+		Count(&existingCount).Error; err != nil {
+		return models.NewDatabaseError("checking existing board member", err)
+	}
+
+	if existingCount > 0 {
+		return models.ErrMemberAlreadyExists
+	}
+
+	if err := dbFromContext(ctx, r.db).Create(member).Error; err != nil {
+		return models.NewDatabaseError("adding board member", err)
+	}
+	return nil
+}
+
+func (r *BoardMemberRepo) Get(ctx context.Context, boardID, userID uint) (*models.BoardMember, error) {
+	var member models.BoardMember
+	result := dbFromContext(ctx, r.db).
+		Where("board_id = ? AND user_id = ?", boardID, userID).
+		First(&member)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrMemberNotFound
+		}
+		return nil, models.NewDatabaseError("getting board member", result.Error)
+	}
+	return &member, nil
+}
+
+func (r *BoardMemberRepo) GetByBoardID(ctx context.Context, boardID uint) ([]models.BoardMember, error) {
+	var members []models.BoardMember
+	result := dbFromContext(ctx, r.db).
+		Preload("User").
+		Where("board_id = ?", boardID).
+		Order("created_at ASC").
+		Find(&members)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting board members", result.Error)
+	}
+	return members, nil
+}
+
+func (r *BoardMemberRepo) UpdateRole(ctx context.Context, boardID, userID uint, role string) error {
+	result := dbFromContext(ctx, r.db).Model(&models.BoardMember{}).
+		Where("board_id = ? AND user_id = ?", boardID, userID).
+		Update("role", role)
+	if result.Error != nil {
+		return models.NewDatabaseError("updating board member role", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *BoardMemberRepo) Remove(ctx context.Context, boardID, userID uint) error {
+	result := dbFromContext(ctx, r.db).
+		Where("board_id = ? AND user_id = ?", boardID, userID).
+		Delete(&models.BoardMember{})
+	if result.Error != nil {
+		return models.NewDatabaseError("removing board member", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrMemberNotFound
+	}
+	return nil
+}
+
+// GetBoardUsers возвращает всех пользователей с доступом к доске: владельца и участников.
+func (r *BoardMemberRepo) GetBoardUsers(ctx context.Context, boardID uint) ([]models.User, error) {
+	var users []models.User
+	err := dbFromContext(ctx, r.db).
+		Where("id IN (?) OR id IN (?)",
+			r.db.Model(&models.Board{}).Select("owner_id").Where("id = ?", boardID),
+			r.db.Model(&models.BoardMember{}).Select("user_id").Where("board_id = ?", boardID),
+		).
+		Find(&users).Error
+	if err != nil {
+		return nil, models.NewDatabaseError("getting board users", err)
+	}
+	return users, nil
+}
+
+// GetUserBoardIDs возвращает доски, которыми пользователь владеет или в которых состоит.
+func (r *BoardMemberRepo) GetUserBoardIDs(ctx context.Context, userID uint) ([]uint, error) {
+	var ids []uint
+	err := dbFromContext(ctx, r.db).Model(&models.Board{}).
+		Where("owner_id = ? OR id IN (?)", userID,
+			r.db.Model(&models.BoardMember{}).Select("board_id").Where("user_id = ?", userID),
+		).
+		Order("id ASC").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, models.NewDatabaseError("getting user boards", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BoardPresenceRepo struct {
+	db *gorm.DB
+}
+
+func NewBoardPresenceRepo(db *gorm.DB) *BoardPresenceRepo {
+	return &BoardPresenceRepo{db: db}
+}
+
+// Touch создает или продлевает отметку присутствия и возвращает ее прежнее состояние.
+// Если отметки не было или она уже истекла, возвращается nil. При keepCard редактируемая
+// карточка активной отметки сохраняется и записывается в presence.
+func (r *BoardPresenceRepo) Touch(ctx context.Context, presence *models.BoardPresence, keepCard bool) (*models.BoardPresence, error) {
+	var previous *models.BoardPresence
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var existing models.BoardPresence
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("board_id = ? AND user_id = ? AND session_id = ?", presence.BoardID, presence.UserID, presence.SessionID).
+			Take(&existing).Error
+		switch {
+		case err == nil:
+			if existing.ExpiresAt.After(presence.LastSeenAt) {
+				previous = &existing
+				presence.CreatedAt = existing.CreatedAt
+				if keepCard {
+					presence.CardID = existing.CardID
+				}
+			} else {
+				// Истекшая отметка, которую еще не убрал сборщик, считается новым входом.
+				presence.CreatedAt = presence.LastSeenAt
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			presence.CreatedAt = presence.LastSeenAt
+		default:
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "board_id"}, {Name: "user_id"}, {Name: "session_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"card_id", "last_seen_at", "expires_at", "created_at"}),
+		}).Omit("User").Create(presence).Error
+	})
+	if err != nil {
+		return nil, models.NewDatabaseError("touching board presence", err)
+	}
+	return previous, nil
+}
+
+// Remove удаляет отметку сессии и возвращает ее; если отметки не было, возвращается nil.
+func (r *BoardPresenceRepo) Remove(ctx context.Context, boardID, userID uint, sessionID string) (*models.BoardPresence, error) {
+	var removed []models.BoardPresence
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.Returning{}).
+		Where("board_id = ? AND user_id = ? AND session_id = ?", boardID, userID, sessionID).
+		Delete(&removed)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("removing board presence", result.Error)
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+	return &removed[0], nil
+}
+
+// GetActive возвращает неистекшие отметки доски вместе с пользователями в порядке входа.
+func (r *BoardPresenceRepo) GetActive(ctx context.Context, boardID uint, now time.Time) ([]models.BoardPresence, error) {
+	var presences []models.BoardPresence
+	result := dbFromContext(ctx, r.db).
+		Preload("User").
+		Where("board_id = ? AND expires_at > ?", boardID, now).
+		Order("created_at ASC, user_id ASC").
+		Find(&presences)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting board presences", result.Error)
+	}
+	return presences, nil
+}
+
+// DeleteExpired удаляет истекшие отметки и возвращает их. Каждая отметка возвращается
+// только одному из экземпляров сервера, вызвавших удаление одновременно.
+func (r *BoardPresenceRepo) DeleteExpired(ctx context.Context, now time.Time) ([]models.BoardPresence, error) {
+	var expired []models.BoardPresence
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.Returning{}).
+		Where("expires_at <= ?", now).
+		Delete(&expired)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("deleting expired board presences", result.Error)
+	}
+	return expired, nil
+}
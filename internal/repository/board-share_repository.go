@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+type BoardShareRepo struct {
+	db *gorm.DB
+}
+
+func NewBoardShareRepo(db *gorm.DB) *BoardShareRepo {
+	return &BoardShareRepo{db: db}
+}
+
+func (r *BoardShareRepo) Create(ctx context.Context, link *models.BoardShareLink) error {
+	result := dbFromContext(ctx, r.db).Create(link)
+	if result.Error != nil {
+		return models.NewDatabaseError("creating share link", result.Error)
+	}
+	return nil
+}
+
+func (r *BoardShareRepo) GetByID(ctx context.Context, id uint) (*models.BoardShareLink, error) {
+	var link models.BoardShareLink
+	result := dbFromContext(ctx, r.db).First(&link, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrShareLinkNotFound
+		}
+		return nil, models.NewDatabaseError("getting share link", result.Error)
+	}
+	return &link, nil
+}
+
+func (r *BoardShareRepo) GetByToken(ctx context.Context, token string) (*models.BoardShareLink, error) {
+	var link models.BoardShareLink
+	result := dbFromContext(ctx, r.db).Where("token = ?", token).First(&link)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrShareLinkNotFound
+		}
+		return nil, models.NewDatabaseError("getting share link by token", result.Error)
+	}
+	return &link, nil
+}
+
+func (r *BoardShareRepo) GetByBoardID(ctx context.Context, boardID uint) ([]models.BoardShareLink, error) {
+	var links []models.BoardShareLink
+	result := dbFromContext(ctx, r.db).Where("board_id = ?", boardID).Order("id ASC").Find(&links)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting board share links", result.Error)
+	}
+	return links, nil
+}
+
+// Revoke отзывает ссылку; повторный отзыв сохраняет исходное время.
+func (r *BoardShareRepo) Revoke(ctx context.Context, id uint, at time.Time) error {
+	result := dbFromContext(ctx, r.db).Model(&models.BoardShareLink{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return models.NewDatabaseError("revoking share link", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BoardStarRepo struct {
+	db *gorm.DB
+}
+
+func NewBoardStarRepo(db *gorm.DB) *BoardStarRepo {
+	return &BoardStarRepo{db: db}
+}
+
+// Star отмечает доску звездочкой; повторная отметка ничего не делает.
+func (r *BoardStarRepo) Star(ctx context.Context, boardID, userID uint) error {
+	star := models.BoardStar{
+		UserID:  userID,
+		BoardID: boardID,
+	}
+
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&star)
+	if result.Error != nil {
+		return models.NewDatabaseError("starring board", result.Error)
+	}
+	return nil
+}
+
+// Unstar снимает звездочку; если ее не было, ничего не делает.
+func (r *BoardStarRepo) Unstar(ctx context.Context, boardID, userID uint) error {
+	result := dbFromContext(ctx, r.db).
+		Where("board_id = ? AND user_id = ?", boardID, userID).
+		Delete(&models.BoardStar{})
+	if result.Error != nil {
+		return models.NewDatabaseError("unstarring board", result.Error)
+	}
+	return nil
+}
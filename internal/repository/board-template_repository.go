@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+type BoardTemplateRepo struct {
+	db *gorm.DB
+}
+
+func NewBoardTemplateRepo(db *gorm.DB) *BoardTemplateRepo {
+	return &BoardTemplateRepo{db: db}
+}
+
+func (r *BoardTemplateRepo) Create(ctx context.Context, template *models.BoardTemplate) error {
+	if err := dbFromContext(ctx, r.db).Create(template).Error; err != nil {
+		return models.NewDatabaseError("creating board template", err)
+	}
+	return nil
+}
+
+func (r *BoardTemplateRepo) GetByID(ctx context.Context, id uint) (*models.BoardTemplate, error) {
+	var template models.BoardTemplate
+	result := dbFromContext(ctx, r.db).First(&template, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrTemplateNotFound
+		}
+		return nil, models.NewDatabaseError("getting board template by ID", result.Error)
+	}
+	return &template, nil
+}
+
+func (r *BoardTemplateRepo) GetByOwnerID(ctx context.Context, ownerID uint) ([]models.BoardTemplate, error) {
+	var templates []models.BoardTemplate
+	result := dbFromContext(ctx, r.db).Where("owner_id = ?", ownerID).Order("name, id").Find(&templates)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting board templates by owner ID", result.Error)
+	}
+	return templates, nil
+}
+
+func (r *BoardTemplateRepo) Delete(ctx context.Context, id uint) error {
+	result := dbFromContext(ctx, r.db).Delete(&models.BoardTemplate{}, id)
+	if result.Error != nil {
+		return models.NewDatabaseError("deleting board template", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrTemplateNotFound
+	}
+	return nil
+}
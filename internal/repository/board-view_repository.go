@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BoardViewRepo struct {
+	db *gorm.DB
+}
+
+func NewBoardViewRepo(db *gorm.DB) *BoardViewRepo {
+	return &BoardViewRepo{db: db}
+}
+
+// Record запоминает просмотр доски и оставляет у пользователя не больше keep последних досок.
+func (r *BoardViewRepo) Record(ctx context.Context, boardID, userID uint, at time.Time, keep int) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		view := models.BoardView{UserID: userID, BoardID: boardID, ViewedAt: at}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "board_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"viewed_at"}),
+		}).Create(&view).Error
+		if err != nil {
+			return models.NewDatabaseError("recording board view", err)
+		}
+
+		err = tx.Where("user_id = ? AND board_id NOT IN (?)", userID,
+			r.db.Model(&models.BoardView{}).Select("board_id").Where("user_id = ?", userID).Order("viewed_at DESC").Limit(keep),
+		).Delete(&models.BoardView{}).Error
+		if err != nil {
+			return models.NewDatabaseError("pruning board views", err)
+		}
+		return nil
+	})
+}
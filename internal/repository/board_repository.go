@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/octaview/kanban-octaview/internal/models"
 	"gorm.io/gorm"
@@ -17,7 +19,7 @@ func NewBoardRepo(db *gorm.DB) *BoardRepo {
 }
 
 func (r *BoardRepo) Create(ctx context.Context, board *models.Board) error {
-	result := r.db.WithContext(ctx).Create(board)
+	result := dbFromContext(ctx, r.db).Create(board)
 	if result.Error != nil {
 		return models.NewDatabaseError("creating board", result.Error)
 	}
@@ -26,7 +28,7 @@ func (r *BoardRepo) Create(ctx context.Context, board *models.Board) error {
 
 func (r *BoardRepo) GetByID(ctx context.Context, id uint) (*models.Board, error) {
 	var board models.Board
-	result := r.db.WithContext(ctx).First(&board, id)
+	result := dbFromContext(ctx, r.db).First(&board, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, models.ErrBoardNotFound
@@ -36,40 +38,263 @@ func (r *BoardRepo) GetByID(ctx context.Context, id uint) (*models.Board, error)
 	return &board, nil
 }
 
+// boardRevisionQuery собирает сводку изменений доски одним запросом. Удаления видны
+// по уменьшению счетчиков, правки и перемещения — по updated_at и версиям.
+const boardRevisionQuery = `
+WITH board_cards AS (
+	SELECT cards.id, cards.updated_at, cards.assigned_to
+	FROM cards
+	JOIN columns ON columns.id = cards.column_id AND columns.deleted_at IS NULL
+	WHERE columns.board_id = @board AND cards.deleted_at IS NULL
+)
+SELECT
+	boards.version AS board_version,
+	(SELECT COUNT(*) FROM columns WHERE columns.board_id = boards.id AND columns.deleted_at IS NULL) AS column_count,
+	(SELECT MAX(columns.updated_at) FROM columns WHERE columns.board_id = boards.id AND columns.deleted_at IS NULL) AS columns_updated_at,
+	(SELECT COUNT(*) FROM swimlanes WHERE swimlanes.board_id = boards.id AND swimlanes.deleted_at IS NULL) AS swimlane_count,
+	(SELECT MAX(swimlanes.updated_at) FROM swimlanes WHERE swimlanes.board_id = boards.id AND swimlanes.deleted_at IS NULL) AS swimlanes_updated_at,
+	(SELECT COUNT(*) FROM board_cards) AS card_count,
+	(SELECT MAX(board_cards.updated_at) FROM board_cards) AS cards_updated_at,
+	(SELECT COUNT(*) FROM labels WHERE labels.board_id = boards.id) AS label_count,
+	(SELECT COALESCE(SUM(labels.version), 0) + COALESCE(MAX(labels.id), 0) FROM labels WHERE labels.board_id = boards.id) AS labels_checksum,
+	(SELECT COUNT(*) FROM card_labels JOIN board_cards ON board_cards.id = card_labels.card_id) AS card_label_count,
+	(SELECT COALESCE(MAX(card_labels.id), 0) FROM card_labels JOIN board_cards ON board_cards.id = card_labels.card_id) AS last_card_label_id,
+	(SELECT COUNT(*) FROM comments JOIN board_cards ON board_cards.id = comments.card_id WHERE comments.deleted_at IS NULL) AS comment_count,
+	(SELECT MAX(users.updated_at) FROM users JOIN board_cards ON board_cards.assigned_to = users.id) AS assignees_updated_at
+FROM boards
+WHERE boards.id = @board AND boards.deleted_at IS NULL`
+
+// GetRevision возвращает сводку изменений доски и ее содержимого.
+func (r *BoardRepo) GetRevision(ctx context.Context, boardID uint) (*BoardRevision, error) {
+	var revision BoardRevision
+	result := dbFromContext(ctx, r.db).Raw(boardRevisionQuery, sql.Named("board", boardID)).Scan(&revision)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting board revision", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, models.ErrBoardNotFound
+	}
+	return &revision, nil
+}
+
 func (r *BoardRepo) GetByOwnerID(ctx context.Context, ownerID uint) ([]models.Board, error) {
 	var boards []models.Board
-	result := r.db.WithContext(ctx).Where("owner_id = ?", ownerID).Find(&boards)
+	result := dbFromContext(ctx, r.db).Where("owner_id = ?", ownerID).Find(&boards)
 	if result.Error != nil {
 		return nil, models.NewDatabaseError("getting boards by owner ID", result.Error)
 	}
 	return boards, nil
 }
 
-func (r *BoardRepo) Update(ctx context.Context, board *models.Board) error {
-	result := r.db.WithContext(ctx).Save(board)
+// boardAccessCondition отбирает доски, которыми пользователь владеет или в которых состоит.
+const boardAccessCondition = "(boards.owner_id = @user OR boards.id IN (SELECT board_id FROM board_members WHERE user_id = @user))"
+
+// boardListSelect дополняет доску отметками текущего пользователя.
+const boardListSelect = `boards.*,
+	EXISTS (SELECT 1 FROM board_stars WHERE board_stars.board_id = boards.id AND board_stars.user_id = @user) AS starred,
+	(SELECT board_views.viewed_at FROM board_views WHERE board_views.board_id = boards.id AND board_views.user_id = @user) AS viewed_at`
+
+// List возвращает страницу досок, доступных пользователю. Страница начинается после курсора
+// в порядке сортировки; при совпадении ключа сортировки доски упорядочиваются по ID.
+func (r *BoardRepo) List(ctx context.Context, filter BoardFilter) ([]models.Board, error) {
+	user := sql.Named("user", filter.UserID)
+	query := dbFromContext(ctx, r.db).
+		Select(boardListSelect, user).
+		Where(boardAccessCondition, user)
+
+	if filter.Archived != nil {
+		if *filter.Archived {
+			query = query.Where("boards.archived_at IS NOT NULL")
+		} else {
+			query = query.Where("boards.archived_at IS NULL")
+		}
+	}
+	if filter.WorkspaceID != 0 {
+		query = query.Where("boards.workspace_id = ?", filter.WorkspaceID)
+	}
+	if filter.Starred {
+		query = query.Where("EXISTS (SELECT 1 FROM board_stars WHERE board_stars.board_id = boards.id AND board_stars.user_id = ?)", filter.UserID)
+	}
+
+	switch filter.Sort {
+	case BoardSortUpdated:
+		query = query.Order("boards.updated_at DESC, boards.id DESC")
+		if filter.After != nil {
+			query = query.Where("(boards.updated_at, boards.id) < (?, ?)", filter.After.At, filter.After.ID)
+		}
+	case BoardSortActivity:
+		query = query.Order("boards.last_activity_at DESC, boards.id DESC")
+		if filter.After != nil {
+			query = query.Where("(boards.last_activity_at, boards.id) < (?, ?)", filter.After.At, filter.After.ID)
+		}
+	default:
+		query = query.Order("boards.title ASC, boards.id ASC")
+		if filter.After != nil {
+			query = query.Where("(boards.title, boards.id) > (?, ?)", filter.After.Title, filter.After.ID)
+		}
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var boards []models.Board
+	if err := query.Find(&boards).Error; err != nil {
+		return nil, models.NewDatabaseError("listing boards", err)
+	}
+	return boards, nil
+}
+
+// GetRecent возвращает доски, которые пользователь недавно открывал, начиная с последней.
+// Архивные доски и доски, к которым у пользователя больше нет доступа, пропускаются.
+func (r *BoardRepo) GetRecent(ctx context.Context, userID uint, limit int) ([]models.Board, error) {
+	user := sql.Named("user", userID)
+	var boards []models.Board
+	result := dbFromContext(ctx, r.db).
+		Select(boardListSelect, user).
+		Joins("JOIN board_views ON board_views.board_id = boards.id AND board_views.user_id = @user", user).
+		Where(boardAccessCondition, user).
+		Where("boards.archived_at IS NULL").
+		Order("board_views.viewed_at DESC").
+		Limit(limit).
+		Find(&boards)
 	if result.Error != nil {
-		return models.NewDatabaseError("updating board", result.Error)
+		return nil, models.NewDatabaseError("getting recent boards", result.Error)
 	}
-	if result.RowsAffected == 0 {
+	return boards, nil
+}
+
+// TouchActivity отмечает изменение содержимого доски. Вызывается в транзакции изменения:
+// для архивной доски возвращается ErrBoardArchived, и изменение откатывается.
+func (r *BoardRepo) TouchActivity(ctx context.Context, boardID uint, at time.Time) error {
+	db := dbFromContext(ctx, r.db)
+	result := db.Exec("UPDATE boards SET last_activity_at = ? WHERE id = ? AND archived_at IS NULL AND deleted_at IS NULL", at, boardID)
+	if result.Error != nil {
+		return models.NewDatabaseError("updating board activity", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var count int64
+	if err := db.Model(&models.Board{}).Where("id = ?", boardID).Count(&count).Error; err != nil {
+		return models.NewDatabaseError("checking board", err)
+	}
+	if count == 0 {
 		return models.ErrBoardNotFound
 	}
-	return nil
+	return models.ErrBoardArchived
+}
+
+// Update сохраняет доску, если ее версия не изменилась с момента чтения, и увеличивает версию.
+func (r *BoardRepo) Update(ctx context.Context, board *models.Board) error {
+	return updateVersioned(dbFromContext(ctx, r.db), board, board.ID, &board.Version, models.ErrBoardNotFound, "updating board")
+}
+
+// Delete мягко удаляет доску вместе с колонками, дорожками, карточками и комментариями.
+// Метки, участники и вебхуки остаются: они недоступны, пока доска удалена.
+func (r *BoardRepo) Delete(ctx context.Context, id uint, version int) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		at, err := softDeleteVersioned(tx, &models.Board{}, id, version, models.ErrBoardNotFound, "deleting board")
+		if err != nil {
+			return err
+		}
+		return softDeleteCascade(tx, boardCascade, id, at)
+	})
 }
 
-func (r *BoardRepo) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+// GetByIDUnscoped возвращает доску, в том числе удаленную.
+func (r *BoardRepo) GetByIDUnscoped(ctx context.Context, id uint) (*models.Board, error) {
+	var board models.Board
+	result := dbFromContext(ctx, r.db).Unscoped().First(&board, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrBoardNotFound
+		}
+		return nil, models.NewDatabaseError("getting board by ID", result.Error)
+	}
+	return &board, nil
+}
+
+// Restore восстанавливает удаленную доску и все, что было удалено вместе с ней,
+// и увеличивает версию доски. Записи, удаленные раньше доски, остаются удаленными.
+func (r *BoardRepo) Restore(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		var board models.Board
-		if err := tx.First(&board, id).Error; err != nil {
+		if err := tx.Unscoped().First(&board, id).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return models.ErrBoardNotFound
 			}
-			return models.NewDatabaseError("finding board for deletion", err)
+			return models.NewDatabaseError("finding board for restore", err)
+		}
+		if !board.DeletedAt.Valid {
+			return nil
 		}
 
-		if err := tx.Delete(&board).Error; err != nil {
-			return models.NewDatabaseError("deleting board", err)
+		if err := restoreCascade(tx, boardCascade, id, board.DeletedAt.Time); err != nil {
+			return err
 		}
+		err := tx.Unscoped().Model(&models.Board{}).
+			Where("id = ?", id).
+			UpdateColumns(map[string]interface{}{
+				"deleted_at": nil,
+				"version":    gorm.Expr("version + 1"),
+			}).Error
+		if err != nil {
+			return models.NewDatabaseError("restoring board", err)
+		}
+		return nil
+	})
+}
 
+// Purge безвозвратно удаляет доску (в том числе ранее удаленную) и все, что к ней относится:
+// колонки, дорожки, карточки с комментариями, метками, подписками, гостями и напоминаниями, метки доски,
+// участников, вебхуки, публичные ссылки, уведомления и журнал событий.
+func (r *BoardRepo) Purge(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		columnIDs := tx.Unscoped().Model(&models.Column{}).Select("id").Where("board_id = ?", id)
+		cardIDs := tx.Unscoped().Model(&models.Card{}).Select("id").Where("column_id IN (?)", columnIDs)
+		labelIDs := tx.Model(&models.Label{}).Select("id").Where("board_id = ?", id)
+		commentIDs := tx.Unscoped().Model(&models.Comment{}).Select("id").Where("card_id IN (?)", cardIDs)
+
+		steps := []struct {
+			name  string
+			query *gorm.DB
+			model interface{}
+		}{
+			{"comment revisions", tx.Where("comment_id IN (?)", commentIDs), &models.CommentRevision{}},
+			{"mentions", tx.Where("card_id IN (?)", cardIDs), &models.Mention{}},
+			{"notifications", tx.Where("board_id = ? OR card_id IN (?)", id, cardIDs), &models.Notification{}},
+			{"card watchers", tx.Where("card_id IN (?)", cardIDs), &models.CardWatcher{}},
+			{"card guests", tx.Where("card_id IN (?)", cardIDs), &models.CardGuest{}},
+			{"card reminders", tx.Where("card_id IN (?)", cardIDs), &models.CardReminder{}},
+			{"card labels", tx.Where("card_id IN (?) OR label_id IN (?)", cardIDs, labelIDs), &models.CardLabel{}},
+			{"comments", tx.Unscoped().Where("card_id IN (?)", cardIDs), &models.Comment{}},
+			{"cards", tx.Unscoped().Where("column_id IN (?)", columnIDs), &models.Card{}},
+			{"columns", tx.Unscoped().Where("board_id = ?", id), &models.Column{}},
+			{"swimlanes", tx.Unscoped().Where("board_id = ?", id), &models.Swimlane{}},
+			{"labels", tx.Where("board_id = ?", id), &models.Label{}},
+			{"board members", tx.Where("board_id = ?", id), &models.BoardMember{}},
+			{"board stars", tx.Where("board_id = ?", id), &models.BoardStar{}},
+			{"board views", tx.Where("board_id = ?", id), &models.BoardView{}},
+			{"board presence", tx.Where("board_id = ?", id), &models.BoardPresence{}},
+			{"webhooks", tx.Where("board_id = ?", id), &models.Webhook{}},
+			{"share links", tx.Where("board_id = ?", id), &models.BoardShareLink{}},
+			{"notification preferences", tx.Where("board_id = ?", id), &models.NotificationPreference{}},
+			{"board events", tx.Where("board_id = ?", id), &models.BoardEventLog{}},
+		}
+		for _, step := range steps {
+			if err := step.query.Delete(step.model).Error; err != nil {
+				return models.NewDatabaseError("purging "+step.name, err)
+			}
+		}
+
+		result := tx.Unscoped().Delete(&models.Board{}, id)
+		if result.Error != nil {
+			return models.NewDatabaseError("purging board", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrBoardNotFound
+		}
 		return nil
 	})
-}
\ No newline at end of file
+}
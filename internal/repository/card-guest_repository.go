@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CardGuestRepo struct {
+	db *gorm.DB
+}
+
+func NewCardGuestRepo(db *gorm.DB) *CardGuestRepo {
+	return &CardGuestRepo{db: db}
+}
+
+// Add открывает карточку гостю; повторное добавление ничего не делает.
+func (r *CardGuestRepo) Add(ctx context.Context, cardID, userID uint) error {
+	guest := models.CardGuest{
+		CardID: cardID,
+		UserID: userID,
+	}
+
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Omit(clause.Associations).
+		Create(&guest)
+	if result.Error != nil {
+		return models.NewDatabaseError("adding card guest", result.Error)
+	}
+	return nil
+}
+
+// Remove закрывает карточку от гостя; если она не была открыта, ничего не делает.
+func (r *CardGuestRepo) Remove(ctx context.Context, cardID, userID uint) error {
+	result := dbFromContext(ctx, r.db).
+		Where("card_id = ? AND user_id = ?", cardID, userID).
+		Delete(&models.CardGuest{})
+	if result.Error != nil {
+		return models.NewDatabaseError("removing card guest", result.Error)
+	}
+	return nil
+}
+
+// RemoveFromBoard закрывает от пользователя все карточки доски.
+func (r *CardGuestRepo) RemoveFromBoard(ctx context.Context, boardID, userID uint) error {
+	db := dbFromContext(ctx, r.db)
+	result := db.
+		Where("user_id = ? AND card_id IN (?)", userID, boardCardIDs(db, boardID)).
+		Delete(&models.CardGuest{})
+	if result.Error != nil {
+		return models.NewDatabaseError("removing board card guests", result.Error)
+	}
+	return nil
+}
+
+// GetByBoardID возвращает неудаленные карточки доски, открытые гостям, вместе с карточками.
+func (r *CardGuestRepo) GetByBoardID(ctx context.Context, boardID uint) ([]models.CardGuest, error) {
+	var guests []models.CardGuest
+	db := dbFromContext(ctx, r.db)
+	result := db.
+		Preload("Card").
+		Where("card_id IN (?)", boardCardIDs(db, boardID).Where("cards.deleted_at IS NULL")).
+		Order("user_id ASC, card_id ASC").
+		Find(&guests)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting board card guests", result.Error)
+	}
+	return guests, nil
+}
+
+// GetCardIDs возвращает ID карточек доски, открытых пользователю, по возрастанию.
+func (r *CardGuestRepo) GetCardIDs(ctx context.Context, boardID, userID uint) ([]uint, error) {
+	var ids []uint
+	db := dbFromContext(ctx, r.db)
+	result := db.
+		Model(&models.CardGuest{}).
+		Where("user_id = ? AND card_id IN (?)", userID, boardCardIDs(db, boardID)).
+		Order("card_id ASC").
+		Pluck("card_id", &ids)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting guest card IDs", result.Error)
+	}
+	return ids, nil
+}
+
+// CountByColumn возвращает число неудаленных карточек доски, открытых пользователю, по колонкам.
+// Колонки без таких карточек в результат не попадают.
+func (r *CardGuestRepo) CountByColumn(ctx context.Context, boardID, userID uint) (map[uint]int, error) {
+	var rows []struct {
+		ColumnID uint
+		Count    int
+	}
+	result := dbFromContext(ctx, r.db).
+		Model(&models.Card{}).
+		Select("cards.column_id, COUNT(*) AS count").
+		Joins("JOIN columns ON columns.id = cards.column_id").
+		Joins("JOIN card_guests ON card_guests.card_id = cards.id AND card_guests.user_id = ?", userID).
+		Where("columns.board_id = ?", boardID).
+		Group("cards.column_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("counting guest cards by column", result.Error)
+	}
+
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.ColumnID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetHiddenCardIDs возвращает те карточки из cardIDs, которые скрыты от пользователя:
+// он гость их доски, и карточки ему не открыты.
+func (r *CardGuestRepo) GetHiddenCardIDs(ctx context.Context, userID uint, cardIDs []uint) ([]uint, error) {
+	if len(cardIDs) == 0 {
+		return nil, nil
+	}
+
+	var ids []uint
+	result := dbFromContext(ctx, r.db).
+		Unscoped().
+		Model(&models.Card{}).
+		Joins("JOIN columns ON columns.id = cards.column_id").
+		Joins("JOIN board_members ON board_members.board_id = columns.board_id AND board_members.user_id = ? AND board_members.role = ?", userID, models.BoardRoleGuest).
+		Where("cards.id IN ?", cardIDs).
+		Where("NOT EXISTS (SELECT 1 FROM card_guests WHERE card_guests.card_id = cards.id AND card_guests.user_id = ?)", userID).
+		Pluck("cards.id", &ids)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting hidden card IDs", result.Error)
+	}
+	return ids, nil
+}
+
+// GetHiddenUserIDs возвращает тех пользователей из userIDs, от которых карточка скрыта.
+func (r *CardGuestRepo) GetHiddenUserIDs(ctx context.Context, cardID uint, userIDs []uint) ([]uint, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	var ids []uint
+	result := dbFromContext(ctx, r.db).
+		Unscoped().
+		Model(&models.Card{}).
+		Joins("JOIN columns ON columns.id = cards.column_id").
+		Joins("JOIN board_members ON board_members.board_id = columns.board_id AND board_members.role = ?", models.BoardRoleGuest).
+		Where("cards.id = ? AND board_members.user_id IN ?", cardID, userIDs).
+		Where("NOT EXISTS (SELECT 1 FROM card_guests WHERE card_guests.card_id = cards.id AND card_guests.user_id = board_members.user_id)").
+		Pluck("board_members.user_id", &ids)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting users the card is hidden from", result.Error)
+	}
+	return ids, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/octaview/kanban-octaview/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CardLabelRepo struct {
@@ -23,7 +24,7 @@ func (r *CardLabelRepo) AddLabelToCard(ctx context.Context, cardID uint, labelID
 	}
 
 	var existingCount int64
-	if err := r.db.WithContext(ctx).Model(&models.CardLabel{}).
+	if err := dbFromContext(ctx, r.db).Model(&models.CardLabel{}).
 		Where("card_id = ? AND label_id = ?", cardID, labelID).
 		Count(&existingCount).Error; err != nil {
 		return models.NewDatabaseError("checking existing card-label association", err)
@@ -33,7 +34,7 @@ func (r *CardLabelRepo) AddLabelToCard(ctx context.Context, cardID uint, labelID
 		return nil
 	}
 
-	result := r.db.WithContext(ctx).Create(&cardLabel)
+	result := dbFromContext(ctx, r.db).Create(&cardLabel)
 	if result.Error != nil {
 		return models.NewDatabaseError("adding label to card", result.Error)
 	}
@@ -41,7 +42,7 @@ func (r *CardLabelRepo) AddLabelToCard(ctx context.Context, cardID uint, labelID
 }
 
 func (r *CardLabelRepo) RemoveLabelFromCard(ctx context.Context, cardID uint, labelID uint) error {
-	result := r.db.WithContext(ctx).
+	result := dbFromContext(ctx, r.db).
 		Where("card_id = ? AND label_id = ?", cardID, labelID).
 		Delete(&models.CardLabel{})
 	
@@ -59,7 +60,7 @@ func (r *CardLabelRepo) RemoveLabelFromCard(ctx context.Context, cardID uint, la
 func (r *CardLabelRepo) GetLabelsByCardID(ctx context.Context, cardID uint) ([]models.Label, error) {
 	var labels []models.Label
 	
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Select("labels.*").
 		Joins("JOIN card_labels ON labels.id = card_labels.label_id").
 		Where("card_labels.card_id = ?", cardID).
@@ -72,10 +73,49 @@ func (r *CardLabelRepo) GetLabelsByCardID(ctx context.Context, cardID uint) ([]m
 	return labels, nil
 }
 
+// GetLabelsByCardIDs возвращает метки каждой карточки из cardIDs одним запросом.
+func (r *CardLabelRepo) GetLabelsByCardIDs(ctx context.Context, cardIDs []uint) (map[uint][]models.Label, error) {
+	labels := make(map[uint][]models.Label)
+	if len(cardIDs) == 0 {
+		return labels, nil
+	}
+
+	var rows []struct {
+		models.Label
+		CardID uint
+	}
+	err := dbFromContext(ctx, r.db).
+		Table("labels").
+		Select("labels.*, card_labels.card_id").
+		Joins("JOIN card_labels ON labels.id = card_labels.label_id").
+		Where("card_labels.card_id IN ?", cardIDs).
+		Order("labels.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, models.NewDatabaseError("getting labels by card IDs", err)
+	}
+
+	for _, row := range rows {
+		labels[row.CardID] = append(labels[row.CardID], row.Label)
+	}
+	return labels, nil
+}
+
+// AddMany привязывает метки к карточкам одной вставкой; уже существующие связи не проверяются.
+func (r *CardLabelRepo) AddMany(ctx context.Context, cardLabels []models.CardLabel) error {
+	if len(cardLabels) == 0 {
+		return nil
+	}
+	if err := dbFromContext(ctx, r.db).Omit(clause.Associations).CreateInBatches(&cardLabels, createBatchSize).Error; err != nil {
+		return models.NewDatabaseError("adding labels to cards", err)
+	}
+	return nil
+}
+
 func (r *CardLabelRepo) GetCardsByLabelID(ctx context.Context, labelID uint) ([]models.Card, error) {
 	var cards []models.Card
 	
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Select("cards.*").
 		Joins("JOIN card_labels ON cards.id = card_labels.card_id").
 		Where("card_labels.label_id = ? AND cards.deleted_at IS NULL", labelID).
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CardReminderRepo struct {
+	db *gorm.DB
+}
+
+func NewCardReminderRepo(db *gorm.DB) *CardReminderRepo {
+	return &CardReminderRepo{db: db}
+}
+
+// Replace заменяет неотправленные напоминания карточки. Уже отправленные напоминания
+// для того же срока не создаются заново.
+func (r *CardReminderRepo) Replace(ctx context.Context, cardID uint, reminders []models.CardReminder) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("card_id = ? AND sent_at IS NULL", cardID).
+			Delete(&models.CardReminder{}).Error; err != nil {
+			return models.NewDatabaseError("deleting pending reminders", err)
+		}
+
+		if len(reminders) == 0 {
+			return nil
+		}
+
+		for i := range reminders {
+			reminders[i].CardID = cardID
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&reminders).Error; err != nil {
+			return models.NewDatabaseError("creating reminders", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *CardReminderRepo) CancelPending(ctx context.Context, cardID uint) error {
+	result := dbFromContext(ctx, r.db).
+		Where("card_id = ? AND sent_at IS NULL", cardID).
+		Delete(&models.CardReminder{})
+	if result.Error != nil {
+		return models.NewDatabaseError("cancelling reminders", result.Error)
+	}
+	return nil
+}
+
+// ClaimDue помечает наступившие напоминания отправленными и возвращает их.
+// Отметка ставится до отправки, поэтому после перезапуска напоминание не повторится.
+func (r *CardReminderRepo) ClaimDue(ctx context.Context, now time.Time, limit int) ([]models.CardReminder, error) {
+	var reminders []models.CardReminder
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("sent_at IS NULL AND fire_at <= ?", now).
+			Order("fire_at ASC, id ASC").
+			Limit(limit).
+			Find(&reminders).Error; err != nil {
+			return err
+		}
+
+		if len(reminders) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(reminders))
+		for i, reminder := range reminders {
+			ids[i] = reminder.ID
+		}
+
+		return tx.Model(&models.CardReminder{}).
+			Where("id IN ?", ids).
+			Update("sent_at", now).Error
+	})
+	if err != nil {
+		return nil, models.NewDatabaseError("claiming reminders", err)
+	}
+	return reminders, nil
+}
+
+// GetUnplannedCards возвращает карточки с будущим сроком, для которых еще нет ни одного напоминания.
+func (r *CardReminderRepo) GetUnplannedCards(ctx context.Context, now time.Time) ([]models.Card, error) {
+	var cards []models.Card
+	result := dbFromContext(ctx, r.db).
+		Where("due_date IS NOT NULL AND due_date > ?", now).
+		Where("NOT EXISTS (?)",
+			r.db.Model(&models.CardReminder{}).Select("1").Where("card_reminders.card_id = cards.id"),
+		).
+		Order("due_date ASC").
+		Find(&cards)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting cards without reminders", result.Error)
+	}
+	return cards, nil
+}
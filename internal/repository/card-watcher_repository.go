@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CardWatcherRepo struct {
+	db *gorm.DB
+}
+
+func NewCardWatcherRepo(db *gorm.DB) *CardWatcherRepo {
+	return &CardWatcherRepo{db: db}
+}
+
+// Watch подписывает пользователя на карточку; повторная подписка ничего не делает.
+func (r *CardWatcherRepo) Watch(ctx context.Context, cardID, userID uint) error {
+	watcher := models.CardWatcher{
+		CardID: cardID,
+		UserID: userID,
+	}
+
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&watcher)
+	if result.Error != nil {
+		return models.NewDatabaseError("watching card", result.Error)
+	}
+	return nil
+}
+
+func (r *CardWatcherRepo) Unwatch(ctx context.Context, cardID, userID uint) error {
+	result := dbFromContext(ctx, r.db).
+		Where("card_id = ? AND user_id = ?", cardID, userID).
+		Delete(&models.CardWatcher{})
+	if result.Error != nil {
+		return models.NewDatabaseError("unwatching card", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotWatching
+	}
+	return nil
+}
+
+func (r *CardWatcherRepo) GetByCardID(ctx context.Context, cardID uint) ([]models.CardWatcher, error) {
+	var watchers []models.CardWatcher
+	result := dbFromContext(ctx, r.db).
+		Preload("User").
+		Where("card_id = ?", cardID).
+		Order("created_at ASC").
+		Find(&watchers)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting card watchers", result.Error)
+	}
+	return watchers, nil
+}
+
+func (r *CardWatcherRepo) GetWatcherIDs(ctx context.Context, cardID uint) ([]uint, error) {
+	var userIDs []uint
+	result := dbFromContext(ctx, r.db).
+		Model(&models.CardWatcher{}).
+		Where("card_id = ?", cardID).
+		Pluck("user_id", &userIDs)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting card watcher IDs", result.Error)
+	}
+	return userIDs, nil
+}
+
+func (r *CardWatcherRepo) GetWatchedCards(ctx context.Context, userID uint) ([]models.Card, error) {
+	var cards []models.Card
+	err := dbFromContext(ctx, r.db).
+		Select("cards.*").
+		Joins("JOIN card_watchers ON cards.id = card_watchers.card_id").
+		Where("card_watchers.user_id = ?", userID).
+		Order("cards.updated_at DESC").
+		Find(&cards).Error
+	if err != nil {
+		return nil, models.NewDatabaseError("getting watched cards", err)
+	}
+	return cards, nil
+}
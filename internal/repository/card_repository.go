@@ -3,11 +3,17 @@ package repository
 import (
 	"context"
 	"errors"
+	"math"
+	"time"
 
 	"github.com/octaview/kanban-octaview/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// createBatchSize ограничивает число строк в одном INSERT при массовой вставке.
+const createBatchSize = 1000
+
 type CardRepo struct {
 	db *gorm.DB
 }
@@ -16,19 +22,15 @@ func NewCardRepo(db *gorm.DB) *CardRepo {
 	return &CardRepo{db: db}
 }
 
+// Create добавляет карточку в конец ее ячейки: колонки и дорожки.
 func (r *CardRepo) Create(ctx context.Context, card *models.Card) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var maxPosition struct {
-			Max int
-		}
-		if err := tx.Model(&models.Card{}).
-			Select("COALESCE(MAX(position), -1) as max").
-			Where("column_id = ?", card.ColumnID).
-			Scan(&maxPosition).Error; err != nil {
-			return models.NewDatabaseError("getting max card position", err)
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		rank, position, err := cardList.rankAt(tx, card.ColumnID, card.SwimlaneID, 0, math.MaxInt32)
+		if err != nil {
+			return err
 		}
-
-		card.Position = maxPosition.Max + 1
+		card.Rank = rank
+		card.Position = position
 
 		if err := tx.Create(card).Error; err != nil {
 			return models.NewDatabaseError("creating card", err)
@@ -38,9 +40,39 @@ func (r *CardRepo) Create(ctx context.Context, card *models.Card) error {
 	})
 }
 
+// CreateOrdered добавляет карточки в новые колонки; в каждой колонке карточки встают
+// в порядке следования в срезе. Колонки должны быть пустыми: ранги раздаются без учета
+// существующих карточек.
+func (r *CardRepo) CreateOrdered(ctx context.Context, cards []models.Card) error {
+	if len(cards) == 0 {
+		return nil
+	}
+	spreadRanks(len(cards),
+		func(i int) uint { return cards[i].ColumnID },
+		func(i int, rank string) { cards[i].Rank = rank })
+
+	if err := dbFromContext(ctx, r.db).Omit(clause.Associations).CreateInBatches(&cards, createBatchSize).Error; err != nil {
+		return models.NewDatabaseError("creating cards", err)
+	}
+	return nil
+}
+
 func (r *CardRepo) GetByID(ctx context.Context, id uint) (*models.Card, error) {
 	var card models.Card
-	result := r.db.WithContext(ctx).First(&card, id)
+	result := dbFromContext(ctx, r.db).Select(cardList.positionSelect()).First(&card, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrCardNotFound
+		}
+		return nil, models.NewDatabaseError("getting card by ID", result.Error)
+	}
+	return &card, nil
+}
+
+// GetByIDUnscoped возвращает карточку, в том числе удаленную. Позиция карточки не заполняется.
+func (r *CardRepo) GetByIDUnscoped(ctx context.Context, id uint) (*models.Card, error) {
+	var card models.Card
+	result := dbFromContext(ctx, r.db).Unscoped().First(&card, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, models.ErrCardNotFound
@@ -52,9 +84,10 @@ func (r *CardRepo) GetByID(ctx context.Context, id uint) (*models.Card, error) {
 
 func (r *CardRepo) GetByColumnID(ctx context.Context, columnID uint) ([]models.Card, error) {
 	var cards []models.Card
-	result := r.db.WithContext(ctx).
+	result := dbFromContext(ctx, r.db).
+		Select(cardList.positionsSelect()).
 		Where("column_id = ?", columnID).
-		Order("position ASC").
+		Order(cardList.order()).
 		Find(&cards)
 	if result.Error != nil {
 		return nil, models.NewDatabaseError("getting cards by column ID", result.Error)
@@ -62,99 +95,117 @@ func (r *CardRepo) GetByColumnID(ctx context.Context, columnID uint) ([]models.C
 	return cards, nil
 }
 
-func (r *CardRepo) Update(ctx context.Context, card *models.Card) error {
-	result := r.db.WithContext(ctx).Save(card)
-	if result.Error != nil {
-		return models.NewDatabaseError("updating card", result.Error)
+// GetUpdatedSince возвращает карточки указанных досок, измененные после since, вместе с колонками.
+func (r *CardRepo) GetUpdatedSince(ctx context.Context, boardIDs []uint, since time.Time) ([]models.Card, error) {
+	var cards []models.Card
+	if len(boardIDs) == 0 {
+		return cards, nil
 	}
-	if result.RowsAffected == 0 {
-		return models.ErrCardNotFound
+
+	result := dbFromContext(ctx, r.db).
+		Preload("Column").
+		Joins("JOIN columns ON columns.id = cards.column_id AND columns.deleted_at IS NULL").
+		Where("columns.board_id IN ? AND cards.updated_at > ?", boardIDs, since).
+		Order("cards.updated_at DESC").
+		Find(&cards)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting updated cards", result.Error)
 	}
-	return nil
+	return cards, nil
 }
 
-func (r *CardRepo) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var card models.Card
-		if err := tx.First(&card, id).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return models.ErrCardNotFound
-			}
-			return models.NewDatabaseError("finding card for deletion", err)
-		}
-
-		if err := tx.Delete(&card).Error; err != nil {
-			return models.NewDatabaseError("deleting card", err)
-		}
+// Update сохраняет карточку, если ее версия не изменилась с момента чтения, и увеличивает версию.
+func (r *CardRepo) Update(ctx context.Context, card *models.Card) error {
+	return updateVersioned(dbFromContext(ctx, r.db), card, card.ID, &card.Version, models.ErrCardNotFound, "updating card")
+}
 
-		if err := tx.Exec("UPDATE cards SET position = position - 1 WHERE column_id = ? AND position > ? AND deleted_at IS NULL",
-			card.ColumnID, card.Position).Error; err != nil {
-			return models.NewDatabaseError("reordering cards after deletion", err)
+// Delete мягко удаляет карточку вместе с ее комментариями.
+func (r *CardRepo) Delete(ctx context.Context, id uint, version int) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		at, err := softDeleteVersioned(tx, &models.Card{}, id, version, models.ErrCardNotFound, "deleting card")
+		if err != nil {
+			return err
 		}
-
-		return nil
+		return softDeleteCascade(tx, cardCascade, id, at)
 	})
 }
 
-func (r *CardRepo) UpdatePositions(ctx context.Context, cards []models.Card) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		for i, card := range cards {
-			if err := tx.Model(&models.Card{}).
-				Where("id = ?", card.ID).
-				Update("position", i).Error; err != nil {
-				return models.NewDatabaseError("updating card position", err)
-			}
-		}
-		return nil
+// Reorder расставляет карточки колонки в порядке ids. Возвращает false, если порядок не изменился.
+func (r *CardRepo) Reorder(ctx context.Context, columnID uint, ids []uint) (bool, error) {
+	var changed bool
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var err error
+		changed, err = cardList.reorder(tx, columnID, ids)
+		return err
 	})
+	return changed, err
 }
 
-func (r *CardRepo) MoveToColumn(ctx context.Context, cardID, columnID uint, position int) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+// MoveToColumn ставит карточку на позицию position в ячейке колонки columnID и дорожки
+// swimlaneID и возвращает итоговую позицию. Меняется только строка перемещаемой карточки.
+func (r *CardRepo) MoveToColumn(ctx context.Context, cardID, columnID uint, swimlaneID *uint, position int) (int, error) {
+	var moved int
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		var card models.Card
-		if err := tx.First(&card, cardID).Error; err != nil {
+		if err := tx.Select("id").First(&card, cardID).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return models.ErrCardNotFound
 			}
 			return models.NewDatabaseError("finding card for moving", err)
 		}
 
-		oldColumnID := card.ColumnID
-		oldPosition := card.Position
-
-		if oldColumnID == columnID {
-			if position < oldPosition {
-				if err := tx.Exec("UPDATE cards SET position = position + 1 WHERE column_id = ? AND position >= ? AND position < ? AND deleted_at IS NULL",
-					columnID, position, oldPosition).Error; err != nil {
-					return models.NewDatabaseError("shifting cards for move within column (up)", err)
-				}
-			}
-			if position > oldPosition {
-				if err := tx.Exec("UPDATE cards SET position = position - 1 WHERE column_id = ? AND position > ? AND position <= ? AND deleted_at IS NULL",
-					columnID, oldPosition, position).Error; err != nil {
-					return models.NewDatabaseError("shifting cards for move within column (down)", err)
-				}
-			}
-		} else {
+		rank, actual, err := cardList.rankAt(tx, columnID, swimlaneID, cardID, position)
+		if err != nil {
+			return err
+		}
+		moved = actual
 
-			if err := tx.Exec("UPDATE cards SET position = position + 1 WHERE column_id = ? AND position >= ? AND deleted_at IS NULL",
-				columnID, position).Error; err != nil {
-				return models.NewDatabaseError("shifting cards in new column", err)
-			}
+		return cardList.setRank(tx, cardID, columnID, swimlaneID, rank)
+	})
+	return moved, err
+}
 
-			if err := tx.Exec("UPDATE cards SET position = position - 1 WHERE column_id = ? AND position > ? AND deleted_at IS NULL",
-				oldColumnID, oldPosition).Error; err != nil {
-				return models.NewDatabaseError("shifting cards in old column", err)
-			}
-		}
+// GetByBoardID возвращает карточки всех колонок доски; позиции отсчитываются внутри ячеек.
+func (r *CardRepo) GetByBoardID(ctx context.Context, boardID uint) ([]models.Card, error) {
+	var cards []models.Card
+	result := dbFromContext(ctx, r.db).
+		Select(cardList.positionsSelect()).
+		Joins("JOIN columns ON columns.id = cards.column_id AND columns.deleted_at IS NULL").
+		Where("columns.board_id = ?", boardID).
+		Order(cardList.order()).
+		Find(&cards)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting cards by board ID", result.Error)
+	}
+	return cards, nil
+}
 
-		if err := tx.Model(&card).Updates(map[string]interface{}{
-			"column_id": columnID,
-			"position":  position,
-		}).Error; err != nil {
-			return models.NewDatabaseError("updating card column and position", err)
-		}
+// SetProgress записывает отметки начала и завершения работы над карточкой. Версия не
+// увеличивается: отметки меняются только вместе с перемещением, которое ее уже увеличило.
+func (r *CardRepo) SetProgress(ctx context.Context, cardID uint, startedAt, completedAt *time.Time) error {
+	result := dbFromContext(ctx, r.db).Model(&models.Card{}).
+		Where("id = ?", cardID).
+		UpdateColumns(map[string]interface{}{
+			"started_at":   startedAt,
+			"completed_at": completedAt,
+		})
+	if result.Error != nil {
+		return models.NewDatabaseError("updating card progress", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrCardNotFound
+	}
+	return nil
+}
 
-		return nil
+// Rebalance заново раздает ранги карточкам колонки, не меняя их порядка.
+func (r *CardRepo) Rebalance(ctx context.Context, columnID uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return cardList.rebalance(tx, columnID)
 	})
-}
\ No newline at end of file
+}
+
+// GetUnbalancedColumnIDs возвращает колонки, ранги карточек в которых пора перераздать.
+func (r *CardRepo) GetUnbalancedColumnIDs(ctx context.Context, maxRankLength, limit int) ([]uint, error) {
+	return cardList.unbalanced(dbFromContext(ctx, r.db), maxRankLength, limit)
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+// Мягкое удаление распространяется по дереву доска → колонки и дорожки → карточки → комментарии.
+// Потомки получают ту же отметку deleted_at, что и удаленный родитель: по ней восстановление
+// возвращает только записи, удаленные вместе с ним, а не удаленные раньше по одной.
+
+// cascadeStep — таблица потомков и условие, связывающее ее строки с родителем @parent.
+type cascadeStep struct {
+	table string
+	where string
+}
+
+var boardCascade = []cascadeStep{
+	{"columns", "board_id = @parent"},
+	{"swimlanes", "board_id = @parent"},
+	{"cards", "column_id IN (SELECT id FROM columns WHERE board_id = @parent)"},
+	{"comments", "card_id IN (SELECT cards.id FROM cards JOIN columns ON columns.id = cards.column_id WHERE columns.board_id = @parent)"},
+}
+
+var columnCascade = []cascadeStep{
+	{"cards", "column_id = @parent"},
+	{"comments", "card_id IN (SELECT id FROM cards WHERE column_id = @parent)"},
+}
+
+var cardCascade = []cascadeStep{
+	{"comments", "card_id = @parent"},
+}
+
+// softDeleteCascade мягко удаляет еще не удаленных потомков родителя parentID с отметкой at.
+func softDeleteCascade(tx *gorm.DB, steps []cascadeStep, parentID uint, at time.Time) error {
+	for _, step := range steps {
+		query := fmt.Sprintf("UPDATE %s SET deleted_at = @at WHERE deleted_at IS NULL AND %s", step.table, step.where)
+		if err := tx.Exec(query, sql.Named("parent", parentID), sql.Named("at", at)).Error; err != nil {
+			return models.NewDatabaseError("deleting "+step.table, err)
+		}
+	}
+	return nil
+}
+
+// restoreCascade восстанавливает потомков родителя parentID, удаленных вместе с ним в момент at.
+func restoreCascade(tx *gorm.DB, steps []cascadeStep, parentID uint, at time.Time) error {
+	for _, step := range steps {
+		query := fmt.Sprintf("UPDATE %s SET deleted_at = NULL WHERE deleted_at = @at AND %s", step.table, step.where)
+		if err := tx.Exec(query, sql.Named("parent", parentID), sql.Named("at", at)).Error; err != nil {
+			return models.NewDatabaseError("restoring "+step.table, err)
+		}
+	}
+	return nil
+}
+
+// softDeleteVersioned мягко удаляет запись с версией version и возвращает отметку удаления.
+func softDeleteVersioned(tx *gorm.DB, model interface{}, id uint, version int, notFound error, operation string) (time.Time, error) {
+	at := time.Now()
+	result := tx.Model(model).
+		Where("id = ? AND version = ?", id, version).
+		UpdateColumn("deleted_at", at)
+	if result.Error != nil {
+		return at, models.NewDatabaseError(operation, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return at, versionConflict(tx, model, id, notFound)
+	}
+	return at, nil
+}
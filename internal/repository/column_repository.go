@@ -3,11 +3,16 @@ package repository
 import (
 	"context"
 	"errors"
+	"math"
 
 	"github.com/octaview/kanban-octaview/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// cardCountSelect — выражение для SELECT с числом карточек в колонке.
+const cardCountSelect = "(SELECT COUNT(*) FROM cards WHERE cards.column_id = columns.id AND cards.deleted_at IS NULL) AS card_count"
+
 type ColumnRepo struct {
 	db *gorm.DB
 }
@@ -16,19 +21,15 @@ func NewColumnRepo(db *gorm.DB) *ColumnRepo {
 	return &ColumnRepo{db: db}
 }
 
+// Create добавляет колонку в конец доски.
 func (r *ColumnRepo) Create(ctx context.Context, column *models.Column) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var maxPosition struct {
-			Max int
-		}
-		if err := tx.Model(&models.Column{}).
-			Select("COALESCE(MAX(position), -1) as max").
-			Where("board_id = ?", column.BoardID).
-			Scan(&maxPosition).Error; err != nil {
-			return models.NewDatabaseError("getting max column position", err)
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		rank, position, err := columnList.rankAt(tx, column.BoardID, nil, 0, math.MaxInt32)
+		if err != nil {
+			return err
 		}
-
-		column.Position = maxPosition.Max + 1
+		column.Rank = rank
+		column.Position = position
 
 		if err := tx.Create(column).Error; err != nil {
 			return models.NewDatabaseError("creating column", err)
@@ -38,9 +39,39 @@ func (r *ColumnRepo) Create(ctx context.Context, column *models.Column) error {
 	})
 }
 
+// CreateOrdered добавляет колонки новой доски в порядке следования в срезе.
+// Колонки должны быть первыми на своих досках: ранги раздаются без учета существующих.
+func (r *ColumnRepo) CreateOrdered(ctx context.Context, columns []models.Column) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	spreadRanks(len(columns),
+		func(i int) uint { return columns[i].BoardID },
+		func(i int, rank string) { columns[i].Rank = rank })
+
+	if err := dbFromContext(ctx, r.db).Omit(clause.Associations).Create(&columns).Error; err != nil {
+		return models.NewDatabaseError("creating columns", err)
+	}
+	return nil
+}
+
 func (r *ColumnRepo) GetByID(ctx context.Context, id uint) (*models.Column, error) {
 	var column models.Column
-	result := r.db.WithContext(ctx).First(&column, id)
+	result := dbFromContext(ctx, r.db).Select(columnList.positionSelect()+", "+cardCountSelect).First(&column, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrColumnNotFound
+		}
+		return nil, models.NewDatabaseError("getting column by ID", result.Error)
+	}
+	markOverLimit(&column)
+	return &column, nil
+}
+
+// GetByIDUnscoped возвращает колонку, в том числе удаленную. Позиция и число карточек не заполняются.
+func (r *ColumnRepo) GetByIDUnscoped(ctx context.Context, id uint) (*models.Column, error) {
+	var column models.Column
+	result := dbFromContext(ctx, r.db).Unscoped().First(&column, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, models.ErrColumnNotFound
@@ -52,59 +83,101 @@ func (r *ColumnRepo) GetByID(ctx context.Context, id uint) (*models.Column, erro
 
 func (r *ColumnRepo) GetByBoardID(ctx context.Context, boardID uint) ([]models.Column, error) {
 	var columns []models.Column
-	result := r.db.WithContext(ctx).
+	result := dbFromContext(ctx, r.db).
+		Select(columnList.positionsSelect()+", "+cardCountSelect).
 		Where("board_id = ?", boardID).
-		Order("position ASC").
+		Order(columnList.order()).
 		Find(&columns)
 	if result.Error != nil {
 		return nil, models.NewDatabaseError("getting columns by board ID", result.Error)
 	}
+	for i := range columns {
+		markOverLimit(&columns[i])
+	}
 	return columns, nil
 }
 
+// Update сохраняет колонку, если ее версия не изменилась с момента чтения, и увеличивает версию.
 func (r *ColumnRepo) Update(ctx context.Context, column *models.Column) error {
-	result := r.db.WithContext(ctx).Save(column)
+	return updateVersioned(dbFromContext(ctx, r.db), column, column.ID, &column.Version, models.ErrColumnNotFound, "updating column")
+}
+
+// Delete мягко удаляет колонку вместе с ее карточками и их комментариями.
+func (r *ColumnRepo) Delete(ctx context.Context, id uint, version int) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		at, err := softDeleteVersioned(tx, &models.Column{}, id, version, models.ErrColumnNotFound, "deleting column")
+		if err != nil {
+			return err
+		}
+		return softDeleteCascade(tx, columnCascade, id, at)
+	})
+}
+
+// Reorder расставляет колонки доски в порядке ids. Возвращает false, если порядок не изменился.
+func (r *ColumnRepo) Reorder(ctx context.Context, boardID uint, ids []uint) (bool, error) {
+	var changed bool
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var err error
+		changed, err = columnList.reorder(tx, boardID, ids)
+		return err
+	})
+	return changed, err
+}
+
+// Rebalance заново раздает ранги колонкам доски, не меняя их порядка.
+func (r *ColumnRepo) Rebalance(ctx context.Context, boardID uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return columnList.rebalance(tx, boardID)
+	})
+}
+
+// GetUnbalancedBoardIDs возвращает доски, ранги колонок в которых пора перераздать.
+func (r *ColumnRepo) GetUnbalancedBoardIDs(ctx context.Context, maxRankLength, limit int) ([]uint, error) {
+	return columnList.unbalanced(dbFromContext(ctx, r.db), maxRankLength, limit)
+}
+
+// LockWIPUsage возвращает WIP-лимит колонки, режим доски и число карточек в колонке.
+// Если лимит соблюдается строго, колонка блокируется до конца транзакции, чтобы
+// одновременные вставки не превысили лимит.
+func (r *ColumnRepo) LockWIPUsage(ctx context.Context, columnID uint) (*models.WIPUsage, error) {
+	db := dbFromContext(ctx, r.db)
+
+	var settings struct {
+		WIPLimit *int   `gorm:"column:wip_limit"`
+		WIPMode  string `gorm:"column:wip_mode"`
+	}
+	result := db.Table("columns").
+		Select("columns.wip_limit, boards.wip_mode").
+		Joins("JOIN boards ON boards.id = columns.board_id").
+		Where("columns.id = ? AND columns.deleted_at IS NULL", columnID).
+		Scan(&settings)
 	if result.Error != nil {
-		return models.NewDatabaseError("updating column", result.Error)
+		return nil, models.NewDatabaseError("getting column WIP limit", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return models.ErrColumnNotFound
+		return nil, models.ErrColumnNotFound
 	}
-	return nil
-}
 
-func (r *ColumnRepo) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var column models.Column
-		if err := tx.First(&column, id).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return models.ErrColumnNotFound
-			}
-			return models.NewDatabaseError("finding column for deletion", err)
-		}
+	usage := &models.WIPUsage{ColumnID: columnID, Limit: settings.WIPLimit, Mode: settings.WIPMode}
+	if usage.Limit == nil {
+		return usage, nil
+	}
 
-		if err := tx.Delete(&column).Error; err != nil {
-			return models.NewDatabaseError("deleting column", err)
+	if usage.Mode == models.WIPModeBlock {
+		if err := cardList.lockParent(db, columnID, true); err != nil {
+			return nil, models.NewDatabaseError("locking column", err)
 		}
+	}
 
-		if err := tx.Exec("UPDATE columns SET position = position - 1 WHERE board_id = ? AND position > ? AND deleted_at IS NULL",
-			column.BoardID, column.Position).Error; err != nil {
-			return models.NewDatabaseError("reordering columns after deletion", err)
-		}
+	var count int64
+	if err := db.Model(&models.Card{}).Where("column_id = ?", columnID).Count(&count).Error; err != nil {
+		return nil, models.NewDatabaseError("counting column cards", err)
+	}
+	usage.Count = int(count)
 
-		return nil
-	})
+	return usage, nil
 }
 
-func (r *ColumnRepo) UpdatePositions(ctx context.Context, columns []models.Column) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		for i, column := range columns {
-			if err := tx.Model(&models.Column{}).
-				Where("id = ?", column.ID).
-				Update("position", i).Error; err != nil {
-				return models.NewDatabaseError("updating column position", err)
-			}
-		}
-		return nil
-	})
-}
\ No newline at end of file
+func markOverLimit(column *models.Column) {
+	column.OverLimit = column.WIPLimit != nil && column.CardCount > *column.WIPLimit
+}
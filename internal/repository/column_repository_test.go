@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+)
+
+func TestColumnRepoLockWIPUsage(t *testing.T) {
+	tests := []struct {
+		name   string
+		limit  driver.Value
+		mode   string
+		count  int64
+		full   bool
+		locked bool
+	}{
+		{name: "block mode at limit", limit: int64(2), mode: models.WIPModeBlock, count: 2, full: true, locked: true},
+		{name: "block mode below limit", limit: int64(3), mode: models.WIPModeBlock, count: 2, full: false, locked: true},
+		{name: "warn mode at limit", limit: int64(2), mode: models.WIPModeWarn, count: 2, full: false, locked: false},
+		{name: "no limit", limit: nil, mode: models.WIPModeBlock, count: 5, full: false, locked: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, db := newFakeDB(t)
+			fake.onQuery("boards.wip_mode", []string{"wip_limit", "wip_mode"}, []driver.Value{tt.limit, tt.mode})
+			fake.onQuery("count(*)", []string{"count"}, []driver.Value{tt.count})
+
+			usage, err := NewColumnRepo(db).LockWIPUsage(context.Background(), 4)
+			if err != nil {
+				t.Fatalf("LockWIPUsage: %v", err)
+			}
+
+			if usage.Mode != tt.mode {
+				t.Errorf("mode = %q, want %q", usage.Mode, tt.mode)
+			}
+			if (usage.Limit == nil) != (tt.limit == nil) || (usage.Limit != nil && int64(*usage.Limit) != tt.limit) {
+				t.Errorf("limit = %v, want %v", usage.Limit, tt.limit)
+			}
+			if got := usage.Full(); got != tt.full {
+				t.Errorf("Full() = %v with %d cards, want %v", got, usage.Count, tt.full)
+			}
+			if locked := len(fake.find("FROM columns WHERE id = $1 FOR UPDATE")) > 0; locked != tt.locked {
+				t.Errorf("column locked = %v, want %v; queries: %q", locked, tt.locked, fake.queries)
+			}
+		})
+	}
+}
+
+func TestColumnRepoLockWIPUsageColumnNotFound(t *testing.T) {
+	_, db := newFakeDB(t)
+	if _, err := NewColumnRepo(db).LockWIPUsage(context.Background(), 4); !errors.Is(err, models.ErrColumnNotFound) {
+		t.Fatalf("LockWIPUsage error = %v, want ErrColumnNotFound", err)
+	}
+}
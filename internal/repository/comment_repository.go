@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/octaview/kanban-octaview/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CommentRepo struct {
@@ -17,16 +19,29 @@ func NewCommentRepo(db *gorm.DB) *CommentRepo {
 }
 
 func (r *CommentRepo) Create(ctx context.Context, comment *models.Comment) error {
-	result := r.db.WithContext(ctx).Create(comment)
-	if result.Error != nil {
-		return models.NewDatabaseError("creating comment", result.Error)
-	}
-	return nil
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comment).Error; err != nil {
+			return models.NewDatabaseError("creating comment", err)
+		}
+
+		revision := models.CommentRevision{
+			CommentID: comment.ID,
+			Version:   1,
+			Content:   comment.Content,
+			EditedBy:  comment.UserID,
+			CreatedAt: comment.CreatedAt,
+		}
+		if err := tx.Create(&revision).Error; err != nil {
+			return models.NewDatabaseError("creating comment revision", err)
+		}
+
+		return nil
+	})
 }
 
 func (r *CommentRepo) GetByID(ctx context.Context, id uint) (*models.Comment, error) {
 	var comment models.Comment
-	result := r.db.WithContext(ctx).First(&comment, id)
+	result := dbFromContext(ctx, r.db).First(&comment, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, models.ErrCommentNotFound
@@ -36,9 +51,11 @@ func (r *CommentRepo) GetByID(ctx context.Context, id uint) (*models.Comment, er
 	return &comment, nil
 }
 
+// GetByCardID возвращает комментарии карточки, включая удаленные, чтобы в ленте остались заглушки.
 func (r *CommentRepo) GetByCardID(ctx context.Context, cardID uint) ([]models.Comment, error) {
 	var comments []models.Comment
-	result := r.db.WithContext(ctx).
+	result := dbFromContext(ctx, r.db).
+		Unscoped().
 		Where("card_id = ?", cardID).
 		Order("created_at DESC").
 		Find(&comments)
@@ -48,24 +65,189 @@ func (r *CommentRepo) GetByCardID(ctx context.Context, cardID uint) ([]models.Co
 	return comments, nil
 }
 
-func (r *CommentRepo) Update(ctx context.Context, comment *models.Comment) error {
-	result := r.db.WithContext(ctx).Save(comment)
+// CountByCardIDs возвращает число неудаленных комментариев у каждой карточки из cardIDs.
+// Карточек без комментариев в результате нет.
+func (r *CommentRepo) CountByCardIDs(ctx context.Context, cardIDs []uint) (map[uint]int, error) {
+	counts := make(map[uint]int)
+	if len(cardIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		CardID uint
+		Count  int
+	}
+	result := dbFromContext(ctx, r.db).
+		Model(&models.Comment{}).
+		Select("card_id, COUNT(*) AS count").
+		Where("card_id IN ?", cardIDs).
+		Group("card_id").
+		Scan(&rows)
 	if result.Error != nil {
-		return models.NewDatabaseError("updating comment", result.Error)
+		return nil, models.NewDatabaseError("counting comments by card IDs", result.Error)
 	}
-	if result.RowsAffected == 0 {
-		return models.ErrCommentNotFound
+
+	for _, row := range rows {
+		counts[row.CardID] = row.Count
 	}
-	return nil
+	return counts, nil
+}
+
+// GetByCardIDs возвращает неудаленные комментарии карточек из cardIDs вместе с авторами,
+// сгруппированные по карточкам, в порядке создания.
+func (r *CommentRepo) GetByCardIDs(ctx context.Context, cardIDs []uint) (map[uint][]models.Comment, error) {
+	grouped := make(map[uint][]models.Comment)
+	if len(cardIDs) == 0 {
+		return grouped, nil
+	}
+
+	var comments []models.Comment
+	result := dbFromContext(ctx, r.db).
+		Preload("User").
+		Where("card_id IN ?", cardIDs).
+		Order("created_at ASC, id ASC").
+		Find(&comments)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting comments by card IDs", result.Error)
+	}
+
+	for _, comment := range comments {
+		grouped[comment.CardID] = append(grouped[comment.CardID], comment)
+	}
+	return grouped, nil
+}
+
+// Update сохраняет новое содержимое комментария и записывает его как очередную ревизию.
+// Если версия комментария в базе отличается от comment.Version, возвращается ErrVersionConflict.
+// Строка комментария блокируется до конца транзакции, поэтому одновременные правки получают
+// номера ревизий по очереди, а опоздавшая правка — ErrVersionConflict. Если содержимое
+// не изменилось, ревизия не записывается и возвращается false.
+func (r *CommentRepo) Update(ctx context.Context, comment *models.Comment, editedBy uint) (bool, error) {
+	var changed bool
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var existing models.Comment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&existing, comment.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return models.ErrCommentNotFound
+			}
+			return models.NewDatabaseError("finding comment for update", err)
+		}
+
+		if existing.Version != comment.Version {
+			return models.ErrVersionConflict
+		}
+		if existing.Content == comment.Content {
+			return nil
+		}
+
+		var lastVersion struct {
+			Max int
+		}
+		if err := tx.Model(&models.CommentRevision{}).
+			Select("COALESCE(MAX(version), 0) as max").
+			Where("comment_id = ?", comment.ID).
+			Scan(&lastVersion).Error; err != nil {
+			return models.NewDatabaseError("getting last comment revision", err)
+		}
+
+		// Комментарии, созданные до появления истории, получают исходную версию задним числом.
+		if lastVersion.Max == 0 {
+			original := models.CommentRevision{
+				CommentID: existing.ID,
+				Version:   1,
+				Content:   existing.Content,
+				EditedBy:  existing.UserID,
+				CreatedAt: existing.CreatedAt,
+			}
+			if err := tx.Create(&original).Error; err != nil {
+				return models.NewDatabaseError("creating original comment revision", err)
+			}
+			lastVersion.Max = 1
+		}
+
+		now := time.Now()
+		revision := models.CommentRevision{
+			CommentID: comment.ID,
+			Version:   lastVersion.Max + 1,
+			Content:   comment.Content,
+			EditedBy:  editedBy,
+			CreatedAt: now,
+		}
+		if err := tx.Create(&revision).Error; err != nil {
+			return models.NewDatabaseError("creating comment revision", err)
+		}
+
+		result := tx.Model(&existing).Where("version = ?", existing.Version).Updates(map[string]interface{}{
+			"content":   comment.Content,
+			"edited_at": now,
+			"version":   gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return models.NewDatabaseError("updating comment", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrVersionConflict
+		}
+
+		comment.EditedAt = &now
+		comment.Version = existing.Version + 1
+		changed = true
+		return nil
+	})
+	return changed, err
 }
 
-func (r *CommentRepo) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&models.Comment{}, id)
+func (r *CommentRepo) Delete(ctx context.Context, id uint, version int) error {
+	db := dbFromContext(ctx, r.db)
+	result := db.Where("version = ?", version).Delete(&models.Comment{}, id)
 	if result.Error != nil {
 		return models.NewDatabaseError("deleting comment", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return models.ErrCommentNotFound
+		return versionConflict(db, &models.Comment{ID: id}, id, models.ErrCommentNotFound)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// Purge безвозвратно удаляет комментарий (в том числе ранее удаленный) вместе с историей правок.
+func (r *CommentRepo) Purge(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id = ?", id).Delete(&models.CommentRevision{}).Error; err != nil {
+			return models.NewDatabaseError("purging comment revisions", err)
+		}
+
+		result := tx.Unscoped().Delete(&models.Comment{}, id)
+		if result.Error != nil {
+			return models.NewDatabaseError("purging comment", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrCommentNotFound
+		}
+
+		return nil
+	})
+}
+
+func (r *CommentRepo) GetByIDUnscoped(ctx context.Context, id uint) (*models.Comment, error) {
+	var comment models.Comment
+	result := dbFromContext(ctx, r.db).Unscoped().First(&comment, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrCommentNotFound
+		}
+		return nil, models.NewDatabaseError("getting comment by ID", result.Error)
+	}
+	return &comment, nil
+}
+
+func (r *CommentRepo) GetRevisions(ctx context.Context, commentID uint) ([]models.CommentRevision, error) {
+	var revisions []models.CommentRevision
+	result := dbFromContext(ctx, r.db).
+		Where("comment_id = ?", commentID).
+		Order("version ASC").
+		Find(&revisions)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting comment revisions", result.Error)
+	}
+	return revisions, nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+)
+
+var commentColumns = []string{"id", "content", "card_id", "user_id", "version", "created_at"}
+
+func TestCommentRepoUpdateLocksCommentBeforeNumberingRevision(t *testing.T) {
+	fake, db := newFakeDB(t)
+	fake.onQuery(`FROM "comments"`, commentColumns, []driver.Value{int64(7), "old", int64(3), int64(1), int64(2), time.Now()})
+	fake.onQuery("MAX(version)", []string{"max"}, []driver.Value{int64(2)})
+	fake.onQuery(`INSERT INTO "comment_revisions"`, []string{"id"}, []driver.Value{int64(11)})
+	fake.onExec(`UPDATE "comments"`, 1)
+
+	comment := &models.Comment{ID: 7, Content: "new", Version: 2}
+	if changed, err := NewCommentRepo(db).Update(context.Background(), comment, 5); err != nil || !changed {
+		t.Fatalf("Update = %v, %v, want a change", changed, err)
+	}
+
+	lock := fake.indexOf(`FROM "comments"`)
+	if lock < 0 || len(fake.find("FOR UPDATE")) != 1 || fake.indexOf("FOR UPDATE") != lock {
+		t.Fatalf("comment row is not locked; queries: %q", fake.queries)
+	}
+	if next := fake.indexOf("MAX(version)"); next < lock {
+		t.Errorf("revision number is read before the comment row is locked; queries: %q", fake.queries)
+	}
+	if got := len(fake.find(`INSERT INTO "comment_revisions"`)); got != 1 {
+		t.Errorf("inserted %d revisions, want 1", got)
+	}
+	if comment.Version != 3 || comment.EditedAt == nil {
+		t.Errorf("comment version = %d, edited_at = %v, want 3 and set", comment.Version, comment.EditedAt)
+	}
+}
+
+func TestCommentRepoUpdateBackfillsOriginalRevision(t *testing.T) {
+	fake, db := newFakeDB(t)
+	fake.onQuery(`FROM "comments"`, commentColumns, []driver.Value{int64(7), "old", int64(3), int64(1), int64(1), time.Now()})
+	fake.onQuery("MAX(version)", []string{"max"}, []driver.Value{int64(0)})
+	fake.onQuery(`INSERT INTO "comment_revisions"`, []string{"id"}, []driver.Value{int64(11)})
+	fake.onExec(`UPDATE "comments"`, 1)
+
+	if _, err := NewCommentRepo(db).Update(context.Background(), &models.Comment{ID: 7, Content: "new", Version: 1}, 5); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := len(fake.find(`INSERT INTO "comment_revisions"`)); got != 2 {
+		t.Errorf("inserted %d revisions, want the original and the edit", got)
+	}
+}
+
+func TestCommentRepoUpdateConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		stored   int64
+		expected int
+		updated  int64
+	}{
+		// Правка, дождавшаяся блокировки после чужой правки, видит новую версию.
+		{name: "stale version", stored: 3, expected: 2, updated: 1},
+		{name: "version changed during update", stored: 2, expected: 2, updated: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, db := newFakeDB(t)
+			fake.onQuery(`FROM "comments"`, commentColumns, []driver.Value{int64(7), "old", int64(3), int64(1), tt.stored, time.Now()})
+			fake.onQuery("MAX(version)", []string{"max"}, []driver.Value{int64(2)})
+			fake.onQuery(`INSERT INTO "comment_revisions"`, []string{"id"}, []driver.Value{int64(11)})
+			fake.onExec(`UPDATE "comments"`, tt.updated)
+
+			_, err := NewCommentRepo(db).Update(context.Background(), &models.Comment{ID: 7, Content: "new", Version: tt.expected}, 5)
+			if !errors.Is(err, models.ErrVersionConflict) {
+				t.Fatalf("Update error = %v, want ErrVersionConflict", err)
+			}
+			if fake.indexOf("ROLLBACK") < 0 {
+				t.Errorf("transaction was not rolled back; queries: %q", fake.queries)
+			}
+		})
+	}
+}
+
+func TestCommentRepoUpdateUnchangedContent(t *testing.T) {
+	fake, db := newFakeDB(t)
+	fake.onQuery(`FROM "comments"`, commentColumns, []driver.Value{int64(7), "same", int64(3), int64(1), int64(2), time.Now()})
+
+	changed, err := NewCommentRepo(db).Update(context.Background(), &models.Comment{ID: 7, Content: "same", Version: 2}, 5)
+	if err != nil || changed {
+		t.Fatalf("Update = %v, %v, want no change", changed, err)
+	}
+	if got := fake.find("comment_revisions"); len(got) != 0 {
+		t.Errorf("unchanged content touched revisions: %q", got)
+	}
+}
+
+func TestCommentRepoUpdateNotFound(t *testing.T) {
+	_, db := newFakeDB(t)
+	_, err := NewCommentRepo(db).Update(context.Background(), &models.Comment{ID: 7, Content: "new"}, 5)
+	if !errors.Is(err, models.ErrCommentNotFound) {
+		t.Fatalf("Update error = %v, want ErrCommentNotFound", err)
+	}
+}
+
+func TestCardAndColumnLookupsIncludeDeleted(t *testing.T) {
+	fake, db := newFakeDB(t)
+	fake.onQuery(`FROM "cards"`, []string{"id", "column_id"}, []driver.Value{int64(3), int64(4)})
+	fake.onQuery(`FROM "columns"`, []string{"id", "board_id"}, []driver.Value{int64(4), int64(9)})
+
+	card, err := NewCardRepo(db).GetByIDUnscoped(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("card GetByIDUnscoped: %v", err)
+	}
+	column, err := NewColumnRepo(db).GetByIDUnscoped(context.Background(), card.ColumnID)
+	if err != nil {
+		t.Fatalf("column GetByIDUnscoped: %v", err)
+	}
+	if column.BoardID != 9 {
+		t.Errorf("board ID = %d, want 9", column.BoardID)
+	}
+	if got := fake.find("deleted_at"); len(got) != 0 {
+		t.Errorf("lookups filter deleted rows: %q", got)
+	}
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EmailRepo struct {
+	db *gorm.DB
+}
+
+func NewEmailRepo(db *gorm.DB) *EmailRepo {
+	return &EmailRepo{db: db}
+}
+
+func (r *EmailRepo) Enqueue(ctx context.Context, email *models.OutboundEmail) error {
+	if email.Status == "" {
+		email.Status = models.EmailStatusPending
+	}
+	if email.NextAttemptAt.IsZero() {
+		email.NextAttemptAt = time.Now()
+	}
+
+	result := dbFromContext(ctx, r.db).Create(email)
+	if result.Error != nil {
+		return models.NewDatabaseError("enqueueing email", result.Error)
+	}
+	return nil
+}
+
+// ClaimDue выбирает письма, готовые к отправке, и откладывает их следующую попытку на lease,
+// чтобы параллельные обработчики не взяли те же письма. Если обработчик упадет,
+// письма снова станут доступны по истечении lease.
+func (r *EmailRepo) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.OutboundEmail, error) {
+	var emails []models.OutboundEmail
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.EmailStatusPending, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&emails).Error; err != nil {
+			return err
+		}
+
+		if len(emails) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(emails))
+		for i, email := range emails {
+			ids[i] = email.ID
+		}
+
+		return tx.Model(&models.OutboundEmail{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, models.NewDatabaseError("claiming emails", err)
+	}
+	return emails, nil
+}
+
+func (r *EmailRepo) MarkSent(ctx context.Context, id uint, sentAt time.Time) error {
+	result := dbFromContext(ctx, r.db).Model(&models.OutboundEmail{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     models.EmailStatusSent,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"sent_at":    sentAt,
+			"last_error": "",
+		})
+	if result.Error != nil {
+		return models.NewDatabaseError("marking email sent", result.Error)
+	}
+	return nil
+}
+
+// MarkAttemptFailed фиксирует неудачную попытку. При нулевом nextAttempt письмо помечается
+// окончательно неотправленным.
+func (r *EmailRepo) MarkAttemptFailed(ctx context.Context, id uint, lastError string, nextAttempt time.Time) error {
+	updates := map[string]interface{}{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": lastError,
+	}
+	if nextAttempt.IsZero() {
+		updates["status"] = models.EmailStatusFailed
+	} else {
+		updates["next_attempt_at"] = nextAttempt
+	}
+
+	result := dbFromContext(ctx, r.db).Model(&models.OutboundEmail{}).
+		Where("id = ?", id).
+		Updates(updates)
+	if result.Error != nil {
+		return models.NewDatabaseError("marking email attempt failed", result.Error)
+	}
+	return nil
+}
+
+// GetPreference возвращает настройки писем пользователя или nil, если они не заданы.
+func (r *EmailRepo) GetPreference(ctx context.Context, userID uint) (*models.EmailPreference, error) {
+	var pref models.EmailPreference
+	result := dbFromContext(ctx, r.db).Where("user_id = ?", userID).First(&pref)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, models.NewDatabaseError("getting email preference", result.Error)
+	}
+	return &pref, nil
+}
+
+func (r *EmailRepo) SavePreference(ctx context.Context, pref *models.EmailPreference) error {
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"immediate", "digest", "updated_at"}),
+		}).
+		Create(pref)
+	if result.Error != nil {
+		return models.NewDatabaseError("saving email preference", result.Error)
+	}
+	return nil
+}
+
+// GetDigestRecipients возвращает пользователей с заданной периодичностью дайджеста,
+// которым дайджест не отправлялся с момента before.
+func (r *EmailRepo) GetDigestRecipients(ctx context.Context, digest string, before time.Time) ([]models.EmailPreference, error) {
+	var prefs []models.EmailPreference
+	result := dbFromContext(ctx, r.db).
+		Where("digest = ?", digest).
+		Where("last_digest_at IS NULL OR last_digest_at < ?", before).
+		Order("user_id ASC").
+		Find(&prefs)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting digest recipients", result.Error)
+	}
+	return prefs, nil
+}
+
+func (r *EmailRepo) SetLastDigest(ctx context.Context, userID uint, at time.Time) error {
+	result := dbFromContext(ctx, r.db).Model(&models.EmailPreference{}).
+		Where("user_id = ?", userID).
+		Update("last_digest_at", at)
+	if result.Error != nil {
+		return models.NewDatabaseError("updating last digest time", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeDB — драйвер database/sql для тестов репозиториев без PostgreSQL. Он запоминает
+// выполненные запросы и отвечает на них заготовленными результатами; запросы без
+// заготовки возвращают пустой результат.
+type fakeDB struct {
+	mu        sync.Mutex
+	queries   []string
+	responses []*fakeResponse
+}
+
+type fakeResponse struct {
+	match    string
+	columns  []string
+	rows     [][]driver.Value
+	affected int64
+	err      error
+	once     bool
+	used     bool
+}
+
+// newFakeDB возвращает gorm.DB с диалектом PostgreSQL поверх fakeDB.
+func newFakeDB(t *testing.T) (*fakeDB, *gorm.DB) {
+	t.Helper()
+	fake := &fakeDB{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(fake)}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake, db
+}
+
+// onQuery задает строки, которые вернет первый запрос, содержащий match.
+func (f *fakeDB) onQuery(match string, columns []string, rows ...[]driver.Value) *fakeResponse {
+	return f.add(&fakeResponse{match: match, columns: columns, rows: rows})
+}
+
+// onExec задает число строк, измененных командой, содержащей match.
+func (f *fakeDB) onExec(match string, affected int64) *fakeResponse {
+	return f.add(&fakeResponse{match: match, affected: affected})
+}
+
+// onError задает ошибку для запросов, содержащих match.
+func (f *fakeDB) onError(match string, err error) *fakeResponse {
+	return f.add(&fakeResponse{match: match, err: err})
+}
+
+// Once ограничивает заготовку одним запросом: следующие получат другую заготовку или пустой результат.
+func (r *fakeResponse) Once() *fakeResponse {
+	r.once = true
+	return r
+}
+
+func (f *fakeDB) add(response *fakeResponse) *fakeResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses = append(f.responses, response)
+	return response
+}
+
+func (f *fakeDB) respond(query string) *fakeResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries = append(f.queries, query)
+	for _, response := range f.responses {
+		if response.used || !strings.Contains(query, response.match) {
+			continue
+		}
+		if response.once {
+			response.used = true
+		}
+		return response
+	}
+	return &fakeResponse{}
+}
+
+// find возвращает выполненные запросы, содержащие substr.
+func (f *fakeDB) find(substr string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var found []string
+	for _, query := range f.queries {
+		if strings.Contains(query, substr) {
+			found = append(found, query)
+		}
+	}
+	return found
+}
+
+// indexOf возвращает номер первого запроса, содержащего substr, или -1.
+func (f *fakeDB) indexOf(substr string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, query := range f.queries {
+		if strings.Contains(query, substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *fakeDB) Connect(ctx context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *fakeDB) Driver() driver.Driver                            { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fakedb: use sql.OpenDB")
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fakedb: prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.db.respond("BEGIN")
+	return fakeTx{db: c.db}, nil
+}
+
+// CheckNamedValue принимает аргументы как есть: драйвер их не разбирает.
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	response := c.db.respond(query)
+	if response.err != nil {
+		return nil, response.err
+	}
+	return &fakeRows{columns: response.columns, rows: response.rows}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	response := c.db.respond(query)
+	if response.err != nil {
+		return nil, response.err
+	}
+	return driver.RowsAffected(response.affected), nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (tx fakeTx) Commit() error {
+	tx.db.respond("COMMIT")
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.db.respond("ROLLBACK")
+	return nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
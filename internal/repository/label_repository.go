@@ -17,16 +17,19 @@ func NewLabelRepo(db *gorm.DB) *LabelRepo {
 }
 
 func (r *LabelRepo) Create(ctx context.Context, label *models.Label) error {
-	result := r.db.WithContext(ctx).Create(label)
+	result := dbFromContext(ctx, r.db).Create(label)
 	if result.Error != nil {
 		return models.NewDatabaseError("creating label", result.Error)
 	}
 	return nil
 }
 
+// GetByID возвращает метку, если ее доска не удалена.
 func (r *LabelRepo) GetByID(ctx context.Context, id uint) (*models.Label, error) {
 	var label models.Label
-	result := r.db.WithContext(ctx).First(&label, id)
+	result := dbFromContext(ctx, r.db).
+		Joins("JOIN boards ON boards.id = labels.board_id AND boards.deleted_at IS NULL").
+		First(&label, "labels.id = ?", id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, models.ErrLabelNotFound
@@ -36,10 +39,14 @@ func (r *LabelRepo) GetByID(ctx context.Context, id uint) (*models.Label, error)
 	return &label, nil
 }
 
+// GetByBoardID возвращает метки доски и общие метки ее рабочего пространства.
 func (r *LabelRepo) GetByBoardID(ctx context.Context, boardID uint) ([]models.Label, error) {
 	var labels []models.Label
-	result := r.db.WithContext(ctx).
-		Where("board_id = ?", boardID).
+	result := dbFromContext(ctx, r.db).
+		Where("board_id = ? OR workspace_id = (?)", boardID,
+			r.db.Model(&models.Board{}).Select("workspace_id").Where("id = ?", boardID),
+		).
+		Order("id ASC").
 		Find(&labels)
 	if result.Error != nil {
 		return nil, models.NewDatabaseError("getting labels by board ID", result.Error)
@@ -47,24 +54,95 @@ func (r *LabelRepo) GetByBoardID(ctx context.Context, boardID uint) ([]models.La
 	return labels, nil
 }
 
-func (r *LabelRepo) Update(ctx context.Context, label *models.Label) error {
-	result := r.db.WithContext(ctx).Save(label)
+// GetByWorkspaceID возвращает общие метки рабочего пространства.
+func (r *LabelRepo) GetByWorkspaceID(ctx context.Context, workspaceID uint) ([]models.Label, error) {
+	var labels []models.Label
+	result := dbFromContext(ctx, r.db).
+		Where("workspace_id = ?", workspaceID).
+		Order("id ASC").
+		Find(&labels)
 	if result.Error != nil {
-		return models.NewDatabaseError("updating label", result.Error)
+		return nil, models.NewDatabaseError("getting labels by workspace ID", result.Error)
 	}
-	if result.RowsAffected == 0 {
-		return models.ErrLabelNotFound
-	}
-	return nil
+	return labels, nil
 }
 
-func (r *LabelRepo) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&models.Label{}, id)
+// Update сохраняет метку, если ее версия не изменилась с момента чтения, и увеличивает версию.
+func (r *LabelRepo) Update(ctx context.Context, label *models.Label) error {
+	return updateVersioned(dbFromContext(ctx, r.db), label, label.ID, &label.Version, models.ErrLabelNotFound, "updating label")
+}
+
+func (r *LabelRepo) Delete(ctx context.Context, id uint, version int) error {
+	db := dbFromContext(ctx, r.db)
+	result := db.Where("version = ?", version).Delete(&models.Label{}, id)
 	if result.Error != nil {
 		return models.NewDatabaseError("deleting label", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return models.ErrLabelNotFound
+		return versionConflict(db, &models.Label{ID: id}, id, models.ErrLabelNotFound)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// Share делает метку общей для рабочего пространства workspaceID или, если workspaceID nil,
+// снова меткой только своей доски. Во втором случае метка снимается с карточек других досок.
+func (r *LabelRepo) Share(ctx context.Context, id uint, workspaceID *uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Label{}).
+			Where("id = ?", id).
+			UpdateColumns(map[string]interface{}{
+				"workspace_id": workspaceID,
+				"version":      gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return models.NewDatabaseError("sharing label", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrLabelNotFound
+		}
+		if workspaceID != nil {
+			return nil
+		}
+
+		err := tx.Where("label_id = ? AND card_id NOT IN (?)", id, boardCardIDs(tx,
+			tx.Model(&models.Label{}).Select("board_id").Where("id = ?", id),
+		)).Delete(&models.CardLabel{}).Error
+		if err != nil {
+			return models.NewDatabaseError("detaching shared label", err)
+		}
+		return nil
+	})
+}
+
+// DetachFromWorkspace готовит доску к переносу в другое рабочее пространство: ее общие метки
+// становятся метками только этой доски, а с ее карточек снимаются общие метки других досок.
+func (r *LabelRepo) DetachFromWorkspace(ctx context.Context, boardID uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		own := tx.Model(&models.Label{}).Select("id").Where("board_id = ?", boardID)
+		cards := boardCardIDs(tx, boardID)
+
+		err := tx.Where("label_id IN (?) AND card_id NOT IN (?)", own, cards).Delete(&models.CardLabel{}).Error
+		if err != nil {
+			return models.NewDatabaseError("detaching board labels", err)
+		}
+		err = tx.Where("card_id IN (?) AND label_id NOT IN (?)", cards, own).Delete(&models.CardLabel{}).Error
+		if err != nil {
+			return models.NewDatabaseError("detaching workspace labels", err)
+		}
+		err = tx.Model(&models.Label{}).Where("board_id = ? AND workspace_id IS NOT NULL", boardID).
+			UpdateColumns(map[string]interface{}{
+				"workspace_id": nil,
+				"version":      gorm.Expr("version + 1"),
+			}).Error
+		if err != nil {
+			return models.NewDatabaseError("unsharing board labels", err)
+		}
+		return nil
+	})
+}
+
+// boardCardIDs — подзапрос ID карточек доски (или досок, если board — подзапрос), включая удаленные.
+func boardCardIDs(tx *gorm.DB, board interface{}) *gorm.DB {
+	return tx.Unscoped().Model(&models.Card{}).Select("id").
+		Where("column_id IN (?)", tx.Unscoped().Model(&models.Column{}).Select("id").Where("board_id IN (?)", board))
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+type MentionRepo struct {
+	db *gorm.DB
+}
+
+func NewMentionRepo(db *gorm.DB) *MentionRepo {
+	return &MentionRepo{db: db}
+}
+
+// ReplaceForSource заменяет все упоминания источника (комментария или описания карточки) новым набором.
+func (r *MentionRepo) ReplaceForSource(ctx context.Context, sourceType string, sourceID uint, mentions []models.Mention) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("source_type = ? AND source_id = ?", sourceType, sourceID).
+			Delete(&models.Mention{}).Error; err != nil {
+			return models.NewDatabaseError("deleting old mentions", err)
+		}
+
+		if len(mentions) == 0 {
+			return nil
+		}
+
+		if err := tx.Create(&mentions).Error; err != nil {
+			return models.NewDatabaseError("creating mentions", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *MentionRepo) GetBySource(ctx context.Context, sourceType string, sourceID uint) ([]models.Mention, error) {
+	return r.GetBySources(ctx, sourceType, []uint{sourceID})
+}
+
+func (r *MentionRepo) GetBySources(ctx context.Context, sourceType string, sourceIDs []uint) ([]models.Mention, error) {
+	var mentions []models.Mention
+	if len(sourceIDs) == 0 {
+		return mentions, nil
+	}
+
+	result := dbFromContext(ctx, r.db).
+		Where("source_type = ? AND source_id IN ?", sourceType, sourceIDs).
+		Order("span_start ASC").
+		Find(&mentions)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting mentions by source", result.Error)
+	}
+	return mentions, nil
+}
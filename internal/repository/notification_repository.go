@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+type NotificationRepo struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepo(db *gorm.DB) *NotificationRepo {
+	return &NotificationRepo{db: db}
+}
+
+func (r *NotificationRepo) Create(ctx context.Context, notification *models.Notification) error {
+	result := dbFromContext(ctx, r.db).Create(notification)
+	if result.Error != nil {
+		return models.NewDatabaseError("creating notification", result.Error)
+	}
+	return nil
+}
+
+func (r *NotificationRepo) GetByUserID(ctx context.Context, userID uint, filter NotificationFilter) ([]models.Notification, error) {
+	var notifications []models.Notification
+	query := dbFromContext(ctx, r.db).Where("user_id = ?", userID)
+	if filter.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	result := query.Order("created_at DESC, id DESC").Find(&notifications)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting notifications", result.Error)
+	}
+	return notifications, nil
+}
+
+func (r *NotificationRepo) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := dbFromContext(ctx, r.db).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, models.NewDatabaseError("counting unread notifications", err)
+	}
+	return count, nil
+}
+
+func (r *NotificationRepo) SetRead(ctx context.Context, id, userID uint, read bool) error {
+	var readAt interface{}
+	if read {
+		readAt = time.Now()
+	}
+
+	result := dbFromContext(ctx, r.db).Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		return models.NewDatabaseError("updating notification read state", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (r *NotificationRepo) MarkAllRead(ctx context.Context, userID uint) (int64, error) {
+	result := dbFromContext(ctx, r.db).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return 0, models.NewDatabaseError("marking all notifications read", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *NotificationRepo) GetPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error) {
+	var prefs []models.NotificationPreference
+	result := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("id ASC").
+		Find(&prefs)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting notification preferences", result.Error)
+	}
+	return prefs, nil
+}
+
+func (r *NotificationRepo) ReplacePreferences(ctx context.Context, userID uint, prefs []models.NotificationPreference) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.NotificationPreference{}).Error; err != nil {
+			return models.NewDatabaseError("deleting notification preferences", err)
+		}
+
+		if len(prefs) == 0 {
+			return nil
+		}
+
+		for i := range prefs {
+			prefs[i].UserID = userID
+		}
+		if err := tx.Create(&prefs).Error; err != nil {
+			return models.NewDatabaseError("creating notification preferences", err)
+		}
+
+		return nil
+	})
+}
+
+// IsMuted проверяет, отключил ли пользователь уведомления данного типа или по данной доске.
+func (r *NotificationRepo) IsMuted(ctx context.Context, userID uint, notificationType string, boardID uint) (bool, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).Model(&models.NotificationPreference{}).
+		Where("user_id = ?", userID).
+		Where("(type = ? OR type = '' OR type IS NULL)", notificationType).
+		Where("(board_id = ? OR board_id IS NULL)", boardID).
+		Where("NOT ((type = '' OR type IS NULL) AND board_id IS NULL)").
+		Count(&count).Error
+	if err != nil {
+		return false, models.NewDatabaseError("checking notification preferences", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+// outboxRelayLockKey — ключ advisory lock, который удерживает активный ретранслятор outbox.
+const outboxRelayLockKey = 0x6f7574626f78
+
+type OutboxRepo struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepo(db *gorm.DB) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+// Append сохраняет событие. Вызывается в транзакции изменения, чтобы событие
+// было записано тогда и только тогда, когда изменение зафиксировано.
+func (r *OutboxRepo) Append(ctx context.Context, event *models.OutboxEvent) error {
+	result := dbFromContext(ctx, r.db).Create(event)
+	if result.Error != nil {
+		return models.NewDatabaseError("appending outbox event", result.Error)
+	}
+	return nil
+}
+
+// WithRelayLock выполняет fn, только если ни один другой экземпляр сервера не обрабатывает outbox.
+// Возвращает false, если блокировка занята. fn получает контекст без транзакции блокировки:
+// его записи фиксируются сразу, и подписчики видят событие в журнале до конца пачки.
+func (r *OutboxRepo) WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	acquired := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxRelayLockKey).Scan(&acquired).Error; err != nil {
+			return models.NewDatabaseError("acquiring outbox relay lock", err)
+		}
+		if !acquired {
+			return nil
+		}
+		return fn(ctx)
+	})
+	return acquired, err
+}
+
+func (r *OutboxRepo) GetPending(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	result := dbFromContext(ctx, r.db).
+		Where("published_at IS NULL").
+		Order("id ASC").
+		Limit(limit).
+		Find(&events)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting pending outbox events", result.Error)
+	}
+	return events, nil
+}
+
+func (r *OutboxRepo) MarkPublished(ctx context.Context, ids []uint64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	result := dbFromContext(ctx, r.db).Model(&models.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"published_at": at,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   "",
+		})
+	if result.Error != nil {
+		return models.NewDatabaseError("marking outbox events published", result.Error)
+	}
+	return nil
+}
+
+func (r *OutboxRepo) MarkFailed(ctx context.Context, id uint64, lastError string) error {
+	result := dbFromContext(ctx, r.db).Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": lastError,
+		})
+	if result.Error != nil {
+		return models.NewDatabaseError("marking outbox event failed", result.Error)
+	}
+	return nil
+}
+
+// DeletePublishedBefore удаляет опубликованные события старше before.
+func (r *OutboxRepo) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Where("published_at IS NOT NULL AND published_at < ?", before).
+		Delete(&models.OutboxEvent{})
+	if result.Error != nil {
+		return 0, models.NewDatabaseError("deleting published outbox events", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+// PubSubRepo рассылает сообщения между экземплярами сервера через LISTEN/NOTIFY PostgreSQL.
+type PubSubRepo struct {
+	db *gorm.DB
+}
+
+func NewPubSubRepo(db *gorm.DB) *PubSubRepo {
+	return &PubSubRepo{db: db}
+}
+
+// Notify отправляет сообщение в канал. В транзакции сообщение уходит при ее фиксации.
+func (r *PubSubRepo) Notify(ctx context.Context, channel, payload string) error {
+	result := dbFromContext(ctx, r.db).Exec("SELECT pg_notify(?, ?)", channel, payload)
+	if result.Error != nil {
+		return models.NewDatabaseError("sending notification", result.Error)
+	}
+	return nil
+}
+
+// Listen подписывается на каналы и вызывает handle для каждого сообщения, пока ctx не отменен
+// или не разорвано соединение. Для подписки из пула занимается отдельное соединение.
+func (r *PubSubRepo) Listen(ctx context.Context, channels []string, handle func(channel, payload string)) error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return models.NewDatabaseError("acquiring listen connection", err)
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("LISTEN requires the pgx driver, got %T", driverConn)
+		}
+		pgConn := stdConn.Conn()
+
+		for _, channel := range channels {
+			if _, err := pgConn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+				return models.NewDatabaseError("subscribing to channel", err)
+			}
+		}
+
+		for {
+			notification, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+					return ctx.Err()
+				}
+				return models.NewDatabaseError("waiting for notification", err)
+			}
+			handle(notification.Channel, notification.Payload)
+		}
+	})
+}
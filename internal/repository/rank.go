@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/pkg/lexorank"
+	"gorm.io/gorm"
+)
+
+// rankBatchSize ограничивает число строк в одном UPDATE при раздаче рангов, чтобы
+// не превысить лимит PostgreSQL на число параметров запроса.
+const rankBatchSize = 10000
+
+// rankedList описывает упорядоченный список: карточки колонки, колонки или дорожки доски.
+// Порядок задается строковым рангом (см. pkg/lexorank), при равных рангах — по id.
+// Позиция записи не хранится и вычисляется при чтении.
+//
+// Если задан cellKey, список делится на ячейки (карточки колонки — по дорожкам): ранги
+// у ячеек общие, а позиции отсчитываются внутри ячейки.
+type rankedList struct {
+	table       string
+	parentKey   string
+	parentTable string
+	cellKey     string
+}
+
+var (
+	cardList     = rankedList{table: "cards", parentKey: "column_id", parentTable: "columns", cellKey: "swimlane_id"}
+	columnList   = rankedList{table: "columns", parentKey: "board_id", parentTable: "boards"}
+	swimlaneList = rankedList{table: "swimlanes", parentKey: "board_id", parentTable: "boards"}
+)
+
+// lockParent блокирует строку родителя списка. Вставки и перемещения берут разделяемую
+// блокировку и друг другу не мешают, перераздача рангов — исключительную, чтобы ранги
+// не менялись между чтением соседей и записью нового ранга. Разделяемую блокировку нельзя
+// потом повышать до исключительной: две транзакции, сделавшие это одновременно, ждали бы
+// друг друга (см. rankAt).
+func (l rankedList) lockParent(tx *gorm.DB, parentID uint, exclusive bool) error {
+	mode := "FOR KEY SHARE"
+	if exclusive {
+		mode = "FOR UPDATE"
+	}
+
+	var ids []uint
+	return tx.Raw(fmt.Sprintf("SELECT id FROM %s WHERE id = ? %s", l.parentTable, mode), parentID).Scan(&ids).Error
+}
+
+// positionSelect — выражение для SELECT, вычисляющее позицию отдельной записи в ее ячейке.
+func (l rankedList) positionSelect() string {
+	sameCell := ""
+	if l.cellKey != "" {
+		sameCell = fmt.Sprintf(" AND sibling.%[2]s IS NOT DISTINCT FROM %[1]s.%[2]s", l.table, l.cellKey)
+	}
+	return fmt.Sprintf("%[1]s.*, (SELECT COUNT(*) FROM %[1]s AS sibling WHERE sibling.%[2]s = %[1]s.%[2]s%[3]s"+
+		" AND sibling.deleted_at IS NULL AND (sibling.rank, sibling.id) < (%[1]s.rank, %[1]s.id)) AS position",
+		l.table, l.parentKey, sameCell)
+}
+
+// positionsSelect — выражение для SELECT, нумерующее записи по порядку в каждой ячейке.
+func (l rankedList) positionsSelect() string {
+	partition := l.table + "." + l.parentKey
+	if l.cellKey != "" {
+		partition += ", " + l.table + "." + l.cellKey
+	}
+	return fmt.Sprintf("%[1]s.*, ROW_NUMBER() OVER (PARTITION BY %[2]s ORDER BY %[1]s.rank, %[1]s.id) - 1 AS position",
+		l.table, partition)
+}
+
+func (l rankedList) order() string {
+	return fmt.Sprintf("%[1]s.rank, %[1]s.id", l.table)
+}
+
+// rankGap — промежуток между соседями, куда встает запись.
+type rankGap struct {
+	lower, upper       string
+	hasLower, hasUpper bool
+	position           int
+}
+
+// rank возвращает ранг внутри промежутка. ok равно false, если ранги соседей совпадают
+// или не заданы и промежутка между ними нет.
+func (g rankGap) rank() (string, bool) {
+	if (g.hasLower && g.lower == "") || (g.hasUpper && g.upper == "") {
+		return "", false
+	}
+	rank, err := lexorank.Between(g.lower, g.upper)
+	return rank, err == nil
+}
+
+// errNoRankGap сообщает, что между соседями нет промежутка и ранги нужно перераздать.
+var errNoRankGap = errors.New("no rank gap")
+
+// rankAt блокирует родителя списка и возвращает ранг и итоговую позицию для записи, которая
+// должна встать на позицию position в ячейке cell. Запись exclude (перемещаемая) при отсчете
+// позиций не учитывается; позиция за концом ячейки означает вставку в конец.
+//
+// Соседи читаются под разделяемой блокировкой в точке сохранения. Если промежутка между
+// ними нет, точка сохранения откатывается вместе с блокировкой, и ранги перераздаются уже
+// под исключительной: так блокировка не повышается, и параллельные вставки не попадают
+// во взаимную блокировку.
+func (l rankedList) rankAt(tx *gorm.DB, parentID uint, cell *uint, exclude uint, position int) (string, int, error) {
+	if position < 0 {
+		position = 0
+	}
+
+	var gap rankGap
+	err := tx.Transaction(func(sp *gorm.DB) error {
+		if err := l.lockParent(sp, parentID, false); err != nil {
+			return models.NewDatabaseError(fmt.Sprintf("locking %s", l.parentTable), err)
+		}
+		var err error
+		if gap, err = l.gapAt(sp, parentID, cell, exclude, position); err != nil {
+			return err
+		}
+		if _, ok := gap.rank(); !ok {
+			return errNoRankGap
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, errNoRankGap):
+		if err := l.rebalance(tx, parentID); err != nil {
+			return "", 0, err
+		}
+		if gap, err = l.gapAt(tx, parentID, cell, exclude, position); err != nil {
+			return "", 0, err
+		}
+	case err != nil:
+		return "", 0, err
+	}
+
+	rank, ok := gap.rank()
+	if !ok {
+		return "", 0, models.NewDatabaseError(fmt.Sprintf("ranking %s", l.table),
+			fmt.Errorf("no rank between %q and %q", gap.lower, gap.upper))
+	}
+	return rank, gap.position, nil
+}
+
+func (l rankedList) gapAt(tx *gorm.DB, parentID uint, cell *uint, exclude uint, position int) (rankGap, error) {
+	siblings := func() *gorm.DB {
+		query := tx.Table(l.table).
+			Where(fmt.Sprintf("%s = ? AND id <> ? AND deleted_at IS NULL", l.parentKey), parentID, exclude)
+		switch {
+		case l.cellKey == "":
+		case cell == nil:
+			query = query.Where(l.cellKey + " IS NULL")
+		default:
+			query = query.Where(l.cellKey+" = ?", *cell)
+		}
+		return query
+	}
+	readErr := func(err error) (rankGap, error) {
+		return rankGap{}, models.NewDatabaseError(fmt.Sprintf("reading %s ranks", l.table), err)
+	}
+
+	var ranks []string
+	if position == 0 {
+		if err := siblings().Order("rank, id").Limit(1).Pluck("rank", &ranks).Error; err != nil {
+			return readErr(err)
+		}
+		if len(ranks) == 0 {
+			return rankGap{}, nil
+		}
+		return rankGap{upper: ranks[0], hasUpper: true}, nil
+	}
+
+	if err := siblings().Order("rank, id").Offset(position-1).Limit(2).Pluck("rank", &ranks).Error; err != nil {
+		return readErr(err)
+	}
+	switch len(ranks) {
+	case 2:
+		return rankGap{lower: ranks[0], upper: ranks[1], hasLower: true, hasUpper: true, position: position}, nil
+	case 1:
+		return rankGap{lower: ranks[0], hasLower: true, position: position}, nil
+	}
+
+	// Позиция за концом списка: встаем после последней записи.
+	var count int64
+	if err := siblings().Count(&count).Error; err != nil {
+		return readErr(err)
+	}
+	if count == 0 {
+		return rankGap{}, nil
+	}
+	if err := siblings().Order("rank DESC, id DESC").Limit(1).Pluck("rank", &ranks).Error; err != nil {
+		return readErr(err)
+	}
+	return rankGap{lower: ranks[0], hasLower: true, position: int(count)}, nil
+}
+
+// setRank переносит запись в ячейку cell списка parentID с новым рангом и увеличивает ее версию.
+func (l rankedList) setRank(tx *gorm.DB, id, parentID uint, cell *uint, rank string) error {
+	values := map[string]interface{}{
+		l.parentKey:  parentID,
+		"rank":       rank,
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": gorm.Expr("NOW()"),
+	}
+	if l.cellKey != "" {
+		values[l.cellKey] = cell
+	}
+
+	result := tx.Table(l.table).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(values)
+	if result.Error != nil {
+		return models.NewDatabaseError(fmt.Sprintf("updating %s rank", l.table), result.Error)
+	}
+	return nil
+}
+
+// rebalance заново раздает ранги записям списка с равными промежутками, сохраняя порядок.
+// Позиции записей не меняются, поэтому версии не увеличиваются.
+func (l rankedList) rebalance(tx *gorm.DB, parentID uint) error {
+	if err := l.lockParent(tx, parentID, true); err != nil {
+		return models.NewDatabaseError(fmt.Sprintf("locking %s", l.parentTable), err)
+	}
+
+	var ids []uint
+	if err := tx.Table(l.table).
+		Where(fmt.Sprintf("%s = ? AND deleted_at IS NULL", l.parentKey), parentID).
+		Order("rank, id").
+		Pluck("id", &ids).Error; err != nil {
+		return models.NewDatabaseError(fmt.Sprintf("reading %s order", l.table), err)
+	}
+
+	return l.assign(tx, parentID, ids, false)
+}
+
+// reorder расставляет записи списка в порядке ids. Родитель блокируется до конца транзакции,
+// и если ids не совпадает с текущим составом списка, возвращается *models.OrderConflictError.
+// Возвращает false, если порядок уже такой.
+func (l rankedList) reorder(tx *gorm.DB, parentID uint, ids []uint) (bool, error) {
+	if err := l.lockParent(tx, parentID, true); err != nil {
+		return false, models.NewDatabaseError(fmt.Sprintf("locking %s", l.parentTable), err)
+	}
+
+	var current []uint
+	if err := tx.Table(l.table).
+		Where(fmt.Sprintf("%s = ? AND deleted_at IS NULL", l.parentKey), parentID).
+		Order("rank, id").
+		Pluck("id", &current).Error; err != nil {
+		return false, models.NewDatabaseError(fmt.Sprintf("reading %s order", l.table), err)
+	}
+
+	if err := checkOrder(current, ids); err != nil {
+		return false, err
+	}
+	if slices.Equal(current, ids) {
+		return false, nil
+	}
+
+	return true, l.assign(tx, parentID, ids, true)
+}
+
+// checkOrder проверяет, что submitted — перестановка current. Повторы считаются лишними записями.
+func checkOrder(current, submitted []uint) error {
+	members := make(map[uint]bool, len(current))
+	for _, id := range current {
+		members[id] = true
+	}
+
+	conflict := &models.OrderConflictError{Current: current}
+	seen := make(map[uint]bool, len(submitted))
+	for _, id := range submitted {
+		if !members[id] || seen[id] {
+			conflict.Unexpected = append(conflict.Unexpected, id)
+		}
+		seen[id] = true
+	}
+	for _, id := range current {
+		if !seen[id] {
+			conflict.Missing = append(conflict.Missing, id)
+		}
+	}
+
+	if len(conflict.Missing) > 0 || len(conflict.Unexpected) > 0 {
+		return conflict
+	}
+	return nil
+}
+
+// assign раздает записям ids ранги с равными промежутками в порядке следования ids.
+// Записи, успевшие перейти в другой список, не затрагиваются.
+func (l rankedList) assign(tx *gorm.DB, parentID uint, ids []uint, bumpVersion bool) error {
+	set := "rank = ranked.rank"
+	if bumpVersion {
+		set += ", version = version + 1, updated_at = NOW()"
+	}
+
+	ranks := lexorank.Spread(len(ids))
+	for start := 0; start < len(ids); start += rankBatchSize {
+		end := min(start+rankBatchSize, len(ids))
+
+		values := make([]string, 0, end-start)
+		args := make([]interface{}, 0, 2*(end-start)+1)
+		for i := start; i < end; i++ {
+			values = append(values, "(?::bigint, ?)")
+			args = append(args, ids[i], ranks[i])
+		}
+		args = append(args, parentID)
+
+		query := fmt.Sprintf("UPDATE %[1]s SET %[2]s FROM (VALUES %[3]s) AS ranked(id, rank) WHERE %[1]s.id = ranked.id AND %[1]s.%[4]s = ?",
+			l.table, set, strings.Join(values, ", "), l.parentKey)
+		if err := tx.Exec(query, args...).Error; err != nil {
+			return models.NewDatabaseError(fmt.Sprintf("assigning %s ranks", l.table), err)
+		}
+	}
+	return nil
+}
+
+// spreadRanks раздает ранги записям новых списков в порядке следования: записи с одинаковым
+// parentOf попадают в один список. Используется при массовой вставке, когда списки
+// создаются целиком и соседей в базе еще нет.
+func spreadRanks(n int, parentOf func(i int) uint, setRank func(i int, rank string)) {
+	counts := make(map[uint]int)
+	for i := 0; i < n; i++ {
+		counts[parentOf(i)]++
+	}
+
+	ranks := make(map[uint][]string, len(counts))
+	for parentID, count := range counts {
+		ranks[parentID] = lexorank.Spread(count)
+	}
+	for i := 0; i < n; i++ {
+		parentID := parentOf(i)
+		setRank(i, ranks[parentID][0])
+		ranks[parentID] = ranks[parentID][1:]
+	}
+}
+
+// unbalanced возвращает до limit родителей, в списках которых ранги длиннее maxLength,
+// совпадают или не заданы.
+func (l rankedList) unbalanced(db *gorm.DB, maxLength, limit int) ([]uint, error) {
+	var ids []uint
+	err := db.Table(l.table).
+		Where("deleted_at IS NULL").
+		Group(l.parentKey).
+		Having("MAX(LENGTH(rank)) > ? OR MIN(LENGTH(rank)) = 0 OR COUNT(*) <> COUNT(DISTINCT rank)", maxLength).
+		Limit(limit).
+		Pluck(l.parentKey, &ids).Error
+	if err != nil {
+		return nil, models.NewDatabaseError(fmt.Sprintf("finding unbalanced %s", l.table), err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+)
+
+func TestCheckOrder(t *testing.T) {
+	current := []uint{1, 2, 3}
+
+	tests := []struct {
+		name           string
+		submitted      []uint
+		wantMissing    []uint
+		wantUnexpected []uint
+	}{
+		{name: "same order", submitted: []uint{1, 2, 3}},
+		{name: "permutation", submitted: []uint{3, 1, 2}},
+		{name: "missing card", submitted: []uint{3, 1}, wantMissing: []uint{2}},
+		{name: "foreign card", submitted: []uint{3, 1, 2, 9}, wantUnexpected: []uint{9}},
+		{name: "repeated card", submitted: []uint{3, 1, 3}, wantMissing: []uint{2}, wantUnexpected: []uint{3}},
+		{name: "empty list", submitted: nil, wantMissing: []uint{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkOrder(current, tt.submitted)
+			if tt.wantMissing == nil && tt.wantUnexpected == nil {
+				if err != nil {
+					t.Fatalf("checkOrder error: %v", err)
+				}
+				return
+			}
+
+			var conflict *models.OrderConflictError
+			if !errors.As(err, &conflict) {
+				t.Fatalf("checkOrder error = %v, want *OrderConflictError", err)
+			}
+			if !slices.Equal(conflict.Missing, tt.wantMissing) || !slices.Equal(conflict.Unexpected, tt.wantUnexpected) {
+				t.Errorf("missing = %v, unexpected = %v, want %v and %v", conflict.Missing, conflict.Unexpected, tt.wantMissing, tt.wantUnexpected)
+			}
+			if !slices.Equal(conflict.Current, current) {
+				t.Errorf("current = %v, want %v", conflict.Current, current)
+			}
+		})
+	}
+}
+
+func TestCardRepoReorder(t *testing.T) {
+	tests := []struct {
+		name        string
+		ids         []uint
+		wantChanged bool
+		wantErr     bool
+	}{
+		{name: "new order", ids: []uint{3, 1, 2}, wantChanged: true},
+		{name: "same order", ids: []uint{1, 2, 3}},
+		{name: "stale list", ids: []uint{3, 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, db := newFakeDB(t)
+			fake.onQuery(`SELECT "id" FROM "cards"`, []string{"id"},
+				[]driver.Value{int64(1)}, []driver.Value{int64(2)}, []driver.Value{int64(3)})
+
+			changed, err := NewCardRepo(db).Reorder(context.Background(), 7, tt.ids)
+			if tt.wantErr {
+				var conflict *models.OrderConflictError
+				if !errors.As(err, &conflict) {
+					t.Fatalf("Reorder error = %v, want *OrderConflictError", err)
+				}
+				if len(fake.find("ROLLBACK")) != 1 {
+					t.Errorf("transaction was not rolled back; queries: %q", fake.queries)
+				}
+			} else if err != nil {
+				t.Fatalf("Reorder: %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+
+			// Колонка блокируется до чтения порядка, чтобы параллельная перестановка его не изменила.
+			lock, read := fake.indexOf("FROM columns WHERE id = $1 FOR UPDATE"), fake.indexOf(`SELECT "id" FROM "cards"`)
+			if lock < 0 || lock > read {
+				t.Errorf("column lock at %d, order read at %d; queries: %q", lock, read, fake.queries)
+			}
+			if updated := len(fake.find("UPDATE cards SET rank")) > 0; updated != tt.wantChanged {
+				t.Errorf("ranks updated = %v, want %v", updated, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestCardRepoMoveToColumnLocking(t *testing.T) {
+	tests := []struct {
+		name          string
+		neighbours    []string
+		wantRebalance bool
+	}{
+		{name: "gap between neighbours", neighbours: []string{"a", "c"}},
+		{name: "equal neighbour ranks", neighbours: []string{"b", "b"}, wantRebalance: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, db := newFakeDB(t)
+			fake.onQuery(`SELECT "id" FROM "cards"`, []string{"id"}, []driver.Value{int64(3)}).Once()
+			fake.onQuery(`SELECT "rank" FROM "cards"`, []string{"rank"},
+				[]driver.Value{tt.neighbours[0]}, []driver.Value{tt.neighbours[1]}).Once()
+
+			if _, err := NewCardRepo(db).MoveToColumn(context.Background(), 3, 7, nil, 1); err != nil {
+				t.Fatalf("MoveToColumn: %v", err)
+			}
+
+			share, exclusive := fake.indexOf("FOR KEY SHARE"), fake.indexOf("FOR UPDATE")
+			if share < 0 || share > fake.indexOf(`SELECT "rank" FROM "cards"`) {
+				t.Fatalf("neighbours are read before the column is locked; queries: %q", fake.queries)
+			}
+			if !tt.wantRebalance {
+				if exclusive >= 0 {
+					t.Errorf("column is locked exclusively without a rebalance; queries: %q", fake.queries)
+				}
+				return
+			}
+
+			// Разделяемая блокировка снимается откатом точки сохранения до исключительной,
+			// а не повышается: иначе параллельные вставки ждали бы друг друга.
+			release := fake.indexOf("ROLLBACK TO SAVEPOINT")
+			if release < share || exclusive < release {
+				t.Errorf("shared lock at %d, released at %d, exclusive lock at %d; queries: %q",
+					share, release, exclusive, fake.queries)
+			}
+			if rebalance := fake.indexOf(`SELECT "id" FROM "cards" WHERE column_id`); rebalance < exclusive {
+				t.Errorf("card order is read for rebalancing at %d, before the exclusive lock at %d; queries: %q",
+					rebalance, exclusive, fake.queries)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/octaview/kanban-octaview/internal/models"
 	"gorm.io/gorm"
@@ -10,79 +11,363 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	GetByID(ctx context.Context, id uint) (*models.User, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
 	Delete(ctx context.Context, id uint) error
 }
 
+// BoardRevision — сводка изменений доски и всего, что показывается в ее снимке.
+// Сводка меняется при любом таком изменении, поэтому по ней можно проверить актуальность
+// снимка, не загружая его.
+type BoardRevision struct {
+	BoardVersion       int
+	ColumnCount        int64
+	ColumnsUpdatedAt   *time.Time
+	SwimlaneCount      int64
+	SwimlanesUpdatedAt *time.Time
+	CardCount          int64
+	CardsUpdatedAt     *time.Time
+	LabelCount         int64
+	LabelsChecksum     int64
+	CardLabelCount     int64
+	LastCardLabelID    uint
+	CommentCount       int64
+	AssigneesUpdatedAt *time.Time
+}
+
+// Порядок списков досок.
+const (
+	BoardSortName     = "name"
+	BoardSortUpdated  = "updated_at"
+	BoardSortActivity = "activity"
+)
+
+// BoardFilter задает выборку досок, доступных пользователю.
+type BoardFilter struct {
+	UserID uint
+	// WorkspaceID, если задан, ограничивает выборку досками одного рабочего пространства.
+	WorkspaceID uint
+	// Archived отбирает только архивные (true) или только действующие (false) доски; nil — все.
+	Archived *bool
+	Starred  bool
+	Sort     string
+	After    *BoardCursor
+	Limit    int
+}
+
+// BoardCursor — позиция последней доски предыдущей страницы: ключ сортировки и ID.
+type BoardCursor struct {
+	Title string    `json:"title,omitempty"`
+	At    time.Time `json:"at,omitempty"`
+	ID    uint      `json:"id"`
+}
+
 type BoardRepository interface {
 	Create(ctx context.Context, board *models.Board) error
 	GetByID(ctx context.Context, id uint) (*models.Board, error)
+	GetRevision(ctx context.Context, boardID uint) (*BoardRevision, error)
 	GetByOwnerID(ctx context.Context, ownerID uint) ([]models.Board, error)
+	List(ctx context.Context, filter BoardFilter) ([]models.Board, error)
+	GetRecent(ctx context.Context, userID uint, limit int) ([]models.Board, error)
+	TouchActivity(ctx context.Context, boardID uint, at time.Time) error
 	Update(ctx context.Context, board *models.Board) error
+	Delete(ctx context.Context, id uint, version int) error
+	GetByIDUnscoped(ctx context.Context, id uint) (*models.Board, error)
+	Restore(ctx context.Context, id uint) error
+	Purge(ctx context.Context, id uint) error
+}
+
+type WorkspaceRepository interface {
+	Create(ctx context.Context, workspace *models.Workspace) error
+	GetByID(ctx context.Context, id uint) (*models.Workspace, error)
+	GetByUserID(ctx context.Context, userID uint) ([]models.Workspace, error)
+	Update(ctx context.Context, workspace *models.Workspace) error
+	Delete(ctx context.Context, id uint, version int) error
+}
+
+type WorkspaceMemberRepository interface {
+	Add(ctx context.Context, member *models.WorkspaceMember) error
+	Get(ctx context.Context, workspaceID, userID uint) (*models.WorkspaceMember, error)
+	GetByWorkspaceID(ctx context.Context, workspaceID uint) ([]models.WorkspaceMember, error)
+	UpdateRole(ctx context.Context, workspaceID, userID uint, role string) error
+	Remove(ctx context.Context, workspaceID, userID uint) error
+}
+
+type BoardStarRepository interface {
+	Star(ctx context.Context, boardID, userID uint) error
+	Unstar(ctx context.Context, boardID, userID uint) error
+}
+
+type BoardViewRepository interface {
+	Record(ctx context.Context, boardID, userID uint, at time.Time, keep int) error
+}
+
+type BoardTemplateRepository interface {
+	Create(ctx context.Context, template *models.BoardTemplate) error
+	GetByID(ctx context.Context, id uint) (*models.BoardTemplate, error)
+	GetByOwnerID(ctx context.Context, ownerID uint) ([]models.BoardTemplate, error)
 	Delete(ctx context.Context, id uint) error
 }
 
 type ColumnRepository interface {
 	Create(ctx context.Context, column *models.Column) error
 	GetByID(ctx context.Context, id uint) (*models.Column, error)
+	GetByIDUnscoped(ctx context.Context, id uint) (*models.Column, error)
 	GetByBoardID(ctx context.Context, boardID uint) ([]models.Column, error)
+	CreateOrdered(ctx context.Context, columns []models.Column) error
 	Update(ctx context.Context, column *models.Column) error
-	Delete(ctx context.Context, id uint) error
-	UpdatePositions(ctx context.Context, columns []models.Column) error
+	Delete(ctx context.Context, id uint, version int) error
+	Reorder(ctx context.Context, boardID uint, ids []uint) (bool, error)
+	LockWIPUsage(ctx context.Context, columnID uint) (*models.WIPUsage, error)
+	Rebalance(ctx context.Context, boardID uint) error
+	GetUnbalancedBoardIDs(ctx context.Context, maxRankLength, limit int) ([]uint, error)
 }
 
 type CardRepository interface {
 	Create(ctx context.Context, card *models.Card) error
 	GetByID(ctx context.Context, id uint) (*models.Card, error)
+	GetByIDUnscoped(ctx context.Context, id uint) (*models.Card, error)
 	GetByColumnID(ctx context.Context, columnID uint) ([]models.Card, error)
+	GetUpdatedSince(ctx context.Context, boardIDs []uint, since time.Time) ([]models.Card, error)
 	Update(ctx context.Context, card *models.Card) error
-	Delete(ctx context.Context, id uint) error
-	UpdatePositions(ctx context.Context, cards []models.Card) error
-	MoveToColumn(ctx context.Context, cardID, columnID uint, position int) error
+	Delete(ctx context.Context, id uint, version int) error
+	Reorder(ctx context.Context, columnID uint, ids []uint) (bool, error)
+	GetByBoardID(ctx context.Context, boardID uint) ([]models.Card, error)
+	CreateOrdered(ctx context.Context, cards []models.Card) error
+	MoveToColumn(ctx context.Context, cardID, columnID uint, swimlaneID *uint, position int) (int, error)
+	SetProgress(ctx context.Context, cardID uint, startedAt, completedAt *time.Time) error
+	Rebalance(ctx context.Context, columnID uint) error
+	GetUnbalancedColumnIDs(ctx context.Context, maxRankLength, limit int) ([]uint, error)
+}
+
+type SwimlaneRepository interface {
+	Create(ctx context.Context, swimlane *models.Swimlane) error
+	GetByID(ctx context.Context, id uint) (*models.Swimlane, error)
+	GetByBoardID(ctx context.Context, boardID uint) ([]models.Swimlane, error)
+	CreateOrdered(ctx context.Context, swimlanes []models.Swimlane) error
+	Update(ctx context.Context, swimlane *models.Swimlane) error
+	Delete(ctx context.Context, id uint, version int) error
+	Reorder(ctx context.Context, boardID uint, ids []uint) (bool, error)
+	Rebalance(ctx context.Context, boardID uint) error
+	GetUnbalancedBoardIDs(ctx context.Context, maxRankLength, limit int) ([]uint, error)
 }
 
 type CommentRepository interface {
 	Create(ctx context.Context, comment *models.Comment) error
 	GetByID(ctx context.Context, id uint) (*models.Comment, error)
+	GetByIDUnscoped(ctx context.Context, id uint) (*models.Comment, error)
 	GetByCardID(ctx context.Context, cardID uint) ([]models.Comment, error)
-	Update(ctx context.Context, comment *models.Comment) error
-	Delete(ctx context.Context, id uint) error
+	Update(ctx context.Context, comment *models.Comment, editedBy uint) (bool, error)
+	Delete(ctx context.Context, id uint, version int) error
+	Purge(ctx context.Context, id uint) error
+	GetRevisions(ctx context.Context, commentID uint) ([]models.CommentRevision, error)
+	CountByCardIDs(ctx context.Context, cardIDs []uint) (map[uint]int, error)
+	GetByCardIDs(ctx context.Context, cardIDs []uint) (map[uint][]models.Comment, error)
 }
 
 type LabelRepository interface {
 	Create(ctx context.Context, label *models.Label) error
 	GetByID(ctx context.Context, id uint) (*models.Label, error)
 	GetByBoardID(ctx context.Context, boardID uint) ([]models.Label, error)
+	GetByWorkspaceID(ctx context.Context, workspaceID uint) ([]models.Label, error)
 	Update(ctx context.Context, label *models.Label) error
-	Delete(ctx context.Context, id uint) error
+	Delete(ctx context.Context, id uint, version int) error
+	Share(ctx context.Context, id uint, workspaceID *uint) error
+	DetachFromWorkspace(ctx context.Context, boardID uint) error
 }
 
 type CardLabelRepository interface {
 	AddLabelToCard(ctx context.Context, cardID uint, labelID uint) error
 	RemoveLabelFromCard(ctx context.Context, cardID uint, labelID uint) error
 	GetLabelsByCardID(ctx context.Context, cardID uint) ([]models.Label, error)
+	GetLabelsByCardIDs(ctx context.Context, cardIDs []uint) (map[uint][]models.Label, error)
+	AddMany(ctx context.Context, cardLabels []models.CardLabel) error
 	GetCardsByLabelID(ctx context.Context, labelID uint) ([]models.Card, error)
 }
 
+type BoardMemberRepository interface {
+	Add(ctx context.Context, member *models.BoardMember) error
+	Get(ctx context.Context, boardID, userID uint) (*models.BoardMember, error)
+	GetByBoardID(ctx context.Context, boardID uint) ([]models.BoardMember, error)
+	UpdateRole(ctx context.Context, boardID, userID uint, role string) error
+	Remove(ctx context.Context, boardID, userID uint) error
+	GetBoardUsers(ctx context.Context, boardID uint) ([]models.User, error)
+	GetUserBoardIDs(ctx context.Context, userID uint) ([]uint, error)
+}
+
+type CardGuestRepository interface {
+	Add(ctx context.Context, cardID, userID uint) error
+	Remove(ctx context.Context, cardID, userID uint) error
+	RemoveFromBoard(ctx context.Context, boardID, userID uint) error
+	GetByBoardID(ctx context.Context, boardID uint) ([]models.CardGuest, error)
+	GetCardIDs(ctx context.Context, boardID, userID uint) ([]uint, error)
+	CountByColumn(ctx context.Context, boardID, userID uint) (map[uint]int, error)
+	GetHiddenCardIDs(ctx context.Context, userID uint, cardIDs []uint) ([]uint, error)
+	GetHiddenUserIDs(ctx context.Context, cardID uint, userIDs []uint) ([]uint, error)
+}
+
+type MentionRepository interface {
+	ReplaceForSource(ctx context.Context, sourceType string, sourceID uint, mentions []models.Mention) error
+	GetBySource(ctx context.Context, sourceType string, sourceID uint) ([]models.Mention, error)
+	GetBySources(ctx context.Context, sourceType string, sourceIDs []uint) ([]models.Mention, error)
+}
+
+type CardWatcherRepository interface {
+	Watch(ctx context.Context, cardID, userID uint) error
+	Unwatch(ctx context.Context, cardID, userID uint) error
+	GetByCardID(ctx context.Context, cardID uint) ([]models.CardWatcher, error)
+	GetWatcherIDs(ctx context.Context, cardID uint) ([]uint, error)
+	GetWatchedCards(ctx context.Context, userID uint) ([]models.Card, error)
+}
+
+// NotificationFilter задает выборку уведомлений пользователя.
+type NotificationFilter struct {
+	UnreadOnly bool
+	Limit      int
+	Offset     int
+}
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	GetByUserID(ctx context.Context, userID uint, filter NotificationFilter) ([]models.Notification, error)
+	CountUnread(ctx context.Context, userID uint) (int64, error)
+	SetRead(ctx context.Context, id, userID uint, read bool) error
+	MarkAllRead(ctx context.Context, userID uint) (int64, error)
+	GetPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error)
+	ReplacePreferences(ctx context.Context, userID uint, prefs []models.NotificationPreference) error
+	IsMuted(ctx context.Context, userID uint, notificationType string, boardID uint) (bool, error)
+}
+
+type CardReminderRepository interface {
+	Replace(ctx context.Context, cardID uint, reminders []models.CardReminder) error
+	CancelPending(ctx context.Context, cardID uint) error
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]models.CardReminder, error)
+	GetUnplannedCards(ctx context.Context, now time.Time) ([]models.Card, error)
+}
+
+type EmailRepository interface {
+	Enqueue(ctx context.Context, email *models.OutboundEmail) error
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.OutboundEmail, error)
+	MarkSent(ctx context.Context, id uint, sentAt time.Time) error
+	MarkAttemptFailed(ctx context.Context, id uint, lastError string, nextAttempt time.Time) error
+	GetPreference(ctx context.Context, userID uint) (*models.EmailPreference, error)
+	SavePreference(ctx context.Context, pref *models.EmailPreference) error
+	GetDigestRecipients(ctx context.Context, digest string, before time.Time) ([]models.EmailPreference, error)
+	SetLastDigest(ctx context.Context, userID uint, at time.Time) error
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.Webhook) error
+	GetByID(ctx context.Context, id uint) (*models.Webhook, error)
+	GetByBoardID(ctx context.Context, boardID uint) ([]models.Webhook, error)
+	GetActiveByBoardID(ctx context.Context, boardID uint) ([]models.Webhook, error)
+	Update(ctx context.Context, webhook *models.Webhook) error
+	Delete(ctx context.Context, id uint) error
+	RecordSuccess(ctx context.Context, id uint) error
+	RecordFailure(ctx context.Context, id uint, threshold int, now time.Time) (bool, error)
+	CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, webhookID, id uint) (*models.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, webhookID uint, limit int) ([]models.WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	SaveDeliveryAttempt(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+type BoardShareRepository interface {
+	Create(ctx context.Context, link *models.BoardShareLink) error
+	GetByID(ctx context.Context, id uint) (*models.BoardShareLink, error)
+	GetByToken(ctx context.Context, token string) (*models.BoardShareLink, error)
+	GetByBoardID(ctx context.Context, boardID uint) ([]models.BoardShareLink, error)
+	Revoke(ctx context.Context, id uint, at time.Time) error
+}
+
+type OutboxRepository interface {
+	Append(ctx context.Context, event *models.OutboxEvent) error
+	WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error)
+	GetPending(ctx context.Context, limit int) ([]models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, ids []uint64, at time.Time) error
+	MarkFailed(ctx context.Context, id uint64, lastError string) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type BoardEventRepository interface {
+	Append(ctx context.Context, event *models.BoardEventLog) error
+	GetByID(ctx context.Context, id uint64) (*models.BoardEventLog, error)
+	GetAfter(ctx context.Context, boardID uint, afterID uint64, limit int) ([]models.BoardEventLog, error)
+	GetBounds(ctx context.Context) (uint64, uint64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type PubSubRepository interface {
+	Notify(ctx context.Context, channel, payload string) error
+	Listen(ctx context.Context, channels []string, handle func(channel, payload string)) error
+}
+
+type BoardPresenceRepository interface {
+	Touch(ctx context.Context, presence *models.BoardPresence, keepCard bool) (*models.BoardPresence, error)
+	Remove(ctx context.Context, boardID, userID uint, sessionID string) (*models.BoardPresence, error)
+	GetActive(ctx context.Context, boardID uint, now time.Time) ([]models.BoardPresence, error)
+	DeleteExpired(ctx context.Context, now time.Time) ([]models.BoardPresence, error)
+}
+
 type Repositories struct {
-	User      UserRepository
-	Board     BoardRepository
-	Column    ColumnRepository
-	Card      CardRepository
-	Comment   CommentRepository
-	Label     LabelRepository
-	CardLabel CardLabelRepository
+	User            UserRepository
+	Board           BoardRepository
+	Workspace       WorkspaceRepository
+	WorkspaceMember WorkspaceMemberRepository
+	BoardStar       BoardStarRepository
+	BoardView       BoardViewRepository
+	BoardShare      BoardShareRepository
+	Template        BoardTemplateRepository
+	Column          ColumnRepository
+	Card            CardRepository
+	Swimlane        SwimlaneRepository
+	Comment         CommentRepository
+	Label           LabelRepository
+	CardLabel       CardLabelRepository
+	Member          BoardMemberRepository
+	CardGuest       CardGuestRepository
+	Mention         MentionRepository
+	Watcher         CardWatcherRepository
+	Notification    NotificationRepository
+	Email           EmailRepository
+	Reminder        CardReminderRepository
+	Webhook         WebhookRepository
+	Outbox          OutboxRepository
+	BoardEvent      BoardEventRepository
+	PubSub          PubSubRepository
+	Presence        BoardPresenceRepository
+	Transactor      Transactor
 }
 
 func NewRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		User:      NewUserRepo(db),
-		Board:     NewBoardRepo(db),
-		Column:    NewColumnRepo(db),
-		Card:      NewCardRepo(db),
-		Comment:   NewCommentRepo(db),
-		Label:     NewLabelRepo(db),
-		CardLabel: NewCardLabelRepo(db),
+		User:            NewUserRepo(db),
+		Board:           NewBoardRepo(db),
+		Workspace:       NewWorkspaceRepo(db),
+		WorkspaceMember: NewWorkspaceMemberRepo(db),
+		BoardStar:       NewBoardStarRepo(db),
+		BoardView:       NewBoardViewRepo(db),
+		BoardShare:      NewBoardShareRepo(db),
+		Template:        NewBoardTemplateRepo(db),
+		Column:          NewColumnRepo(db),
+		Card:            NewCardRepo(db),
+		Swimlane:        NewSwimlaneRepo(db),
+		Comment:         NewCommentRepo(db),
+		Label:           NewLabelRepo(db),
+		CardLabel:       NewCardLabelRepo(db),
+		Member:          NewBoardMemberRepo(db),
+		CardGuest:       NewCardGuestRepo(db),
+		Mention:         NewMentionRepo(db),
+		Watcher:         NewCardWatcherRepo(db),
+		Notification:    NewNotificationRepo(db),
+		Email:           NewEmailRepo(db),
+		Reminder:        NewCardReminderRepo(db),
+		Webhook:         NewWebhookRepo(db),
+		Outbox:          NewOutboxRepo(db),
+		BoardEvent:      NewBoardEventRepo(db),
+		PubSub:          NewPubSubRepo(db),
+		Presence:        NewBoardPresenceRepo(db),
+		Transactor:      NewGormTransactor(db),
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+type SwimlaneRepo struct {
+	db *gorm.DB
+}
+
+func NewSwimlaneRepo(db *gorm.DB) *SwimlaneRepo {
+	return &SwimlaneRepo{db: db}
+}
+
+// Create добавляет дорожку в конец доски.
+func (r *SwimlaneRepo) Create(ctx context.Context, swimlane *models.Swimlane) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		rank, position, err := swimlaneList.rankAt(tx, swimlane.BoardID, nil, 0, math.MaxInt32)
+		if err != nil {
+			return err
+		}
+		swimlane.Rank = rank
+		swimlane.Position = position
+
+		if err := tx.Create(swimlane).Error; err != nil {
+			return models.NewDatabaseError("creating swimlane", err)
+		}
+
+		return nil
+	})
+}
+
+// CreateOrdered добавляет дорожки новой доски в порядке следования в срезе.
+// Дорожки должны быть первыми на своих досках: ранги раздаются без учета существующих.
+func (r *SwimlaneRepo) CreateOrdered(ctx context.Context, swimlanes []models.Swimlane) error {
+	if len(swimlanes) == 0 {
+		return nil
+	}
+	spreadRanks(len(swimlanes),
+		func(i int) uint { return swimlanes[i].BoardID },
+		func(i int, rank string) { swimlanes[i].Rank = rank })
+
+	if err := dbFromContext(ctx, r.db).Create(&swimlanes).Error; err != nil {
+		return models.NewDatabaseError("creating swimlanes", err)
+	}
+	return nil
+}
+
+func (r *SwimlaneRepo) GetByID(ctx context.Context, id uint) (*models.Swimlane, error) {
+	var swimlane models.Swimlane
+	result := dbFromContext(ctx, r.db).Select(swimlaneList.positionSelect()).First(&swimlane, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrSwimlaneNotFound
+		}
+		return nil, models.NewDatabaseError("getting swimlane by ID", result.Error)
+	}
+	return &swimlane, nil
+}
+
+func (r *SwimlaneRepo) GetByBoardID(ctx context.Context, boardID uint) ([]models.Swimlane, error) {
+	var swimlanes []models.Swimlane
+	result := dbFromContext(ctx, r.db).
+		Select(swimlaneList.positionsSelect()).
+		Where("board_id = ?", boardID).
+		Order(swimlaneList.order()).
+		Find(&swimlanes)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting swimlanes by board ID", result.Error)
+	}
+	return swimlanes, nil
+}
+
+// Update сохраняет дорожку, если ее версия не изменилась с момента чтения, и увеличивает версию.
+func (r *SwimlaneRepo) Update(ctx context.Context, swimlane *models.Swimlane) error {
+	return updateVersioned(dbFromContext(ctx, r.db), swimlane, swimlane.ID, &swimlane.Version, models.ErrSwimlaneNotFound, "updating swimlane")
+}
+
+// Delete удаляет дорожку; ее карточки переходят в дорожку по умолчанию.
+func (r *SwimlaneRepo) Delete(ctx context.Context, id uint, version int) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("version = ?", version).Delete(&models.Swimlane{}, id)
+		if result.Error != nil {
+			return models.NewDatabaseError("deleting swimlane", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return versionConflict(tx, &models.Swimlane{}, id, models.ErrSwimlaneNotFound)
+		}
+
+		err := tx.Table("cards").
+			Where("swimlane_id = ?", id).
+			Updates(map[string]interface{}{
+				"swimlane_id": nil,
+				"version":     gorm.Expr("version + 1"),
+				"updated_at":  gorm.Expr("NOW()"),
+			}).Error
+		if err != nil {
+			return models.NewDatabaseError("detaching swimlane cards", err)
+		}
+		return nil
+	})
+}
+
+// Reorder расставляет дорожки доски в порядке ids. Возвращает false, если порядок не изменился.
+func (r *SwimlaneRepo) Reorder(ctx context.Context, boardID uint, ids []uint) (bool, error) {
+	var changed bool
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var err error
+		changed, err = swimlaneList.reorder(tx, boardID, ids)
+		return err
+	})
+	return changed, err
+}
+
+// Rebalance заново раздает ранги дорожкам доски, не меняя их порядка.
+func (r *SwimlaneRepo) Rebalance(ctx context.Context, boardID uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return swimlaneList.rebalance(tx, boardID)
+	})
+}
+
+// GetUnbalancedBoardIDs возвращает доски, ранги дорожек в которых пора перераздать.
+func (r *SwimlaneRepo) GetUnbalancedBoardIDs(ctx context.Context, maxRankLength, limit int) ([]uint, error) {
+	return swimlaneList.unbalanced(dbFromContext(ctx, r.db), maxRankLength, limit)
+}
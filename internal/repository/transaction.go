@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// Transactor выполняет функцию в транзакции. Репозитории, вызванные с контекстом,
+// который передан в fn, работают в этой же транзакции.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type GormTransactor struct {
+	db *gorm.DB
+}
+
+func NewGormTransactor(db *gorm.DB) *GormTransactor {
+	return &GormTransactor{db: db}
+}
+
+// WithinTransaction начинает транзакцию или, если контекст уже в транзакции, продолжает ее.
+func (t *GormTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// dbFromContext возвращает транзакцию из контекста или db, если транзакции нет.
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -17,7 +17,7 @@ func NewUserRepo(db *gorm.DB) *UserRepo {
 }
 
 func (r *UserRepo) Create(ctx context.Context, user *models.User) error {
-	result := r.db.WithContext(ctx).Create(user)
+	result := dbFromContext(ctx, r.db).Create(user)
 	if result.Error != nil {
 		return models.NewDatabaseError("creating user", result.Error)
 	}
@@ -26,7 +26,7 @@ func (r *UserRepo) Create(ctx context.Context, user *models.User) error {
 
 func (r *UserRepo) GetByID(ctx context.Context, id uint) (*models.User, error) {
 	var user models.User
-	result := r.db.WithContext(ctx).First(&user, id)
+	result := dbFromContext(ctx, r.db).First(&user, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, models.ErrUserNotFound
@@ -36,9 +36,23 @@ func (r *UserRepo) GetByID(ctx context.Context, id uint) (*models.User, error) {
 	return &user, nil
 }
 
+// GetByIDs возвращает найденных пользователей из ids; отсутствующие пропускаются.
+func (r *UserRepo) GetByIDs(ctx context.Context, ids []uint) ([]models.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var users []models.User
+	result := dbFromContext(ctx, r.db).Where("id IN ?", ids).Find(&users)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting users by IDs", result.Error)
+	}
+	return users, nil
+}
+
 func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	result := r.db.WithContext(ctx).Where("email = ?", email).First(&user)
+	result := dbFromContext(ctx, r.db).Where("email = ?", email).First(&user)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, models.ErrUserNotFound
@@ -49,7 +63,7 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*models.User,
 }
 
 func (r *UserRepo) Update(ctx context.Context, user *models.User) error {
-	result := r.db.WithContext(ctx).Save(user)
+	result := dbFromContext(ctx, r.db).Save(user)
 	if result.Error != nil {
 		return models.NewDatabaseError("updating user", result.Error)
 	}
@@ -60,7 +74,7 @@ func (r *UserRepo) Update(ctx context.Context, user *models.User) error {
 }
 
 func (r *UserRepo) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&models.User{}, id)
+	result := dbFromContext(ctx, r.db).Delete(&models.User{}, id)
 	if result.Error != nil {
 		return models.NewDatabaseError("deleting user", result.Error)
 	}
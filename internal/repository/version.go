@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// updateVersioned записывает все поля записи, если ее версия в базе равна version в модели,
+// и увеличивает версию. При неудаче версия модели не меняется. Связи, ID и дата создания
+// не перезаписываются.
+func updateVersioned(db *gorm.DB, model interface{}, id uint, version *int, notFound error, operation string) error {
+	expected := *version
+	*version = expected + 1
+
+	result := db.Model(model).
+		Where("version = ?", expected).
+		Select("*").
+		Omit(clause.Associations, "id", "created_at", "deleted_at").
+		Updates(model)
+	if result.Error != nil {
+		*version = expected
+		return models.NewDatabaseError(operation, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		*version = expected
+		return versionConflict(db, model, id, notFound)
+	}
+	return nil
+}
+
+// versionConflict выясняет, почему условная запись не затронула ни одной строки:
+// запись удалена или ее версия уже другая.
+func versionConflict(db *gorm.DB, model interface{}, id uint, notFound error) error {
+	var count int64
+	if err := db.Session(&gorm.Session{NewDB: true}).Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return models.NewDatabaseError("checking record version", err)
+	}
+	if count == 0 {
+		return notFound
+	}
+	return models.ErrVersionConflict
+}
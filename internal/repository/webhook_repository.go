@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepo(db *gorm.DB) *WebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+func (r *WebhookRepo) Create(ctx context.Context, webhook *models.Webhook) error {
+	result := dbFromContext(ctx, r.db).Create(webhook)
+	if result.Error != nil {
+		return models.NewDatabaseError("creating webhook", result.Error)
+	}
+	return nil
+}
+
+func (r *WebhookRepo) GetByID(ctx context.Context, id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	result := dbFromContext(ctx, r.db).First(&webhook, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrWebhookNotFound
+		}
+		return nil, models.NewDatabaseError("getting webhook", result.Error)
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepo) GetByBoardID(ctx context.Context, boardID uint) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	result := dbFromContext(ctx, r.db).Where("board_id = ?", boardID).Order("id ASC").Find(&webhooks)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting board webhooks", result.Error)
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepo) GetActiveByBoardID(ctx context.Context, boardID uint) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	result := dbFromContext(ctx, r.db).Where("board_id = ? AND active", boardID).Find(&webhooks)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting active webhooks", result.Error)
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepo) Update(ctx context.Context, webhook *models.Webhook) error {
+	result := dbFromContext(ctx, r.db).Save(webhook)
+	if result.Error != nil {
+		return models.NewDatabaseError("updating webhook", result.Error)
+	}
+	return nil
+}
+
+// Delete удаляет вебхук вместе с журналом доставок.
+func (r *WebhookRepo) Delete(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return models.NewDatabaseError("deleting webhook deliveries", err)
+		}
+
+		result := tx.Delete(&models.Webhook{}, id)
+		if result.Error != nil {
+			return models.NewDatabaseError("deleting webhook", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrWebhookNotFound
+		}
+		return nil
+	})
+}
+
+// RecordSuccess сбрасывает счетчик последовательных неудач.
+func (r *WebhookRepo) RecordSuccess(ctx context.Context, id uint) error {
+	result := dbFromContext(ctx, r.db).Model(&models.Webhook{}).
+		Where("id = ? AND failure_count <> 0", id).
+		Update("failure_count", 0)
+	if result.Error != nil {
+		return models.NewDatabaseError("resetting webhook failures", result.Error)
+	}
+	return nil
+}
+
+// RecordFailure увеличивает счетчик последовательных неудач и отключает вебхук,
+// когда он достигает threshold. Возвращает true, если вебхук был отключен этим вызовом.
+func (r *WebhookRepo) RecordFailure(ctx context.Context, id uint, threshold int, now time.Time) (bool, error) {
+	var disabled bool
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var webhook models.Webhook
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&webhook, id).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"failure_count": webhook.FailureCount + 1}
+		if webhook.Active && webhook.FailureCount+1 >= threshold {
+			updates["active"] = false
+			updates["disabled_at"] = now
+			disabled = true
+		}
+
+		return tx.Model(&webhook).Updates(updates).Error
+	})
+	if err != nil {
+		return false, models.NewDatabaseError("recording webhook failure", err)
+	}
+	return disabled, nil
+}
+
+func (r *WebhookRepo) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	// Одно и то же событие может прийти повторно из outbox, поэтому уже созданные доставки пропускаются.
+	result := dbFromContext(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "webhook_id"}, {Name: "event_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "redelivery_of IS NULL"}}},
+		DoNothing:   true,
+	}).Create(&deliveries)
+	if result.Error != nil {
+		return models.NewDatabaseError("creating webhook deliveries", result.Error)
+	}
+	return nil
+}
+
+func (r *WebhookRepo) GetDelivery(ctx context.Context, webhookID, id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	result := dbFromContext(ctx, r.db).Where("id = ? AND webhook_id = ?", id, webhookID).First(&delivery)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrDeliveryNotFound
+		}
+		return nil, models.NewDatabaseError("getting webhook delivery", result.Error)
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepo) GetDeliveries(ctx context.Context, webhookID uint, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	result := dbFromContext(ctx, r.db).
+		Where("webhook_id = ?", webhookID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&deliveries)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting webhook deliveries", result.Error)
+	}
+	return deliveries, nil
+}
+
+// ClaimDueDeliveries выбирает доставки, готовые к отправке, и откладывает их
+// следующую попытку на lease, чтобы их не взял другой обработчик.
+func (r *WebhookRepo) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.DeliveryStatusPending, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, models.NewDatabaseError("claiming webhook deliveries", err)
+	}
+	return deliveries, nil
+}
+
+// SaveDeliveryAttempt сохраняет результат попытки доставки.
+func (r *WebhookRepo) SaveDeliveryAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	result := dbFromContext(ctx, r.db).Model(delivery).
+		Select("status", "attempts", "next_attempt_at", "response_status", "response_body", "last_error", "duration_ms", "delivered_at").
+		Updates(delivery)
+	if result.Error != nil {
+		return models.NewDatabaseError("saving webhook delivery", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+type WorkspaceMemberRepo struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceMemberRepo(db *gorm.DB) *WorkspaceMemberRepo {
+	return &WorkspaceMemberRepo{db: db}
+}
+
+func (r *WorkspaceMemberRepo) Add(ctx context.Context, member *models.WorkspaceMember) error {
+	var existingCount int64
+	if err := dbFromContext(ctx, r.db).Model(&models.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", member.WorkspaceID, member.UserID).
+		Count(&existingCount).Error; err != nil {
+		return models.NewDatabaseError("checking existing workspace member", err)
+	}
+
+	if existingCount > 0 {
+		return models.ErrWorkspaceMemberExists
+	}
+
+	if err := dbFromContext(ctx, r.db).Create(member).Error; err != nil {
+		return models.NewDatabaseError("adding workspace member", err)
+	}
+	return nil
+}
+
+func (r *WorkspaceMemberRepo) Get(ctx context.Context, workspaceID, userID uint) (*models.WorkspaceMember, error) {
+	var member models.WorkspaceMember
+	result := dbFromContext(ctx, r.db).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		First(&member)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrWorkspaceMemberNotFound
+		}
+		return nil, models.NewDatabaseError("getting workspace member", result.Error)
+	}
+	return &member, nil
+}
+
+func (r *WorkspaceMemberRepo) GetByWorkspaceID(ctx context.Context, workspaceID uint) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	result := dbFromContext(ctx, r.db).
+		Preload("User").
+		Where("workspace_id = ?", workspaceID).
+		Order("created_at ASC").
+		Find(&members)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting workspace members", result.Error)
+	}
+	return members, nil
+}
+
+func (r *WorkspaceMemberRepo) UpdateRole(ctx context.Context, workspaceID, userID uint, role string) error {
+	result := dbFromContext(ctx, r.db).Model(&models.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Update("role", role)
+	if result.Error != nil {
+		return models.NewDatabaseError("updating workspace member role", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrWorkspaceMemberNotFound
+	}
+	return nil
+}
+
+func (r *WorkspaceMemberRepo) Remove(ctx context.Context, workspaceID, userID uint) error {
+	result := dbFromContext(ctx, r.db).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Delete(&models.WorkspaceMember{})
+	if result.Error != nil {
+		return models.NewDatabaseError("removing workspace member", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrWorkspaceMemberNotFound
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+type WorkspaceRepo struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceRepo(db *gorm.DB) *WorkspaceRepo {
+	return &WorkspaceRepo{db: db}
+}
+
+func (r *WorkspaceRepo) Create(ctx context.Context, workspace *models.Workspace) error {
+	result := dbFromContext(ctx, r.db).Create(workspace)
+	if result.Error != nil {
+		return models.NewDatabaseError("creating workspace", result.Error)
+	}
+	return nil
+}
+
+func (r *WorkspaceRepo) GetByID(ctx context.Context, id uint) (*models.Workspace, error) {
+	var workspace models.Workspace
+	result := dbFromContext(ctx, r.db).First(&workspace, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, models.ErrWorkspaceNotFound
+		}
+		return nil, models.NewDatabaseError("getting workspace by ID", result.Error)
+	}
+	return &workspace, nil
+}
+
+// GetByUserID возвращает рабочие пространства, в которых состоит пользователь: сначала личное,
+// затем остальные по названию.
+func (r *WorkspaceRepo) GetByUserID(ctx context.Context, userID uint) ([]models.Workspace, error) {
+	var workspaces []models.Workspace
+	result := dbFromContext(ctx, r.db).
+		Where("id IN (?)", r.db.Model(&models.WorkspaceMember{}).Select("workspace_id").Where("user_id = ?", userID)).
+		Order("personal DESC, name ASC, id ASC").
+		Find(&workspaces)
+	if result.Error != nil {
+		return nil, models.NewDatabaseError("getting user workspaces", result.Error)
+	}
+	return workspaces, nil
+}
+
+// Update сохраняет рабочее пространство, если его версия не изменилась с момента чтения, и увеличивает версию.
+func (r *WorkspaceRepo) Update(ctx context.Context, workspace *models.Workspace) error {
+	return updateVersioned(dbFromContext(ctx, r.db), workspace, workspace.ID, &workspace.Version, models.ErrWorkspaceNotFound, "updating workspace")
+}
+
+// Delete удаляет рабочее пространство вместе с участниками. Пространство, в котором есть доски,
+// в том числе удаленные, но не удаленные безвозвратно, не удаляется.
+func (r *WorkspaceRepo) Delete(ctx context.Context, id uint, version int) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var boards int64
+		if err := tx.Unscoped().Model(&models.Board{}).Where("workspace_id = ?", id).Count(&boards).Error; err != nil {
+			return models.NewDatabaseError("counting workspace boards", err)
+		}
+		if boards > 0 {
+			return models.ErrWorkspaceNotEmpty
+		}
+
+		result := tx.Where("version = ?", version).Delete(&models.Workspace{}, id)
+		if result.Error != nil {
+			return models.NewDatabaseError("deleting workspace", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return versionConflict(tx, &models.Workspace{ID: id}, id, models.ErrWorkspaceNotFound)
+		}
+
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.WorkspaceMember{}).Error; err != nil {
+			return models.NewDatabaseError("deleting workspace members", err)
+		}
+		return nil
+	})
+}
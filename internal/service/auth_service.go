@@ -19,14 +19,18 @@ type tokenClaims struct {
 }
 
 type AuthService struct {
-	userRepo repository.UserRepository
-	cfg      *config.Config
+	userRepo   repository.UserRepository
+	workspaces WorkspaceServiceInterface
+	transactor repository.Transactor
+	cfg        *config.Config
 }
 
-func NewAuthService(userRepo repository.UserRepository, cfg *config.Config) *AuthService {
+func NewAuthService(userRepo repository.UserRepository, workspaces WorkspaceServiceInterface, transactor repository.Transactor, cfg *config.Config) *AuthService {
 	return &AuthService{
-		userRepo: userRepo,
-		cfg:      cfg,
+		userRepo:   userRepo,
+		workspaces: workspaces,
+		transactor: transactor,
+		cfg:        cfg,
 	}
 }
 
@@ -44,7 +48,15 @@ func (s *AuthService) Register(ctx context.Context, user *models.User) (uint, er
 	}
 	user.Password = string(hashedPassword)
 
-	if err := s.userRepo.Create(ctx, user); err != nil {
+	// Пользователь создается вместе с личным рабочим пространством, без него он не сможет создать доску.
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		_, err := s.workspaces.CreatePersonal(ctx, user)
+		return err
+	})
+	if err != nil {
 		return 0, err
 	}
 
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+// maxEventReplay — сколько пропущенных событий можно восстановить. Если пропущено больше,
+// клиенту дешевле заново загрузить доску.
+const maxEventReplay = 500
+
+// EventReplay — события, пропущенные клиентом. Reset означает, что часть событий уже недоступна
+// и клиенту нужно заново загрузить доску; LatestSeq — номер последнего опубликованного события.
+type EventReplay struct {
+	Events    []BoardEvent
+	Reset     bool
+	LatestSeq uint64
+}
+
+type BoardEventService struct {
+	eventRepo repository.BoardEventRepository
+}
+
+func NewBoardEventService(eventRepo repository.BoardEventRepository) *BoardEventService {
+	return &BoardEventService{eventRepo: eventRepo}
+}
+
+// Replay возвращает события доски, опубликованные после afterSeq.
+func (s *BoardEventService) Replay(ctx context.Context, boardID uint, afterSeq uint64) (*EventReplay, error) {
+	minSeq, maxSeq, err := s.eventRepo.GetBounds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := &EventReplay{LatestSeq: maxSeq}
+	if afterSeq > maxSeq || afterSeq+1 < minSeq {
+		replay.Reset = true
+		return replay, nil
+	}
+
+	logged, err := s.eventRepo.GetAfter(ctx, boardID, afterSeq, maxEventReplay+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(logged) > maxEventReplay {
+		replay.Reset = true
+		return replay, nil
+	}
+
+	replay.Events = make([]BoardEvent, 0, len(logged))
+	for _, entry := range logged {
+		event, err := decodeBoardEvent(entry.Payload)
+		if err != nil {
+			return nil, err
+		}
+		event.Seq = entry.ID
+		replay.Events = append(replay.Events, event)
+	}
+	return replay, nil
+}
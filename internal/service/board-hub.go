@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// BoardSubscription — подписка одного клиента на события доски. Если клиент не успевает
+// забирать события и буфер переполняется, подписка закрывается с флагом Dropped.
+type BoardSubscription struct {
+	BoardID uint
+	UserID  uint
+
+	events    chan BoardEvent
+	done      chan struct{}
+	dropped   atomic.Bool
+	closeOnce sync.Once
+	hub       *BoardHub
+}
+
+func (s *BoardSubscription) Events() <-chan BoardEvent {
+	return s.events
+}
+
+// Done закрывается, когда подписка завершена: клиентом, из-за переполнения буфера или при остановке хаба.
+func (s *BoardSubscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Dropped сообщает, что подписка закрыта из-за медленного клиента.
+func (s *BoardSubscription) Dropped() bool {
+	return s.dropped.Load()
+}
+
+func (s *BoardSubscription) Close() {
+	s.closeOnce.Do(func() {
+		s.hub.remove(s)
+		close(s.done)
+	})
+}
+
+// BoardHub раздает события из брокера подписчикам досок на этом экземпляре сервера.
+type BoardHub struct {
+	broker EventBroker
+
+	mu     sync.RWMutex
+	boards map[uint]map[*BoardSubscription]struct{}
+}
+
+func NewBoardHub(broker EventBroker) *BoardHub {
+	return &BoardHub{
+		broker: broker,
+		boards: make(map[uint]map[*BoardSubscription]struct{}),
+	}
+}
+
+// Run получает события из брокера, пока ctx не отменен, после чего закрывает все подписки.
+func (h *BoardHub) Run(ctx context.Context) {
+	unsubscribe := h.broker.Subscribe(h.dispatch)
+	<-ctx.Done()
+	unsubscribe()
+
+	h.mu.RLock()
+	var subscriptions []*BoardSubscription
+	for _, board := range h.boards {
+		for sub := range board {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, sub := range subscriptions {
+		sub.Close()
+	}
+}
+
+// Subscribe подписывает пользователя на события доски. Доступ к доске проверяет вызывающий.
+func (h *BoardHub) Subscribe(boardID, userID uint, buffer int) *BoardSubscription {
+	sub := &BoardSubscription{
+		BoardID: boardID,
+		UserID:  userID,
+		events:  make(chan BoardEvent, buffer),
+		done:    make(chan struct{}),
+		hub:     h,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	board, ok := h.boards[boardID]
+	if !ok {
+		board = make(map[*BoardSubscription]struct{})
+		h.boards[boardID] = board
+	}
+	board[sub] = struct{}{}
+
+	return sub
+}
+
+func (h *BoardHub) remove(sub *BoardSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	board := h.boards[sub.BoardID]
+	delete(board, sub)
+	if len(board) == 0 {
+		delete(h.boards, sub.BoardID)
+	}
+}
+
+func (h *BoardHub) dispatch(event BoardEvent) {
+	var slow []*BoardSubscription
+
+	h.mu.RLock()
+	for sub := range h.boards[event.BoardID] {
+		select {
+		case sub.events <- event:
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, sub := range slow {
+		sub.dropped.Store(true)
+		sub.Close()
+	}
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+// GuestAccess — гость доски и карточки, которые он видит.
+type GuestAccess struct {
+	models.BoardMember
+	Cards []models.Card `json:"cards"`
+}
+
+type BoardMemberService struct {
+	memberRepo  repository.BoardMemberRepository
+	boardRepo   repository.BoardRepository
+	userRepo    repository.UserRepository
+	guestRepo   repository.CardGuestRepository
+	cardRepo    repository.CardRepository
+	columnRepo  repository.ColumnRepository
+	watcherRepo repository.CardWatcherRepository
+	notifier    Notifier
+}
+
+func NewBoardMemberService(
+	memberRepo repository.BoardMemberRepository,
+	boardRepo repository.BoardRepository,
+	userRepo repository.UserRepository,
+	guestRepo repository.CardGuestRepository,
+	cardRepo repository.CardRepository,
+	columnRepo repository.ColumnRepository,
+	watcherRepo repository.CardWatcherRepository,
+	notifier Notifier,
+) *BoardMemberService {
+	return &BoardMemberService{
+		memberRepo:  memberRepo,
+		boardRepo:   boardRepo,
+		userRepo:    userRepo,
+		guestRepo:   guestRepo,
+		cardRepo:    cardRepo,
+		columnRepo:  columnRepo,
+		watcherRepo: watcherRepo,
+		notifier:    notifier,
+	}
+}
+
+func validBoardRole(role string) bool {
+	return role == models.BoardRoleAdmin || role == models.BoardRoleMember || role == models.BoardRoleGuest
+}
+
+func (s *BoardMemberService) AddMember(ctx context.Context, boardID, userID uint, role string) (*models.BoardMember, error) {
+	if role == "" {
+		role = models.BoardRoleMember
+	}
+	if !validBoardRole(role) {
+		return nil, models.ErrInvalidBoardRole
+	}
+
+	board, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.OwnerID == user.ID {
+		return nil, models.ErrMemberAlreadyExists
+	}
+
+	member := &models.BoardMember{
+		BoardID: boardID,
+		UserID:  userID,
+		Role:    role,
+	}
+	if err := s.memberRepo.Add(ctx, member); err != nil {
+		return nil, err
+	}
+
+	member.User = *user
+
+	actorID, _ := ActorFromContext(ctx)
+	s.notifier.Notify(ctx, NotificationEvent{
+		Type:      NotificationBoardInvite,
+		UserID:    userID,
+		ActorID:   actorID,
+		BoardID:   boardID,
+		Message:   fmt.Sprintf("You were added to board %q", board.Title),
+		CreatedAt: time.Now(),
+	})
+
+	return member, nil
+}
+
+func (s *BoardMemberService) GetMembers(ctx context.Context, boardID uint) ([]models.BoardMember, error) {
+	if _, err := s.boardRepo.GetByID(ctx, boardID); err != nil {
+		return nil, err
+	}
+
+	return s.memberRepo.GetByBoardID(ctx, boardID)
+}
+
+func (s *BoardMemberService) UpdateRole(ctx context.Context, boardID, userID uint, role string) error {
+	if !validBoardRole(role) {
+		return models.ErrInvalidBoardRole
+	}
+
+	return s.memberRepo.UpdateRole(ctx, boardID, userID, role)
+}
+
+// RemoveMember исключает участника из доски и закрывает от него карточки, открытые ему как гостю.
+func (s *BoardMemberService) RemoveMember(ctx context.Context, boardID, userID uint) error {
+	if err := s.memberRepo.Remove(ctx, boardID, userID); err != nil {
+		return err
+	}
+	return s.guestRepo.RemoveFromBoard(ctx, boardID, userID)
+}
+
+// HasAccess сообщает, является ли пользователь владельцем или полноправным участником доски.
+// Гости сюда не входят: им доступен только просмотр открытых карточек.
+func (s *BoardMemberService) HasAccess(ctx context.Context, boardID, userID uint) (bool, error) {
+	role, err := s.roleOf(ctx, boardID, userID)
+	if err != nil {
+		return false, err
+	}
+	return role != "" && role != models.BoardRoleGuest, nil
+}
+
+// CanView сообщает, может ли пользователь просматривать доску, в том числе как гость.
+func (s *BoardMemberService) CanView(ctx context.Context, boardID, userID uint) (bool, error) {
+	role, err := s.roleOf(ctx, boardID, userID)
+	if err != nil {
+		return false, err
+	}
+	return role != "", nil
+}
+
+// IsAdmin сообщает, может ли пользователь управлять доской: владелец всегда считается администратором.
+func (s *BoardMemberService) IsAdmin(ctx context.Context, boardID, userID uint) (bool, error) {
+	role, err := s.roleOf(ctx, boardID, userID)
+	if err != nil {
+		return false, err
+	}
+	return role == models.BoardRoleAdmin, nil
+}
+
+// GetGuests возвращает гостей доски вместе с карточками, которые видит каждый из них.
+func (s *BoardMemberService) GetGuests(ctx context.Context, boardID uint) ([]GuestAccess, error) {
+	members, err := s.GetMembers(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	cardGuests, err := s.guestRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	cards := make(map[uint][]models.Card)
+	for _, guest := range cardGuests {
+		cards[guest.UserID] = append(cards[guest.UserID], guest.Card)
+	}
+
+	guests := make([]GuestAccess, 0)
+	for _, member := range members {
+		if member.Role != models.BoardRoleGuest {
+			continue
+		}
+		access := GuestAccess{BoardMember: member, Cards: cards[member.UserID]}
+		if access.Cards == nil {
+			access.Cards = []models.Card{}
+		}
+		guests = append(guests, access)
+	}
+	return guests, nil
+}
+
+// AddGuestCard открывает гостю карточку доски.
+func (s *BoardMemberService) AddGuestCard(ctx context.Context, boardID, userID, cardID uint) error {
+	if err := s.checkGuest(ctx, boardID, userID); err != nil {
+		return err
+	}
+
+	card, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		return err
+	}
+	column, err := s.columnRepo.GetByID(ctx, card.ColumnID)
+	if err != nil {
+		return err
+	}
+	if column.BoardID != boardID {
+		return models.ErrCardNotFound
+	}
+
+	return s.guestRepo.Add(ctx, cardID, userID)
+}
+
+// RemoveGuestCard закрывает карточку от гостя.
+func (s *BoardMemberService) RemoveGuestCard(ctx context.Context, boardID, userID, cardID uint) error {
+	if err := s.checkGuest(ctx, boardID, userID); err != nil {
+		return err
+	}
+	if err := s.guestRepo.Remove(ctx, cardID, userID); err != nil {
+		return err
+	}
+	// Наблюдение за закрытой карточкой теряет смысл: уведомления о ней гостю больше не придут.
+	if err := s.watcherRepo.Unwatch(ctx, cardID, userID); err != nil && !errors.Is(err, models.ErrNotWatching) {
+		return err
+	}
+	return nil
+}
+
+func (s *BoardMemberService) checkGuest(ctx context.Context, boardID, userID uint) error {
+	member, err := s.memberRepo.Get(ctx, boardID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role != models.BoardRoleGuest {
+		return models.ErrNotBoardGuest
+	}
+	return nil
+}
+
+func (s *BoardMemberService) roleOf(ctx context.Context, boardID, userID uint) (string, error) {
+	board, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		return "", err
+	}
+	if board.OwnerID == userID {
+		return models.BoardRoleAdmin, nil
+	}
+
+	member, err := s.memberRepo.Get(ctx, boardID, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrMemberNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return member.Role, nil
+}
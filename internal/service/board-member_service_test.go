@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+)
+
+func TestBoardMemberServiceRemoveGuestCardUnwatches(t *testing.T) {
+	members := &fakeMemberRepo{members: []models.BoardMember{
+		{BoardID: 1, UserID: 2, Role: models.BoardRoleGuest},
+	}}
+	guests := &fakeGuestRepo{members: members, cards: map[uint][]uint{2: {10, 11}}}
+	watchers := &fakeWatcherRepo{watchers: map[uint][]uint{10: {1, 2}}}
+	service := NewBoardMemberService(members, nil, nil, guests, nil, nil, watchers, &recordingNotifier{})
+
+	// Карточку 11 гость не наблюдал: закрыть ее все равно можно.
+	for _, cardID := range []uint{10, 11} {
+		if err := service.RemoveGuestCard(context.Background(), 1, 2, cardID); err != nil {
+			t.Fatalf("RemoveGuestCard(%d): %v", cardID, err)
+		}
+	}
+
+	if len(guests.cards[2]) != 0 {
+		t.Errorf("guest cards = %v, want none", guests.cards[2])
+	}
+	if got := watchers.watchers[10]; !slices.Equal(got, []uint{1}) {
+		t.Errorf("card 10 watchers = %v, want [1]", got)
+	}
+}
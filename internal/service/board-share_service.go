@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BoardShareService управляет публичными ссылками на доски и отдает по ним снимки
+// только для чтения.
+type BoardShareService struct {
+	shareRepo   repository.BoardShareRepository
+	boardRepo   repository.BoardRepository
+	commentRepo repository.CommentRepository
+	snapshots   BoardSnapshotServiceInterface
+	members     BoardMemberServiceInterface
+}
+
+func NewBoardShareService(
+	shareRepo repository.BoardShareRepository,
+	boardRepo repository.BoardRepository,
+	commentRepo repository.CommentRepository,
+	snapshots BoardSnapshotServiceInterface,
+	members BoardMemberServiceInterface,
+) *BoardShareService {
+	return &BoardShareService{
+		shareRepo:   shareRepo,
+		boardRepo:   boardRepo,
+		commentRepo: commentRepo,
+		snapshots:   snapshots,
+		members:     members,
+	}
+}
+
+// Create выпускает новую ссылку на доску. Пустой пароль означает ссылку без пароля.
+func (s *BoardShareService) Create(ctx context.Context, link *models.BoardShareLink, password string) error {
+	if _, err := s.boardRepo.GetByID(ctx, link.BoardID); err != nil {
+		return err
+	}
+	if link.ExpiresAt != nil && !link.ExpiresAt.After(time.Now()) {
+		return models.NewValidationError("expires_at", "expiration must be in the future")
+	}
+
+	token := make([]byte, 24)
+	if _, err := rand.Read(token); err != nil {
+		return fmt.Errorf("generating share token: %w", err)
+	}
+	link.Token = base64.RawURLEncoding.EncodeToString(token)
+
+	link.PasswordHash = ""
+	if password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return fmt.Errorf("hashing share password: %w", err)
+		}
+		link.PasswordHash = string(hash)
+	}
+
+	if actorID, ok := ActorFromContext(ctx); ok {
+		link.CreatedBy = actorID
+	}
+	link.RevokedAt = nil
+
+	if err := s.shareRepo.Create(ctx, link); err != nil {
+		return err
+	}
+	link.HasPassword = link.PasswordHash != ""
+	return nil
+}
+
+// GetByBoardID возвращает все ссылки доски, включая отозванные и истекшие.
+func (s *BoardShareService) GetByBoardID(ctx context.Context, boardID uint) ([]models.BoardShareLink, error) {
+	links, err := s.shareRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range links {
+		links[i].HasPassword = links[i].PasswordHash != ""
+	}
+	return links, nil
+}
+
+// Revoke отзывает ссылку доски. После отзыва снимок по ней недоступен.
+func (s *BoardShareService) Revoke(ctx context.Context, boardID, id uint) (*models.BoardShareLink, error) {
+	link, err := s.shareRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if link.BoardID != boardID {
+		return nil, models.ErrShareLinkNotFound
+	}
+
+	if link.RevokedAt == nil {
+		now := time.Now()
+		if err := s.shareRepo.Revoke(ctx, id, now); err != nil {
+			return nil, err
+		}
+		link.RevokedAt = &now
+	}
+	link.HasPassword = link.PasswordHash != ""
+	return link, nil
+}
+
+// PublicSnapshot возвращает снимок доски по публичной ссылке. Отозванные и истекшие ссылки,
+// как и ссылки на удаленные доски, считаются ненайденными. Пароль не нужен пользователю,
+// у которого и так есть доступ к доске. Адреса почты и комментарии скрываются, если
+// ссылка не разрешает их показывать.
+func (s *BoardShareService) PublicSnapshot(ctx context.Context, token, password string) (*BoardSnapshot, error) {
+	link, err := s.shareRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !link.Active(time.Now()) {
+		return nil, models.ErrShareLinkNotFound
+	}
+	if link.PasswordHash != "" && !s.canView(ctx, link.BoardID) {
+		if password == "" || bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(password)) != nil {
+			return nil, models.ErrSharePasswordInvalid
+		}
+	}
+
+	fields := SnapshotFields{
+		SnapshotSwimlanes:     true,
+		SnapshotCards:         true,
+		SnapshotLabels:        true,
+		SnapshotAssignees:     true,
+		SnapshotCommentCounts: link.ShowComments,
+	}
+	snapshot, err := s.snapshots.Snapshot(ctx, link.BoardID, fields)
+	if err != nil {
+		if errors.Is(err, models.ErrBoardNotFound) {
+			return nil, models.ErrShareLinkNotFound
+		}
+		return nil, err
+	}
+
+	if link.ShowComments {
+		if err := s.attachComments(ctx, snapshot, link.ShowEmails); err != nil {
+			return nil, err
+		}
+	}
+	if !link.ShowEmails {
+		hideEmails(snapshot)
+	}
+	return snapshot, nil
+}
+
+func (s *BoardShareService) canView(ctx context.Context, boardID uint) bool {
+	actorID, ok := ActorFromContext(ctx)
+	if !ok {
+		return false
+	}
+	hasAccess, err := s.members.HasAccess(ctx, boardID, actorID)
+	return err == nil && hasAccess
+}
+
+func (s *BoardShareService) attachComments(ctx context.Context, snapshot *BoardSnapshot, showEmails bool) error {
+	cardIDs := make([]uint, 0)
+	for _, column := range snapshot.Columns {
+		for _, card := range column.Cards {
+			cardIDs = append(cardIDs, card.ID)
+		}
+	}
+
+	comments, err := s.commentRepo.GetByCardIDs(ctx, cardIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range snapshot.Columns {
+		cards := snapshot.Columns[i].Cards
+		for j := range cards {
+			for _, comment := range comments[cards[j].ID] {
+				item := SnapshotComment{
+					ID:         comment.ID,
+					Content:    comment.Content,
+					AuthorName: comment.User.Name,
+					CreatedAt:  comment.CreatedAt,
+				}
+				if showEmails {
+					item.AuthorEmail = comment.User.Email
+				}
+				cards[j].Comments = append(cards[j].Comments, item)
+			}
+		}
+	}
+	return nil
+}
+
+// hideEmails убирает адреса почты владельца доски и исполнителей из снимка.
+func hideEmails(snapshot *BoardSnapshot) {
+	snapshot.Board.Owner.Email = ""
+	for i := range snapshot.Columns {
+		for j := range snapshot.Columns[i].Cards {
+			if user := snapshot.Columns[i].Cards[j].User; user != nil {
+				user.Email = ""
+			}
+		}
+	}
+}
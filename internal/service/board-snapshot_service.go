@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+// Разделы снимка доски, которые можно выбрать параметром fields. Доска и колонки
+// возвращаются всегда; метки, исполнители и счетчики комментариев относятся к карточкам
+// и без них не загружаются.
+const (
+	SnapshotSwimlanes     = "swimlanes"
+	SnapshotCards         = "cards"
+	SnapshotLabels        = "labels"
+	SnapshotAssignees     = "assignees"
+	SnapshotCommentCounts = "comment_counts"
+)
+
+// SnapshotSections — все разделы снимка доски.
+var SnapshotSections = []string{SnapshotSwimlanes, SnapshotCards, SnapshotLabels, SnapshotAssignees, SnapshotCommentCounts}
+
+// SnapshotFields — выбранные разделы снимка доски.
+type SnapshotFields map[string]bool
+
+// ParseSnapshotFields разбирает список разделов через запятую. Пустой список означает все разделы.
+func ParseSnapshotFields(raw string) (SnapshotFields, error) {
+	fields := make(SnapshotFields, len(SnapshotSections))
+	if strings.TrimSpace(raw) == "" {
+		for _, section := range SnapshotSections {
+			fields[section] = true
+		}
+		return fields, nil
+	}
+
+	for _, section := range strings.Split(raw, ",") {
+		section = strings.TrimSpace(section)
+		if !slices.Contains(SnapshotSections, section) {
+			return nil, models.NewValidationError("fields", fmt.Sprintf("unknown field %q, expected %s", section, strings.Join(SnapshotSections, ", ")))
+		}
+		fields[section] = true
+	}
+	return fields, nil
+}
+
+// key — канонический вид набора разделов для вычисления ETag.
+func (f SnapshotFields) key() string {
+	selected := make([]string, 0, len(f))
+	for _, section := range SnapshotSections {
+		if f[section] {
+			selected = append(selected, section)
+		}
+	}
+	return strings.Join(selected, ",")
+}
+
+// BoardSnapshot — доска со всем содержимым, загруженная за фиксированное число запросов.
+type BoardSnapshot struct {
+	Board     *models.Board     `json:"board"`
+	Columns   []SnapshotColumn  `json:"columns"`
+	Swimlanes []models.Swimlane `json:"swimlanes,omitempty"`
+}
+
+// SnapshotColumn — колонка с карточками в порядке отображения.
+type SnapshotColumn struct {
+	models.Column
+	Cards []SnapshotCard `json:"cards,omitempty"`
+}
+
+// SnapshotCard — карточка с метками и числом комментариев. Исполнитель возвращается в поле user.
+// Сами комментарии есть только в публичном снимке, если ссылка их показывает.
+type SnapshotCard struct {
+	models.Card
+	Labels       []models.Label    `json:"labels,omitempty"`
+	CommentCount *int              `json:"comment_count,omitempty"`
+	Comments     []SnapshotComment `json:"comments,omitempty"`
+}
+
+// SnapshotComment — комментарий в публичном снимке доски.
+type SnapshotComment struct {
+	ID          uint      `json:"id"`
+	Content     string    `json:"content"`
+	AuthorName  string    `json:"author_name"`
+	AuthorEmail string    `json:"author_email,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type BoardSnapshotService struct {
+	boardRepo     repository.BoardRepository
+	columnRepo    repository.ColumnRepository
+	swimlaneRepo  repository.SwimlaneRepository
+	cardRepo      repository.CardRepository
+	cardLabelRepo repository.CardLabelRepository
+	commentRepo   repository.CommentRepository
+	userRepo      repository.UserRepository
+	visibility    *CardVisibility
+}
+
+func NewBoardSnapshotService(repos *repository.Repositories, visibility *CardVisibility) *BoardSnapshotService {
+	return &BoardSnapshotService{
+		boardRepo:     repos.Board,
+		columnRepo:    repos.Column,
+		swimlaneRepo:  repos.Swimlane,
+		cardRepo:      repos.Card,
+		cardLabelRepo: repos.CardLabel,
+		commentRepo:   repos.Comment,
+		userRepo:      repos.User,
+		visibility:    visibility,
+	}
+}
+
+// Revision возвращает метку состояния снимка доски с разделами fields. Метка меняется при
+// любом изменении, попадающем в снимок, и вычисляется одним запросом без загрузки снимка
+// (для гостей доски — еще одним запросом видимых им карточек).
+func (s *BoardSnapshotService) Revision(ctx context.Context, boardID uint, fields SnapshotFields) (string, error) {
+	revision, err := s.boardRepo.GetRevision(ctx, boardID)
+	if err != nil {
+		return "", err
+	}
+	visibility, err := s.visibility.Key(ctx, boardID)
+	if err != nil {
+		return "", err
+	}
+
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s|%s|%d|%d:%d|%d:%d|%d:%d|%d:%d|%d:%d|%d|%d",
+		fields.key(), visibility, revision.BoardVersion,
+		revision.ColumnCount, unixMicro(revision.ColumnsUpdatedAt),
+		revision.SwimlaneCount, unixMicro(revision.SwimlanesUpdatedAt),
+		revision.CardCount, unixMicro(revision.CardsUpdatedAt),
+		revision.LabelCount, revision.LabelsChecksum,
+		revision.CardLabelCount, revision.LastCardLabelID,
+		revision.CommentCount, unixMicro(revision.AssigneesUpdatedAt),
+	)
+	return fmt.Sprintf("%016x", h.Sum64()), nil
+}
+
+// Snapshot загружает доску с разделами fields. Число запросов не зависит от числа
+// колонок и карточек. Гость доски получает только открытые ему карточки, и число карточек
+// в колонках считается по ним же.
+func (s *BoardSnapshotService) Snapshot(ctx context.Context, boardID uint, fields SnapshotFields) (*BoardSnapshot, error) {
+	board, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := s.columnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.visibility.CountCards(ctx, boardID, columns); err != nil {
+		return nil, err
+	}
+
+	snapshot := &BoardSnapshot{Board: board, Columns: make([]SnapshotColumn, len(columns))}
+	columnIndex := make(map[uint]int, len(columns))
+	for i := range columns {
+		snapshot.Columns[i] = SnapshotColumn{Column: columns[i]}
+		columnIndex[columns[i].ID] = i
+	}
+
+	if fields[SnapshotSwimlanes] {
+		if snapshot.Swimlanes, err = s.swimlaneRepo.GetByBoardID(ctx, boardID); err != nil {
+			return nil, err
+		}
+	}
+
+	if !fields[SnapshotCards] {
+		return snapshot, nil
+	}
+
+	cards, err := s.cardRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if cards, err = s.visibility.Filter(ctx, cards); err != nil {
+		return nil, err
+	}
+	cardIDs := make([]uint, 0, len(cards))
+	for _, card := range cards {
+		cardIDs = append(cardIDs, card.ID)
+	}
+
+	var labels map[uint][]models.Label
+	if fields[SnapshotLabels] {
+		if labels, err = s.cardLabelRepo.GetLabelsByCardIDs(ctx, cardIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	var commentCounts map[uint]int
+	if fields[SnapshotCommentCounts] {
+		if commentCounts, err = s.commentRepo.CountByCardIDs(ctx, cardIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	var assignees map[uint]*models.User
+	if fields[SnapshotAssignees] {
+		if assignees, err = s.loadAssignees(ctx, cards); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, card := range cards {
+		i, ok := columnIndex[card.ColumnID]
+		if !ok {
+			continue
+		}
+
+		item := SnapshotCard{Card: card, Labels: labels[card.ID]}
+		if card.AssignedTo != nil {
+			item.User = assignees[*card.AssignedTo]
+		}
+		if commentCounts != nil {
+			count := commentCounts[card.ID]
+			item.CommentCount = &count
+		}
+		snapshot.Columns[i].Cards = append(snapshot.Columns[i].Cards, item)
+	}
+
+	return snapshot, nil
+}
+
+func (s *BoardSnapshotService) loadAssignees(ctx context.Context, cards []models.Card) (map[uint]*models.User, error) {
+	seen := make(map[uint]bool)
+	ids := make([]uint, 0)
+	for _, card := range cards {
+		if card.AssignedTo != nil && !seen[*card.AssignedTo] {
+			seen[*card.AssignedTo] = true
+			ids = append(ids, *card.AssignedTo)
+		}
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees := make(map[uint]*models.User, len(users))
+	for i := range users {
+		assignees[users[i].ID] = &users[i]
+	}
+	return assignees, nil
+}
+
+func unixMicro(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.UnixMicro()
+}
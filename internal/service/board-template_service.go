@@ -0,0 +1,518 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+// builtinTemplates — шаблоны, доступные всем пользователям. Они задаются в коде
+// и различаются по ключу.
+var builtinTemplates = []models.BoardTemplate{
+	{
+		Key:         "scrum",
+		Name:        "Scrum",
+		Description: "Product backlog, sprint backlog and a review step before done",
+		Layout: models.BoardLayout{
+			Columns: []models.TemplateColumn{
+				{Title: "Product Backlog", Kind: models.ColumnKindBacklog},
+				{Title: "Sprint Backlog", Kind: models.ColumnKindTodo},
+				{Title: "In Progress", Kind: models.ColumnKindInProgress},
+				{Title: "Review", Kind: models.ColumnKindReview},
+				{Title: "Done", Kind: models.ColumnKindDone},
+			},
+			Labels: []models.TemplateLabel{
+				{Name: "Story", Color: "#4CAF50"},
+				{Name: "Bug", Color: "#F44336"},
+				{Name: "Task", Color: "#2196F3"},
+				{Name: "Spike", Color: "#9C27B0"},
+			},
+		},
+	},
+	{
+		Key:         "kanban",
+		Name:        "Kanban",
+		Description: "Continuous flow with a WIP limit and an expedite lane",
+		Layout: models.BoardLayout{
+			Columns: []models.TemplateColumn{
+				{Title: "To Do", Kind: models.ColumnKindTodo},
+				{Title: "Doing", Kind: models.ColumnKindInProgress, WIPLimit: intPtr(3)},
+				{Title: "Done", Kind: models.ColumnKindDone},
+			},
+			Swimlanes: []models.TemplateSwimlane{
+				{Title: "Expedite"},
+				{Title: "Standard"},
+			},
+			Labels: []models.TemplateLabel{
+				{Name: "Blocked", Color: "#F44336"},
+			},
+		},
+	},
+	{
+		Key:         "bug-triage",
+		Name:        "Bug triage",
+		Description: "Incoming reports, triage, fix and verification",
+		Layout: models.BoardLayout{
+			Columns: []models.TemplateColumn{
+				{Title: "New", Kind: models.ColumnKindBacklog},
+				{Title: "Triaged", Kind: models.ColumnKindTodo},
+				{Title: "Fixing", Kind: models.ColumnKindInProgress},
+				{Title: "Verifying", Kind: models.ColumnKindReview},
+				{Title: "Closed", Kind: models.ColumnKindDone},
+			},
+			Labels: []models.TemplateLabel{
+				{Name: "Critical", Color: "#B71C1C"},
+				{Name: "Major", Color: "#FF9800"},
+				{Name: "Minor", Color: "#FFEB3B"},
+				{Name: "Needs info", Color: "#9E9E9E"},
+			},
+		},
+	},
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// CloneOptions задает, что переносится в копию доски. Карточки копируются только
+// вместе с колонками; метки карточек — если копируются метки, дорожки карточек —
+// если копируются дорожки, иначе карточки попадают в дорожку по умолчанию.
+// Без WorkspaceID копия создается в рабочем пространстве исходной доски.
+type CloneOptions struct {
+	Title       string
+	Description string
+	WorkspaceID uint
+	Columns     bool
+	Swimlanes   bool
+	Labels      bool
+	Cards       bool
+	Members     bool
+}
+
+// BoardTemplateService создает доски копированием других досок и из шаблонов.
+type BoardTemplateService struct {
+	templateRepo        repository.BoardTemplateRepository
+	boardRepo           repository.BoardRepository
+	columnRepo          repository.ColumnRepository
+	swimlaneRepo        repository.SwimlaneRepository
+	labelRepo           repository.LabelRepository
+	cardRepo            repository.CardRepository
+	cardLabelRepo       repository.CardLabelRepository
+	memberRepo          repository.BoardMemberRepository
+	workspaceMemberRepo repository.WorkspaceMemberRepository
+	userRepo            repository.UserRepository
+	transactor          repository.Transactor
+}
+
+func NewBoardTemplateService(repos *repository.Repositories) *BoardTemplateService {
+	return &BoardTemplateService{
+		templateRepo:        repos.Template,
+		boardRepo:           repos.Board,
+		columnRepo:          repos.Column,
+		swimlaneRepo:        repos.Swimlane,
+		labelRepo:           repos.Label,
+		cardRepo:            repos.Card,
+		cardLabelRepo:       repos.CardLabel,
+		memberRepo:          repos.Member,
+		workspaceMemberRepo: repos.WorkspaceMember,
+		userRepo:            repos.User,
+		transactor:          repos.Transactor,
+	}
+}
+
+// Clone создает доску владельца ownerID по образцу доски sourceID. Все записи копии
+// создаются в одной транзакции.
+func (s *BoardTemplateService) Clone(ctx context.Context, sourceID, ownerID uint, opts CloneOptions) (*models.Board, error) {
+	if opts.Cards && !opts.Columns {
+		return nil, models.NewValidationError("cards", "cards can only be copied together with columns")
+	}
+
+	source, err := s.boardRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.GetByID(ctx, ownerID); err != nil {
+		return nil, err
+	}
+
+	board := &models.Board{
+		Title:       opts.Title,
+		Description: opts.Description,
+		OwnerID:     ownerID,
+		WorkspaceID: opts.WorkspaceID,
+		WIPMode:     source.WIPMode,
+	}
+	if board.WorkspaceID == 0 {
+		board.WorkspaceID = source.WorkspaceID
+	}
+	if err := checkWorkspaceMember(ctx, s.workspaceMemberRepo, board.WorkspaceID, ownerID); err != nil {
+		return nil, err
+	}
+	if board.Title == "" {
+		board.Title = source.Title + " (copy)"
+	}
+	if board.Description == "" {
+		board.Description = source.Description
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.boardRepo.Create(ctx, board); err != nil {
+			return err
+		}
+
+		var columnIDs, swimlaneIDs, labelIDs map[uint]uint
+		var columns map[uint]*models.Column
+		if opts.Columns {
+			if columnIDs, columns, err = s.cloneColumns(ctx, sourceID, board.ID); err != nil {
+				return err
+			}
+		}
+		if opts.Swimlanes {
+			if swimlaneIDs, err = s.cloneSwimlanes(ctx, sourceID, board.ID); err != nil {
+				return err
+			}
+		}
+		if opts.Labels {
+			if labelIDs, err = s.cloneLabels(ctx, source, board); err != nil {
+				return err
+			}
+		}
+
+		var members map[uint]bool
+		if opts.Members {
+			if members, err = s.cloneMembers(ctx, source, board); err != nil {
+				return err
+			}
+		}
+
+		if opts.Cards {
+			return s.cloneCards(ctx, sourceID, board.OwnerID, columnIDs, columns, swimlaneIDs, labelIDs, members)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+// cloneColumns копирует колонки доски и возвращает соответствие старых ID новым и новые колонки по новым ID.
+func (s *BoardTemplateService) cloneColumns(ctx context.Context, sourceID, boardID uint) (map[uint]uint, map[uint]*models.Column, error) {
+	source, err := s.columnRepo.GetByBoardID(ctx, sourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := make([]models.Column, len(source))
+	for i, column := range source {
+		columns[i] = models.Column{Title: column.Title, BoardID: boardID, Kind: column.Kind, WIPLimit: column.WIPLimit}
+	}
+	if err := s.columnRepo.CreateOrdered(ctx, columns); err != nil {
+		return nil, nil, err
+	}
+
+	ids := make(map[uint]uint, len(columns))
+	byID := make(map[uint]*models.Column, len(columns))
+	for i := range columns {
+		ids[source[i].ID] = columns[i].ID
+		byID[columns[i].ID] = &columns[i]
+	}
+	return ids, byID, nil
+}
+
+func (s *BoardTemplateService) cloneSwimlanes(ctx context.Context, sourceID, boardID uint) (map[uint]uint, error) {
+	source, err := s.swimlaneRepo.GetByBoardID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	swimlanes := make([]models.Swimlane, len(source))
+	for i, swimlane := range source {
+		swimlanes[i] = models.Swimlane{Title: swimlane.Title, BoardID: boardID}
+	}
+	if err := s.swimlaneRepo.CreateOrdered(ctx, swimlanes); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[uint]uint, len(swimlanes))
+	for i := range swimlanes {
+		ids[source[i].ID] = swimlanes[i].ID
+	}
+	return ids, nil
+}
+
+// cloneLabels копирует метки доски. Общие метки других досок пространства копируются, только если
+// копия создается в другом рабочем пространстве, иначе карточки копии ссылаются на них же.
+func (s *BoardTemplateService) cloneLabels(ctx context.Context, sourceBoard, board *models.Board) (map[uint]uint, error) {
+	source, err := s.labelRepo.GetByBoardID(ctx, sourceBoard.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[uint]uint, len(source))
+	for _, label := range source {
+		if label.BoardID != sourceBoard.ID && board.WorkspaceID == sourceBoard.WorkspaceID {
+			ids[label.ID] = label.ID
+			continue
+		}
+		copied := &models.Label{Name: label.Name, Color: label.Color, BoardID: board.ID}
+		if err := s.labelRepo.Create(ctx, copied); err != nil {
+			return nil, err
+		}
+		ids[label.ID] = copied.ID
+	}
+	return ids, nil
+}
+
+// cloneMembers переносит участников доски с их ролями. Владелец исходной доски, если это
+// не владелец копии, становится администратором копии. Возвращает всех, у кого есть доступ к копии.
+func (s *BoardTemplateService) cloneMembers(ctx context.Context, source, board *models.Board) (map[uint]bool, error) {
+	members, err := s.memberRepo.GetByBoardID(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	access := map[uint]bool{board.OwnerID: true}
+	add := func(userID uint, role string) error {
+		if access[userID] {
+			return nil
+		}
+		access[userID] = true
+		return s.memberRepo.Add(ctx, &models.BoardMember{BoardID: board.ID, UserID: userID, Role: role})
+	}
+
+	if err := add(source.OwnerID, models.BoardRoleAdmin); err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if err := add(member.UserID, member.Role); err != nil {
+			return nil, err
+		}
+	}
+	return access, nil
+}
+
+// cloneCards копирует карточки в новые колонки с сохранением порядка. Исполнитель сохраняется,
+// только если у него есть доступ к копии; отметки начала и завершения ставятся заново
+// по видам колонок.
+func (s *BoardTemplateService) cloneCards(
+	ctx context.Context,
+	sourceID, ownerID uint,
+	columnIDs map[uint]uint,
+	columns map[uint]*models.Column,
+	swimlaneIDs, labelIDs map[uint]uint,
+	members map[uint]bool,
+) error {
+	source, err := s.cardRepo.GetByBoardID(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cards := make([]models.Card, 0, len(source))
+	sourceIDs := make([]uint, 0, len(source))
+	for _, card := range source {
+		columnID, ok := columnIDs[card.ColumnID]
+		if !ok {
+			continue
+		}
+
+		copied := models.Card{
+			Title:       card.Title,
+			Description: card.Description,
+			ColumnID:    columnID,
+			DueDate:     card.DueDate,
+		}
+		if card.SwimlaneID != nil {
+			if swimlaneID, ok := swimlaneIDs[*card.SwimlaneID]; ok {
+				copied.SwimlaneID = &swimlaneID
+			}
+		}
+		if card.AssignedTo != nil && (*card.AssignedTo == ownerID || members[*card.AssignedTo]) {
+			copied.AssignedTo = card.AssignedTo
+		}
+		trackProgress(&copied, columns[columnID], now)
+
+		cards = append(cards, copied)
+		sourceIDs = append(sourceIDs, card.ID)
+	}
+	if err := s.cardRepo.CreateOrdered(ctx, cards); err != nil {
+		return err
+	}
+
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	labels, err := s.cardLabelRepo.GetLabelsByCardIDs(ctx, sourceIDs)
+	if err != nil {
+		return err
+	}
+	var links []models.CardLabel
+	for i, cardID := range sourceIDs {
+		for _, label := range labels[cardID] {
+			if labelID, ok := labelIDs[label.ID]; ok {
+				links = append(links, models.CardLabel{CardID: cards[i].ID, LabelID: labelID})
+			}
+		}
+	}
+	return s.cardLabelRepo.AddMany(ctx, links)
+}
+
+// Gallery возвращает встроенные шаблоны и шаблоны, сохраненные пользователем.
+func (s *BoardTemplateService) Gallery(ctx context.Context, ownerID uint) ([]models.BoardTemplate, error) {
+	saved, err := s.templateRepo.GetByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]models.BoardTemplate, 0, len(builtinTemplates)+len(saved))
+	for _, template := range builtinTemplates {
+		template.BuiltIn = true
+		templates = append(templates, template)
+	}
+	return append(templates, saved...), nil
+}
+
+// GetTemplate возвращает шаблон по ссылке: числовому ID сохраненного шаблона или ключу встроенного.
+// Чужие сохраненные шаблоны считаются ненайденными.
+func (s *BoardTemplateService) GetTemplate(ctx context.Context, ownerID uint, ref string) (*models.BoardTemplate, error) {
+	id, err := strconv.ParseUint(ref, 10, 32)
+	if err != nil {
+		for _, template := range builtinTemplates {
+			if template.Key == ref {
+				template.BuiltIn = true
+				return &template, nil
+			}
+		}
+		return nil, models.ErrTemplateNotFound
+	}
+
+	template, err := s.templateRepo.GetByID(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	if template.OwnerID != ownerID {
+		return nil, models.ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+// SaveTemplate сохраняет колонки, дорожки и метки доски boardID как шаблон пользователя ownerID.
+func (s *BoardTemplateService) SaveTemplate(ctx context.Context, boardID, ownerID uint, name, description string) (*models.BoardTemplate, error) {
+	board, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = board.Title
+	}
+
+	columns, err := s.columnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	swimlanes, err := s.swimlaneRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.labelRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := models.BoardLayout{Columns: make([]models.TemplateColumn, 0, len(columns))}
+	for _, column := range columns {
+		layout.Columns = append(layout.Columns, models.TemplateColumn{Title: column.Title, Kind: column.Kind, WIPLimit: column.WIPLimit})
+	}
+	for _, swimlane := range swimlanes {
+		layout.Swimlanes = append(layout.Swimlanes, models.TemplateSwimlane{Title: swimlane.Title})
+	}
+	for _, label := range labels {
+		layout.Labels = append(layout.Labels, models.TemplateLabel{Name: label.Name, Color: label.Color})
+	}
+
+	template := &models.BoardTemplate{
+		Name:        name,
+		Description: description,
+		Layout:      layout,
+		OwnerID:     ownerID,
+	}
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteTemplate удаляет сохраненный шаблон пользователя. Встроенные шаблоны удалить нельзя.
+func (s *BoardTemplateService) DeleteTemplate(ctx context.Context, ownerID uint, ref string) error {
+	template, err := s.GetTemplate(ctx, ownerID, ref)
+	if err != nil {
+		return err
+	}
+	if template.BuiltIn {
+		return models.NewValidationError("template_id", "built-in templates cannot be deleted")
+	}
+	return s.templateRepo.Delete(ctx, template.ID)
+}
+
+// CreateFromTemplate создает доску владельца ownerID с колонками, дорожками и метками шаблона
+// в рабочем пространстве board.WorkspaceID.
+func (s *BoardTemplateService) CreateFromTemplate(ctx context.Context, ownerID uint, ref string, board *models.Board) error {
+	template, err := s.GetTemplate(ctx, ownerID, ref)
+	if err != nil {
+		return err
+	}
+	if _, err := s.userRepo.GetByID(ctx, ownerID); err != nil {
+		return err
+	}
+	if err := checkWorkspaceMember(ctx, s.workspaceMemberRepo, board.WorkspaceID, ownerID); err != nil {
+		return err
+	}
+
+	board.OwnerID = ownerID
+	if board.Title == "" {
+		board.Title = template.Name
+	}
+	if board.WIPMode == "" {
+		board.WIPMode = models.WIPModeWarn
+	}
+	if err := validateWIPMode(board.WIPMode); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.boardRepo.Create(ctx, board); err != nil {
+			return err
+		}
+
+		columns := make([]models.Column, len(template.Layout.Columns))
+		for i, column := range template.Layout.Columns {
+			columns[i] = models.Column{Title: column.Title, BoardID: board.ID, Kind: column.Kind, WIPLimit: column.WIPLimit}
+			if err := normalizeKind(&columns[i], models.ColumnKindTodo); err != nil {
+				return err
+			}
+		}
+		if err := s.columnRepo.CreateOrdered(ctx, columns); err != nil {
+			return err
+		}
+
+		swimlanes := make([]models.Swimlane, len(template.Layout.Swimlanes))
+		for i, swimlane := range template.Layout.Swimlanes {
+			swimlanes[i] = models.Swimlane{Title: swimlane.Title, BoardID: board.ID}
+		}
+		if err := s.swimlaneRepo.CreateOrdered(ctx, swimlanes); err != nil {
+			return err
+		}
+
+		for _, label := range template.Layout.Labels {
+			if err := s.labelRepo.Create(ctx, &models.Label{Name: label.Name, Color: label.Color, BoardID: board.ID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -2,21 +2,71 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"log/slog"
+	"time"
 
 	"github.com/octaview/kanban-octaview/internal/models"
 	"github.com/octaview/kanban-octaview/internal/repository"
 )
 
+const (
+	defaultBoardPageSize = 50
+	maxBoardPageSize     = 200
+	// recentBoardsKept — сколько недавно открытых досок хранится для каждого пользователя.
+	recentBoardsKept = 20
+)
+
+// BoardListQuery задает страницу списка досок пользователя. Cursor — значение NextCursor
+// предыдущей страницы, он действителен только с той же сортировкой.
+type BoardListQuery struct {
+	// WorkspaceID, если задан, ограничивает список досками одного рабочего пространства.
+	WorkspaceID uint
+	Sort        string
+	Archived    *bool
+	Starred     bool
+	Cursor      string
+	Limit       int
+}
+
+// BoardPage — страница списка досок. NextCursor пуст на последней странице.
+type BoardPage struct {
+	Boards     []models.Board
+	NextCursor string
+}
+
 type BoardService struct {
-	repo     repository.BoardRepository
-	userRepo repository.UserRepository
+	repo                repository.BoardRepository
+	starRepo            repository.BoardStarRepository
+	viewRepo            repository.BoardViewRepository
+	memberRepo          repository.BoardMemberRepository
+	workspaceMemberRepo repository.WorkspaceMemberRepository
+	labelRepo           repository.LabelRepository
+	userRepo            repository.UserRepository
+	transactor          repository.Transactor
 }
 
-func NewBoardService(repo repository.BoardRepository, userRepo repository.UserRepository) *BoardService {
+func NewBoardService(
+	repo repository.BoardRepository,
+	starRepo repository.BoardStarRepository,
+	viewRepo repository.BoardViewRepository,
+	memberRepo repository.BoardMemberRepository,
+	workspaceMemberRepo repository.WorkspaceMemberRepository,
+	labelRepo repository.LabelRepository,
+	userRepo repository.UserRepository,
+	transactor repository.Transactor,
+) *BoardService {
 	return &BoardService{
-		repo:     repo,
-		userRepo: userRepo,
+		repo:                repo,
+		starRepo:            starRepo,
+		viewRepo:            viewRepo,
+		memberRepo:          memberRepo,
+		workspaceMemberRepo: workspaceMemberRepo,
+		labelRepo:           labelRepo,
+		userRepo:            userRepo,
+		transactor:          transactor,
 	}
 }
 
@@ -28,9 +78,20 @@ func (s *BoardService) Create(ctx context.Context, board *models.Board) error {
 		}
 		return err
 	}
-	
+
 	board.OwnerID = owner.ID
 
+	if err := checkWorkspaceMember(ctx, s.workspaceMemberRepo, board.WorkspaceID, owner.ID); err != nil {
+		return err
+	}
+
+	if board.WIPMode == "" {
+		board.WIPMode = models.WIPModeWarn
+	}
+	if err := validateWIPMode(board.WIPMode); err != nil {
+		return err
+	}
+
 	return s.repo.Create(ctx, board)
 }
 
@@ -55,6 +116,13 @@ func (s *BoardService) Update(ctx context.Context, board *models.Board) error {
 	if err != nil {
 		return err
 	}
+	if existingBoard.Archived() {
+		return models.ErrBoardArchived
+	}
+	if err := checkVersion(ctx, existingBoard.Version); err != nil {
+		return err
+	}
+	board.Version = existingBoard.Version
 
 	if board.OwnerID == 0 {
 		board.OwnerID = existingBoard.OwnerID
@@ -69,14 +137,236 @@ func (s *BoardService) Update(ctx context.Context, board *models.Board) error {
 		board.OwnerID = owner.ID
 	}
 
-	return s.repo.Update(ctx, board)
+	if board.WIPMode == "" {
+		board.WIPMode = existingBoard.WIPMode
+	}
+	if err := validateWIPMode(board.WIPMode); err != nil {
+		return err
+	}
+
+	if board.WorkspaceID == 0 || board.WorkspaceID == existingBoard.WorkspaceID {
+		board.WorkspaceID = existingBoard.WorkspaceID
+		return s.repo.Update(ctx, board)
+	}
+
+	// Перенос в другое рабочее пространство: переносящий должен в нем состоять, а общие
+	// метки старого пространства перестают действовать на доске.
+	actorID, ok := ActorFromContext(ctx)
+	if !ok {
+		actorID = board.OwnerID
+	}
+	if err := checkWorkspaceMember(ctx, s.workspaceMemberRepo, board.WorkspaceID, actorID); err != nil {
+		return err
+	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.labelRepo.DetachFromWorkspace(ctx, board.ID); err != nil {
+			return err
+		}
+		return s.repo.Update(ctx, board)
+	})
 }
 
 func (s *BoardService) Delete(ctx context.Context, id uint) error {
-	_, err := s.repo.GetByID(ctx, id)
+	board, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(ctx, board.Version); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id, board.Version)
+}
+
+// Restore восстанавливает удаленную доску вместе с колонками, дорожками, карточками
+// и комментариями, удаленными вместе с ней. Доступно владельцу и администраторам доски.
+func (s *BoardService) Restore(ctx context.Context, id uint) (*models.Board, error) {
+	if _, err := s.deletableBoard(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// Purge безвозвратно удаляет доску со всем содержимым, в том числе уже удаленную.
+// Доступно владельцу и администраторам доски.
+func (s *BoardService) Purge(ctx context.Context, id uint) error {
+	if _, err := s.deletableBoard(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Purge(ctx, id)
+}
+
+// deletableBoard возвращает доску (в том числе удаленную), если текущий пользователь
+// ее владелец или администратор. Проверка ролей здесь, а не в обработчике: у удаленной
+// доски BoardMemberService не находит владельца.
+func (s *BoardService) deletableBoard(ctx context.Context, id uint) (*models.Board, error) {
+	board, err := s.repo.GetByIDUnscoped(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, ok := ActorFromContext(ctx)
+	if !ok {
+		return nil, models.ErrInsufficientAccess
+	}
+	if board.OwnerID == userID {
+		return board, nil
+	}
 
-	return s.repo.Delete(ctx, id)
-}
\ No newline at end of file
+	member, err := s.memberRepo.Get(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrMemberNotFound) {
+			return nil, models.ErrInsufficientAccess
+		}
+		return nil, err
+	}
+	if member.Role != models.BoardRoleAdmin {
+		return nil, models.ErrInsufficientAccess
+	}
+	return board, nil
+}
+
+// List возвращает страницу досок, которыми пользователь владеет или в которых состоит.
+func (s *BoardService) List(ctx context.Context, userID uint, query BoardListQuery) (*BoardPage, error) {
+	switch query.Sort {
+	case "":
+		query.Sort = repository.BoardSortName
+	case repository.BoardSortName, repository.BoardSortUpdated, repository.BoardSortActivity:
+	default:
+		return nil, models.NewValidationError("sort", "sort must be name, updated_at or activity")
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultBoardPageSize
+	}
+	query.Limit = min(query.Limit, maxBoardPageSize)
+
+	if query.WorkspaceID != 0 {
+		if err := checkWorkspaceMember(ctx, s.workspaceMemberRepo, query.WorkspaceID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	filter := repository.BoardFilter{
+		UserID:      userID,
+		WorkspaceID: query.WorkspaceID,
+		Archived:    query.Archived,
+		Starred:     query.Starred,
+		Sort:        query.Sort,
+		Limit:       query.Limit + 1,
+	}
+	if query.Cursor != "" {
+		cursor, err := decodeBoardCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = cursor
+	}
+
+	boards, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &BoardPage{Boards: boards}
+	if len(boards) > query.Limit {
+		page.Boards = boards[:query.Limit]
+		page.NextCursor = encodeBoardCursor(&page.Boards[query.Limit-1], query.Sort)
+	}
+	return page, nil
+}
+
+// GetRecent возвращает доски, которые пользователь открывал последними.
+func (s *BoardService) GetRecent(ctx context.Context, userID uint, limit int) ([]models.Board, error) {
+	if limit <= 0 || limit > recentBoardsKept {
+		limit = recentBoardsKept
+	}
+	return s.repo.GetRecent(ctx, userID, limit)
+}
+
+// RecordView запоминает, что пользователь открыл доску. Ошибка записи не мешает
+// просмотру, поэтому только логируется.
+func (s *BoardService) RecordView(ctx context.Context, boardID, userID uint) {
+	if err := s.viewRepo.Record(ctx, boardID, userID, time.Now(), recentBoardsKept); err != nil {
+		slog.ErrorContext(ctx, "failed to record board view",
+			slog.Uint64("board_id", uint64(boardID)), slog.Any("error", err))
+	}
+}
+
+// Archive переносит доску в архив. Архивная доска скрыта из списков по умолчанию,
+// а ее содержимое нельзя изменять до восстановления.
+func (s *BoardService) Archive(ctx context.Context, id uint) (*models.Board, error) {
+	return s.setArchived(ctx, id, true)
+}
+
+// Unarchive возвращает доску из архива.
+func (s *BoardService) Unarchive(ctx context.Context, id uint) (*models.Board, error) {
+	return s.setArchived(ctx, id, false)
+}
+
+func (s *BoardService) setArchived(ctx context.Context, id uint, archived bool) (*models.Board, error) {
+	board, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(ctx, board.Version); err != nil {
+		return nil, err
+	}
+	if board.Archived() == archived {
+		return board, nil
+	}
+
+	board.ArchivedAt = nil
+	if archived {
+		now := time.Now()
+		board.ArchivedAt = &now
+	}
+	if err := s.repo.Update(ctx, board); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+func (s *BoardService) Star(ctx context.Context, boardID, userID uint) error {
+	return s.starRepo.Star(ctx, boardID, userID)
+}
+
+func (s *BoardService) Unstar(ctx context.Context, boardID, userID uint) error {
+	return s.starRepo.Unstar(ctx, boardID, userID)
+}
+
+func encodeBoardCursor(board *models.Board, sort string) string {
+	cursor := repository.BoardCursor{ID: board.ID}
+	switch sort {
+	case repository.BoardSortUpdated:
+		cursor.At = board.UpdatedAt
+	case repository.BoardSortActivity:
+		cursor.At = board.LastActivityAt
+	default:
+		cursor.Title = board.Title
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeBoardCursor(value string) (*repository.BoardCursor, error) {
+	var cursor repository.BoardCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err == nil {
+		err = json.Unmarshal(data, &cursor)
+	}
+	if err != nil || cursor.ID == 0 {
+		return nil, models.NewValidationError("cursor", "invalid cursor")
+	}
+	return &cursor, nil
+}
+func validateWIPMode(mode string) error {
+	if mode != models.WIPModeWarn && mode != models.WIPModeBlock {
+		return models.NewValidationError("wip_mode", "WIP mode must be \"warn\" or \"block\"")
+	}
+	return nil
+}
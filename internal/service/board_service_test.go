@@ -0,0 +1,41 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+func TestBoardCursor(t *testing.T) {
+	updated := time.Date(2026, 5, 1, 12, 30, 0, 0, time.UTC)
+	activity := updated.Add(time.Hour)
+	board := &models.Board{ID: 42, Title: "Roadmap", UpdatedAt: updated, LastActivityAt: activity}
+
+	tests := []struct {
+		sort string
+		want repository.BoardCursor
+	}{
+		{sort: repository.BoardSortUpdated, want: repository.BoardCursor{ID: 42, At: updated}},
+		{sort: repository.BoardSortActivity, want: repository.BoardCursor{ID: 42, At: activity}},
+		{sort: "", want: repository.BoardCursor{ID: 42, Title: "Roadmap"}},
+	}
+	for _, tt := range tests {
+		cursor, err := decodeBoardCursor(encodeBoardCursor(board, tt.sort))
+		if err != nil {
+			t.Fatalf("sort %q: decodeBoardCursor: %v", tt.sort, err)
+		}
+		if cursor.ID != tt.want.ID || cursor.Title != tt.want.Title || !cursor.At.Equal(tt.want.At) {
+			t.Errorf("sort %q: cursor = %+v, want %+v", tt.sort, *cursor, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "not base64!", "bnVsbA", "e30"} {
+		var validationErr *models.ValidationError
+		if _, err := decodeBoardCursor(value); !errors.As(err, &validationErr) {
+			t.Errorf("decodeBoardCursor(%q) error = %v, want a validation error", value, err)
+		}
+	}
+}
@@ -17,11 +17,14 @@ type CardLabelRepository interface {
 }
 
 type CardLabelService struct {
-	cardLabelRepo CardLabelRepository
-	cardRepo      repository.CardRepository
-	labelRepo     repository.LabelRepository
-	boardRepo     repository.BoardRepository
-	columnRepo    repository.ColumnRepository
+	cardLabelRepo  CardLabelRepository
+	cardRepo       repository.CardRepository
+	labelRepo      repository.LabelRepository
+	boardRepo      repository.BoardRepository
+	columnRepo     repository.ColumnRepository
+	watcherService CardWatcherServiceInterface
+	events         EventOutbox
+	visibility     *CardVisibility
 }
 
 func NewCardLabelService(
@@ -30,18 +33,24 @@ func NewCardLabelService(
 	labelRepo repository.LabelRepository,
 	boardRepo repository.BoardRepository,
 	columnRepo repository.ColumnRepository,
+	watcherService CardWatcherServiceInterface,
+	events EventOutbox,
+	visibility *CardVisibility,
 ) *CardLabelService {
 	return &CardLabelService{
-		cardLabelRepo: cardLabelRepo,
-		cardRepo:      cardRepo,
-		labelRepo:     labelRepo,
-		boardRepo:     boardRepo,
-		columnRepo:    columnRepo,
+		cardLabelRepo:  cardLabelRepo,
+		cardRepo:       cardRepo,
+		labelRepo:      labelRepo,
+		boardRepo:      boardRepo,
+		columnRepo:     columnRepo,
+		watcherService: watcherService,
+		events:         events,
+		visibility:     visibility,
 	}
 }
 
 func (s *CardLabelService) AddLabelToCard(ctx context.Context, cardID uint, labelID uint) error {
-	card, err := s.cardRepo.GetByID(ctx, cardID)
+	card, err := s.getCard(ctx, cardID)
 	if err != nil {
 		if errors.Is(err, models.ErrCardNotFound) {
 			return models.ErrCardNotFound
@@ -62,16 +71,26 @@ func (s *CardLabelService) AddLabelToCard(ctx context.Context, cardID uint, labe
 		return err
 	}
 
-	if label.BoardID != column.BoardID {
-		return fmt.Errorf("label %d belongs to board %d but card %d belongs to board %d", 
-			labelID, label.BoardID, cardID, column.BoardID)
+	if err := s.checkLabelBoard(ctx, label, column.BoardID); err != nil {
+		return err
 	}
 
-	return s.cardLabelRepo.AddLabelToCard(ctx, cardID, labelID)
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.cardLabelRepo.AddLabelToCard(ctx, cardID, labelID); err != nil {
+			return err
+		}
+		return s.recordLabelsChanged(ctx, card, column.BoardID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notifyLabelsChanged(ctx, card, column.BoardID, fmt.Sprintf("Label %q was added to card %q", label.Name, card.Title))
+	return nil
 }
 
 func (s *CardLabelService) RemoveLabelFromCard(ctx context.Context, cardID uint, labelID uint) error {
-	_, err := s.cardRepo.GetByID(ctx, cardID)
+	card, err := s.getCard(ctx, cardID)
 	if err != nil {
 		if errors.Is(err, models.ErrCardNotFound) {
 			return models.ErrCardNotFound
@@ -79,7 +98,7 @@ func (s *CardLabelService) RemoveLabelFromCard(ctx context.Context, cardID uint,
 		return err
 	}
 
-	_, err = s.labelRepo.GetByID(ctx, labelID)
+	label, err := s.labelRepo.GetByID(ctx, labelID)
 	if err != nil {
 		if errors.Is(err, models.ErrLabelNotFound) {
 			return models.ErrLabelNotFound
@@ -87,11 +106,22 @@ func (s *CardLabelService) RemoveLabelFromCard(ctx context.Context, cardID uint,
 		return err
 	}
 
-	return s.cardLabelRepo.RemoveLabelFromCard(ctx, cardID, labelID)
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.cardLabelRepo.RemoveLabelFromCard(ctx, cardID, labelID); err != nil {
+			return err
+		}
+		return s.recordLabelsChanged(ctx, card, label.BoardID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notifyLabelsChanged(ctx, card, label.BoardID, fmt.Sprintf("Label %q was removed from card %q", label.Name, card.Title))
+	return nil
 }
 
 func (s *CardLabelService) GetLabelsByCardID(ctx context.Context, cardID uint) ([]models.Label, error) {
-	_, err := s.cardRepo.GetByID(ctx, cardID)
+	_, err := s.getCard(ctx, cardID)
 	if err != nil {
 		if errors.Is(err, models.ErrCardNotFound) {
 			return nil, models.ErrCardNotFound
@@ -111,7 +141,19 @@ func (s *CardLabelService) GetCardsByLabelID(ctx context.Context, labelID uint)
 		return nil, err
 	}
 
-	return s.cardLabelRepo.GetCardsByLabelID(ctx, labelID)
+	cards, err := s.cardLabelRepo.GetCardsByLabelID(ctx, labelID)
+	if err != nil {
+		return nil, err
+	}
+	return s.visibility.Filter(ctx, cards)
+}
+
+// getCard загружает карточку, если она видна пользователю запроса.
+func (s *CardLabelService) getCard(ctx context.Context, id uint) (*models.Card, error) {
+	if err := s.visibility.Check(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.cardRepo.GetByID(ctx, id)
 }
 
 func (s *CardLabelService) getColumnForCard(ctx context.Context, card *models.Card) (*models.Column, error) {
@@ -140,7 +182,7 @@ func (s *CardLabelService) getColumnByID(ctx context.Context, columnID uint) (*m
 }
 
 func (s *CardLabelService) BatchAddLabelsToCard(ctx context.Context, cardID uint, labelIDs []uint) error {
-	card, err := s.cardRepo.GetByID(ctx, cardID)
+	card, err := s.getCard(ctx, cardID)
 	if err != nil {
 		if errors.Is(err, models.ErrCardNotFound) {
 			return models.ErrCardNotFound
@@ -162,23 +204,29 @@ func (s *CardLabelService) BatchAddLabelsToCard(ctx context.Context, cardID uint
 			return err
 		}
 		
-		if label.BoardID != column.BoardID {
-			return fmt.Errorf("label %d belongs to board %d but card %d belongs to board %d", 
-				labelID, label.BoardID, cardID, column.BoardID)
+		if err := s.checkLabelBoard(ctx, label, column.BoardID); err != nil {
+			return err
 		}
 	}
 	
-	for _, labelID := range labelIDs {
-		if err := s.cardLabelRepo.AddLabelToCard(ctx, cardID, labelID); err != nil {
-			return err
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		for _, labelID := range labelIDs {
+			if err := s.cardLabelRepo.AddLabelToCard(ctx, cardID, labelID); err != nil {
+				return err
+			}
 		}
+		return s.recordLabelsChanged(ctx, card, column.BoardID)
+	})
+	if err != nil {
+		return err
 	}
 	
+	s.notifyLabelsChanged(ctx, card, column.BoardID, fmt.Sprintf("Labels of card %q were changed", card.Title))
 	return nil
 }
 
 func (s *CardLabelService) BatchRemoveLabelsFromCard(ctx context.Context, cardID uint, labelIDs []uint) error {
-	_, err := s.cardRepo.GetByID(ctx, cardID)
+	card, err := s.getCard(ctx, cardID)
 	if err != nil {
 		if errors.Is(err, models.ErrCardNotFound) {
 			return models.ErrCardNotFound
@@ -196,12 +244,19 @@ func (s *CardLabelService) BatchRemoveLabelsFromCard(ctx context.Context, cardID
 		}
 	}
 	
-	for _, labelID := range labelIDs {
-		if err := s.cardLabelRepo.RemoveLabelFromCard(ctx, cardID, labelID); err != nil {
-			return err
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		for _, labelID := range labelIDs {
+			if err := s.cardLabelRepo.RemoveLabelFromCard(ctx, cardID, labelID); err != nil {
+				return err
+			}
 		}
+		return s.recordLabelsChanged(ctx, card, 0)
+	})
+	if err != nil {
+		return err
 	}
 	
+	s.notifyLabelsChanged(ctx, card, 0, fmt.Sprintf("Labels of card %q were changed", card.Title))
 	return nil
 }
 
@@ -224,7 +279,7 @@ func (s *CardLabelService) GetLabelCountByCardID(ctx context.Context, cardID uin
 }
 
 func (s *CardLabelService) RemoveAllLabelsFromCard(ctx context.Context, cardID uint) error {
-	_, err := s.cardRepo.GetByID(ctx, cardID)
+	card, err := s.getCard(ctx, cardID)
 	if err != nil {
 		if errors.Is(err, models.ErrCardNotFound) {
 			return models.ErrCardNotFound
@@ -237,11 +292,74 @@ func (s *CardLabelService) RemoveAllLabelsFromCard(ctx context.Context, cardID u
 		return err
 	}
 	
-	for _, label := range labels {
-		if err := s.cardLabelRepo.RemoveLabelFromCard(ctx, cardID, label.ID); err != nil {
-			return err
+	if len(labels) == 0 {
+		return nil
+	}
+
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		for _, label := range labels {
+			if err := s.cardLabelRepo.RemoveLabelFromCard(ctx, cardID, label.ID); err != nil {
+				return err
+			}
 		}
+		return s.recordLabelsChanged(ctx, card, 0)
+	})
+	if err != nil {
+		return err
 	}
-	
+
+	s.notifyLabelsChanged(ctx, card, 0, fmt.Sprintf("All labels were removed from card %q", card.Title))
 	return nil
-}
\ No newline at end of file
+}
+
+func (s *CardLabelService) notifyLabelsChanged(ctx context.Context, card *models.Card, boardID uint, message string) {
+	s.watcherService.NotifyCardChange(ctx, CardChange{
+		Type:    NotificationLabelsChanged,
+		CardID:  card.ID,
+		BoardID: boardID,
+		Message: message,
+	})
+}
+
+// recordLabelsChanged записывает в outbox событие с итоговым набором меток карточки.
+func (s *CardLabelService) recordLabelsChanged(ctx context.Context, card *models.Card, boardID uint) error {
+	if boardID == 0 {
+		column, err := s.getColumnForCard(ctx, card)
+		if err != nil {
+			return err
+		}
+		boardID = column.BoardID
+	}
+
+	labels, err := s.cardLabelRepo.GetLabelsByCardID(ctx, card.ID)
+	if err != nil {
+		return err
+	}
+
+	labelIDs := make([]uint, len(labels))
+	for i, label := range labels {
+		labelIDs[i] = label.ID
+	}
+	return s.events.Record(ctx, newBoardEvent(ctx, EventCardLabelsChanged, boardID, CardLabelsPayload{
+		CardID:   card.ID,
+		LabelIDs: labelIDs,
+	}))
+}
+
+// checkLabelBoard проверяет, что метку можно назначить карточке доски boardID: это метка
+// самой доски или общая метка ее рабочего пространства.
+func (s *CardLabelService) checkLabelBoard(ctx context.Context, label *models.Label, boardID uint) error {
+	if label.BoardID == boardID {
+		return nil
+	}
+	if label.WorkspaceID != nil {
+		board, err := s.boardRepo.GetByID(ctx, boardID)
+		if err != nil {
+			return err
+		}
+		if board.WorkspaceID == *label.WorkspaceID {
+			return nil
+		}
+	}
+	return fmt.Errorf("label %d belongs to board %d and is not shared with board %d", label.ID, label.BoardID, boardID)
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+// CardVisibility ограничивает карточки, которые видит пользователь запроса. Гость доски видит
+// только открытые ему карточки; остальные пользователи и запросы без пользователя (фоновые
+// задачи) видят все карточки.
+type CardVisibility struct {
+	memberRepo repository.BoardMemberRepository
+	guestRepo  repository.CardGuestRepository
+}
+
+func NewCardVisibility(memberRepo repository.BoardMemberRepository, guestRepo repository.CardGuestRepository) *CardVisibility {
+	return &CardVisibility{
+		memberRepo: memberRepo,
+		guestRepo:  guestRepo,
+	}
+}
+
+// Filter оставляет в cards только карточки, видимые пользователю запроса. Карточки могут
+// относиться к разным доскам.
+func (v *CardVisibility) Filter(ctx context.Context, cards []models.Card) ([]models.Card, error) {
+	actorID, ok := ActorFromContext(ctx)
+	if !ok || len(cards) == 0 {
+		return cards, nil
+	}
+
+	ids := make([]uint, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	hidden, err := v.guestRepo.GetHiddenCardIDs(ctx, actorID, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(hidden) == 0 {
+		return cards, nil
+	}
+
+	return slices.DeleteFunc(cards, func(card models.Card) bool {
+		return slices.Contains(hidden, card.ID)
+	}), nil
+}
+
+// Check возвращает ErrCardNotFound, если карточка скрыта от пользователя запроса.
+func (v *CardVisibility) Check(ctx context.Context, cardID uint) error {
+	actorID, ok := ActorFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	hidden, err := v.guestRepo.GetHiddenCardIDs(ctx, actorID, []uint{cardID})
+	if err != nil {
+		return err
+	}
+	if len(hidden) > 0 {
+		return models.ErrCardNotFound
+	}
+	return nil
+}
+
+// HiddenFrom возвращает тех пользователей из userIDs, от которых карточка скрыта.
+func (v *CardVisibility) HiddenFrom(ctx context.Context, cardID uint, userIDs []uint) ([]uint, error) {
+	return v.guestRepo.GetHiddenUserIDs(ctx, cardID, userIDs)
+}
+
+// CheckFullAccess возвращает ErrInsufficientAccess, если пользователь запроса — гость доски.
+// Гости не создают карточки и не меняют их порядок, потому что не видят колонку целиком.
+func (v *CardVisibility) CheckFullAccess(ctx context.Context, boardID uint) error {
+	guest, err := v.isGuest(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	if guest {
+		return models.ErrInsufficientAccess
+	}
+	return nil
+}
+
+// Key возвращает метку набора карточек доски, видимых пользователю запроса, для вычисления
+// ETag. Для всех, кроме гостей, метка пустая.
+func (v *CardVisibility) Key(ctx context.Context, boardID uint) (string, error) {
+	guest, err := v.isGuest(ctx, boardID)
+	if err != nil || !guest {
+		return "", err
+	}
+
+	actorID, _ := ActorFromContext(ctx)
+	ids, err := v.guestRepo.GetCardIDs(ctx, boardID, actorID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("guest:%d:%v", actorID, ids), nil
+}
+
+// CountCards пересчитывает CardCount и OverLimit колонок доски по карточкам, видимым гостю.
+// Иначе по числу карточек гость узнал бы о закрытых от него. Для остальных пользователей
+// колонки не меняются.
+func (v *CardVisibility) CountCards(ctx context.Context, boardID uint, columns []models.Column) error {
+	guest, err := v.isGuest(ctx, boardID)
+	if err != nil || !guest {
+		return err
+	}
+
+	actorID, _ := ActorFromContext(ctx)
+	counts, err := v.guestRepo.CountByColumn(ctx, boardID, actorID)
+	if err != nil {
+		return err
+	}
+	for i := range columns {
+		columns[i].CardCount = counts[columns[i].ID]
+		columns[i].OverLimit = columns[i].WIPLimit != nil && columns[i].CardCount > *columns[i].WIPLimit
+	}
+	return nil
+}
+
+func (v *CardVisibility) isGuest(ctx context.Context, boardID uint) (bool, error) {
+	actorID, ok := ActorFromContext(ctx)
+	if !ok {
+		return false, nil
+	}
+
+	member, err := v.memberRepo.Get(ctx, boardID, actorID)
+	if err != nil {
+		if errors.Is(err, models.ErrMemberNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return member.Role == models.BoardRoleGuest, nil
+}
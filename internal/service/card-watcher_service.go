@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+// CardChange описывает изменение карточки, о котором нужно сообщить наблюдателям.
+type CardChange struct {
+	Type      string
+	CardID    uint
+	BoardID   uint
+	CommentID uint
+	Message   string
+	// Exclude — пользователи, которых уже уведомили напрямую.
+	Exclude []uint
+}
+
+type CardWatcherService struct {
+	watcherRepo repository.CardWatcherRepository
+	cardRepo    repository.CardRepository
+	columnRepo  repository.ColumnRepository
+	notifier    Notifier
+	visibility  *CardVisibility
+}
+
+func NewCardWatcherService(
+	watcherRepo repository.CardWatcherRepository,
+	cardRepo repository.CardRepository,
+	columnRepo repository.ColumnRepository,
+	notifier Notifier,
+	visibility *CardVisibility,
+) *CardWatcherService {
+	return &CardWatcherService{
+		watcherRepo: watcherRepo,
+		cardRepo:    cardRepo,
+		columnRepo:  columnRepo,
+		notifier:    notifier,
+		visibility:  visibility,
+	}
+}
+
+func (s *CardWatcherService) Watch(ctx context.Context, cardID, userID uint) error {
+	if err := s.visibility.Check(ctx, cardID); err != nil {
+		return err
+	}
+	if _, err := s.cardRepo.GetByID(ctx, cardID); err != nil {
+		return err
+	}
+
+	return s.watcherRepo.Watch(ctx, cardID, userID)
+}
+
+func (s *CardWatcherService) Unwatch(ctx context.Context, cardID, userID uint) error {
+	if _, err := s.cardRepo.GetByID(ctx, cardID); err != nil {
+		return err
+	}
+
+	return s.watcherRepo.Unwatch(ctx, cardID, userID)
+}
+
+func (s *CardWatcherService) GetWatchers(ctx context.Context, cardID uint) ([]models.CardWatcher, error) {
+	if err := s.visibility.Check(ctx, cardID); err != nil {
+		return nil, err
+	}
+	if _, err := s.cardRepo.GetByID(ctx, cardID); err != nil {
+		return nil, err
+	}
+
+	return s.watcherRepo.GetByCardID(ctx, cardID)
+}
+
+func (s *CardWatcherService) GetWatchedCards(ctx context.Context, userID uint) ([]models.Card, error) {
+	cards, err := s.watcherRepo.GetWatchedCards(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.visibility.Filter(ctx, cards)
+}
+
+// AutoWatch подписывает пользователя на карточку как побочный эффект другого действия,
+// поэтому ошибки только логируются.
+func (s *CardWatcherService) AutoWatch(ctx context.Context, cardID, userID uint) {
+	if cardID == 0 || userID == 0 {
+		return
+	}
+
+	if err := s.watcherRepo.Watch(ctx, cardID, userID); err != nil {
+		slog.ErrorContext(ctx, "failed to auto-watch card",
+			slog.Uint64("card_id", uint64(cardID)),
+			slog.Uint64("user_id", uint64(userID)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// NotifyCardChange рассылает уведомление всем наблюдателям карточки, кроме автора изменения
+// и гостей, от которых карточка скрыта.
+func (s *CardWatcherService) NotifyCardChange(ctx context.Context, change CardChange) {
+	watcherIDs, err := s.watcherRepo.GetWatcherIDs(ctx, change.CardID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get card watchers", slog.Any("error", err))
+		return
+	}
+	if len(watcherIDs) == 0 {
+		return
+	}
+
+	if change.BoardID == 0 {
+		boardID, err := s.boardIDForCard(ctx, change.CardID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to resolve board for card", slog.Any("error", err))
+			return
+		}
+		change.BoardID = boardID
+	}
+
+	skip := map[uint]bool{}
+	if actorID, ok := ActorFromContext(ctx); ok {
+		skip[actorID] = true
+	}
+	for _, userID := range change.Exclude {
+		skip[userID] = true
+	}
+	hidden, err := s.visibility.HiddenFrom(ctx, change.CardID, watcherIDs)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check card visibility for watchers", slog.Any("error", err))
+		return
+	}
+	for _, userID := range hidden {
+		skip[userID] = true
+	}
+
+	actorID, _ := ActorFromContext(ctx)
+	now := time.Now()
+	for _, userID := range watcherIDs {
+		if skip[userID] {
+			continue
+		}
+		s.notifier.Notify(ctx, NotificationEvent{
+			Type:      change.Type,
+			UserID:    userID,
+			ActorID:   actorID,
+			BoardID:   change.BoardID,
+			CardID:    change.CardID,
+			CommentID: change.CommentID,
+			Message:   change.Message,
+			CreatedAt: now,
+		})
+	}
+}
+
+func (s *CardWatcherService) boardIDForCard(ctx context.Context, cardID uint) (uint, error) {
+	card, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		return 0, err
+	}
+
+	column, err := s.columnRepo.GetByID(ctx, card.ColumnID)
+	if err != nil {
+		return 0, err
+	}
+
+	return column.BoardID, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"gorm.io/gorm"
+)
+
+func TestCardWatcherServiceNotifyCardChange(t *testing.T) {
+	members := &fakeMemberRepo{members: []models.BoardMember{
+		{BoardID: 1, UserID: 1, Role: models.BoardRoleMember},
+		{BoardID: 1, UserID: 2, Role: models.BoardRoleMember},
+		{BoardID: 1, UserID: 3, Role: models.BoardRoleGuest},
+		{BoardID: 1, UserID: 4, Role: models.BoardRoleGuest},
+		{BoardID: 1, UserID: 5, Role: models.BoardRoleMember},
+	}}
+	guests := &fakeGuestRepo{members: members, cards: map[uint][]uint{4: {10, 11}}}
+	deleted := gorm.DeletedAt{Time: time.Now(), Valid: true}
+	cards := &fakeCardRepo{cards: map[uint]*models.Card{
+		10: {ID: 10, ColumnID: 5},
+		11: {ID: 11, ColumnID: 5, DeletedAt: deleted},
+	}}
+	columns := &fakeColumnRepo{columns: map[uint]*models.Column{5: {ID: 5, BoardID: 1}}}
+	watchers := &fakeWatcherRepo{watchers: map[uint][]uint{10: {1, 2, 3, 4, 5}, 11: {1, 4, 5}}}
+
+	tests := []struct {
+		name   string
+		change CardChange
+		want   []uint
+	}{
+		{
+			// Автор изменения, уже уведомленный пользователь и гость без доступа пропускаются.
+			name:   "card updated",
+			change: CardChange{Type: NotificationCardUpdated, CardID: 10, Exclude: []uint{2}},
+			want:   []uint{4, 5},
+		},
+		{
+			// Доска удаленной карточки передается заранее: по карточке ее уже не найти.
+			name:   "card deleted",
+			change: CardChange{Type: NotificationCardDeleted, CardID: 11, BoardID: 1},
+			want:   []uint{4, 5},
+		},
+		{
+			name:   "deleted card without board",
+			change: CardChange{Type: NotificationCardDeleted, CardID: 11},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &recordingNotifier{}
+			service := NewCardWatcherService(watchers, cards, columns, notifier, NewCardVisibility(members, guests))
+
+			service.NotifyCardChange(WithActor(context.Background(), 1), tt.change)
+
+			if got := notifier.userIDs(); !slices.Equal(got, tt.want) {
+				t.Fatalf("notified users = %v, want %v", got, tt.want)
+			}
+			for _, event := range notifier.events {
+				if event.BoardID != 1 || event.ActorID != 1 || event.Type != tt.change.Type {
+					t.Errorf("notification = %+v, want board 1, actor 1 and type %s", event, tt.change.Type)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/octaview/kanban-octaview/internal/models"
@@ -10,27 +11,65 @@ import (
 )
 
 type CardService struct {
-	cardRepo   repository.CardRepository
-	columnRepo repository.ColumnRepository
-	userRepo   repository.UserRepository
+	cardRepo       repository.CardRepository
+	columnRepo     repository.ColumnRepository
+	swimlaneRepo   repository.SwimlaneRepository
+	userRepo       repository.UserRepository
+	mentionService MentionServiceInterface
+	renderer       MarkdownRenderer
+	watcherService CardWatcherServiceInterface
+	reminders      ReminderServiceInterface
+	notifier       Notifier
+	events         EventOutbox
+	visibility     *CardVisibility
 }
 
-func NewCardService(cardRepo repository.CardRepository, columnRepo repository.ColumnRepository, userRepo repository.UserRepository) *CardService {
+func NewCardService(
+	cardRepo repository.CardRepository,
+	columnRepo repository.ColumnRepository,
+	swimlaneRepo repository.SwimlaneRepository,
+	userRepo repository.UserRepository,
+	mentionService MentionServiceInterface,
+	renderer MarkdownRenderer,
+	watcherService CardWatcherServiceInterface,
+	reminders ReminderServiceInterface,
+	notifier Notifier,
+	events EventOutbox,
+	visibility *CardVisibility,
+) *CardService {
 	return &CardService{
-		cardRepo:   cardRepo,
-		columnRepo: columnRepo,
-		userRepo:   userRepo,
+		cardRepo:       cardRepo,
+		columnRepo:     columnRepo,
+		swimlaneRepo:   swimlaneRepo,
+		userRepo:       userRepo,
+		mentionService: mentionService,
+		renderer:       renderer,
+		watcherService: watcherService,
+		reminders:      reminders,
+		notifier:       notifier,
+		events:         events,
+		visibility:     visibility,
 	}
 }
 
 func (s *CardService) Create(ctx context.Context, card *models.Card) error {
-	_, err := s.columnRepo.GetByID(ctx, card.ColumnID)
+	column, err := s.columnRepo.GetByID(ctx, card.ColumnID)
 	if err != nil {
 		if errors.Is(err, models.ErrColumnNotFound) {
 			return models.ErrColumnNotFound
 		}
 		return err
 	}
+	if err := s.visibility.CheckFullAccess(ctx, column.BoardID); err != nil {
+		return err
+	}
+
+	card.SwimlaneID, err = s.resolveSwimlane(ctx, column.BoardID, card.SwimlaneID, nil)
+	if err != nil {
+		return err
+	}
+	card.StartedAt, card.CompletedAt = nil, nil
+	trackProgress(card, column, time.Now())
 
 	if card.AssignedTo != nil {
 		_, err := s.userRepo.GetByID(ctx, *card.AssignedTo)
@@ -46,11 +85,59 @@ func (s *CardService) Create(ctx context.Context, card *models.Card) error {
 		return models.NewValidationError("due_date", "due date cannot be in the past")
 	}
 
-	return s.cardRepo.Create(ctx, card)
+	var mentions *MentionSync
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkWIPLimit(ctx, card.ColumnID); err != nil {
+			return err
+		}
+		if err := s.cardRepo.Create(ctx, card); err != nil {
+			return err
+		}
+		if card.Description != "" {
+			mentions, err = s.mentionService.Sync(ctx, models.MentionSourceCard, card.ID, card.ID, card.Description)
+			if err != nil {
+				return err
+			}
+		}
+		return s.record(ctx, EventCardCreated, column.BoardID, card.ColumnID, cardPayload(card))
+	})
+	if err != nil {
+		return err
+	}
+
+	if actorID, ok := ActorFromContext(ctx); ok {
+		s.watcherService.AutoWatch(ctx, card.ID, actorID)
+	}
+	if card.AssignedTo != nil {
+		s.watcherService.AutoWatch(ctx, card.ID, *card.AssignedTo)
+	}
+	if card.DueDate != nil {
+		s.reminders.Plan(ctx, card)
+	}
+
+	if mentions != nil {
+		card.Mentions = mentions.Spans
+		s.mentionService.Notify(ctx, mentions)
+	}
+	renderCard(s.renderer, card)
+
+	return nil
 }
 
 func (s *CardService) GetByID(ctx context.Context, id uint) (*models.Card, error) {
-	return s.cardRepo.GetByID(ctx, id)
+	card, err := s.getCard(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions, err := s.mentionService.Spans(ctx, models.MentionSourceCard, card.ID)
+	if err != nil {
+		return nil, err
+	}
+	card.Mentions = mentions
+	renderCard(s.renderer, card)
+
+	return card, nil
 }
 
 func (s *CardService) GetByColumnID(ctx context.Context, columnID uint) ([]models.Card, error) {
@@ -62,26 +149,60 @@ func (s *CardService) GetByColumnID(ctx context.Context, columnID uint) ([]model
 		return nil, err
 	}
 
-	return s.cardRepo.GetByColumnID(ctx, columnID)
+	cards, err := s.cardRepo.GetByColumnID(ctx, columnID)
+	if err != nil {
+		return nil, err
+	}
+	if cards, err = s.visibility.Filter(ctx, cards); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+
+	mentions, err := s.mentionService.SpansBySources(ctx, models.MentionSourceCard, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cards {
+		cards[i].Mentions = mentions[cards[i].ID]
+		renderCard(s.renderer, &cards[i])
+	}
+
+	return cards, nil
 }
 
 func (s *CardService) Update(ctx context.Context, card *models.Card) error {
-	existingCard, err := s.cardRepo.GetByID(ctx, card.ID)
+	existingCard, err := s.getCard(ctx, card.ID)
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(ctx, existingCard.Version); err != nil {
+		return err
+	}
+	card.Version = existingCard.Version
 
-	if card.ColumnID != 0 && card.ColumnID != existingCard.ColumnID {
-		_, err := s.columnRepo.GetByID(ctx, card.ColumnID)
-		if err != nil {
-			if errors.Is(err, models.ErrColumnNotFound) {
-				return models.ErrColumnNotFound
-			}
-			return err
-		}
-	} else {
+	if card.ColumnID == 0 {
 		card.ColumnID = existingCard.ColumnID
 	}
+	column, err := s.columnRepo.GetByID(ctx, card.ColumnID)
+	if err != nil {
+		if errors.Is(err, models.ErrColumnNotFound) {
+			return models.ErrColumnNotFound
+		}
+		return err
+	}
+
+	card.SwimlaneID, err = s.resolveSwimlane(ctx, column.BoardID, card.SwimlaneID, existingCard.SwimlaneID)
+	if err != nil {
+		return err
+	}
+	card.StartedAt, card.CompletedAt = existingCard.StartedAt, existingCard.CompletedAt
+	if card.ColumnID != existingCard.ColumnID {
+		trackProgress(card, column, time.Now())
+	}
 
 	if card.AssignedTo != nil && (existingCard.AssignedTo == nil || *card.AssignedTo != *existingCard.AssignedTo) {
 		_, err := s.userRepo.GetByID(ctx, *card.AssignedTo)
@@ -103,48 +224,131 @@ func (s *CardService) Update(ctx context.Context, card *models.Card) error {
 		card.Position = existingCard.Position
 	}
 
-	return s.cardRepo.Update(ctx, card)
+	var mentions *MentionSync
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if card.ColumnID != existingCard.ColumnID {
+			if err := s.checkWIPLimit(ctx, card.ColumnID); err != nil {
+				return err
+			}
+		}
+		if err := s.cardRepo.Update(ctx, card); err != nil {
+			return err
+		}
+		if card.Description != existingCard.Description {
+			mentions, err = s.mentionService.Sync(ctx, models.MentionSourceCard, card.ID, card.ID, card.Description)
+			if err != nil {
+				return err
+			}
+		}
+		return s.record(ctx, EventCardUpdated, 0, card.ColumnID, cardPayload(card))
+	})
+	if err != nil {
+		return err
+	}
+
+	if card.AssignedTo != nil && (existingCard.AssignedTo == nil || *card.AssignedTo != *existingCard.AssignedTo) {
+		s.watcherService.AutoWatch(ctx, card.ID, *card.AssignedTo)
+	}
+	if !sameDueDate(card.DueDate, existingCard.DueDate) || card.ColumnID != existingCard.ColumnID {
+		s.reminders.Plan(ctx, card)
+	}
+	s.watcherService.NotifyCardChange(ctx, CardChange{
+		Type:    NotificationCardUpdated,
+		CardID:  card.ID,
+		Message: fmt.Sprintf("Card %q was updated", card.Title),
+	})
+
+	if mentions != nil {
+		card.Mentions = mentions.Spans
+		s.mentionService.Notify(ctx, mentions)
+	} else {
+		spans, err := s.mentionService.Spans(ctx, models.MentionSourceCard, card.ID)
+		if err != nil {
+			return err
+		}
+		card.Mentions = spans
+	}
+	renderCard(s.renderer, card)
+
+	return nil
 }
 
 func (s *CardService) Delete(ctx context.Context, id uint) error {
-	_, err := s.cardRepo.GetByID(ctx, id)
+	card, err := s.getCard(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(ctx, card.Version); err != nil {
+		return err
+	}
 
-	return s.cardRepo.Delete(ctx, id)
-}
+	column, err := s.columnRepo.GetByID(ctx, card.ColumnID)
+	if err != nil {
+		return err
+	}
 
-func (s *CardService) UpdatePositions(ctx context.Context, cards []models.Card) error {
-	if len(cards) == 0 {
-		return errors.New("no cards provided for position update")
+	// Наблюдатели оповещаются после фиксации удаления, когда доску карточки уже не найти
+	// по ее id, поэтому доска заполняется заранее.
+	change := CardChange{
+		Type:    NotificationCardDeleted,
+		CardID:  card.ID,
+		BoardID: column.BoardID,
+		Message: fmt.Sprintf("Card %q was deleted", card.Title),
 	}
 
-	columnID := cards[0].ColumnID
-	for _, card := range cards {
-		if card.ColumnID != columnID {
-			return errors.New("all cards must belong to the same column")
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.cardRepo.Delete(ctx, id, card.Version); err != nil {
+			return err
 		}
+		return s.record(ctx, EventCardDeleted, column.BoardID, card.ColumnID, cardPayload(card))
+	})
+	if err != nil {
+		return err
 	}
 
-	_, err := s.columnRepo.GetByID(ctx, columnID)
+	s.reminders.Cancel(ctx, id)
+	s.watcherService.NotifyCardChange(ctx, change)
+	return nil
+}
+
+// Reorder расставляет карточки колонки в порядке cardIDs и возвращает их в новом порядке.
+// cardIDs должен содержать ровно все карточки колонки, иначе возвращается *models.OrderConflictError.
+func (s *CardService) Reorder(ctx context.Context, columnID uint, cardIDs []uint) ([]models.Card, error) {
+	if id, ok := duplicateID(cardIDs); ok {
+		return nil, models.NewValidationError("card_ids", fmt.Sprintf("card %d is listed more than once", id))
+	}
+
+	column, err := s.columnRepo.GetByID(ctx, columnID)
 	if err != nil {
-		if errors.Is(err, models.ErrColumnNotFound) {
-			return models.ErrColumnNotFound
+		return nil, err
+	}
+	if err := s.visibility.CheckFullAccess(ctx, column.BoardID); err != nil {
+		return nil, err
+	}
+
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		changed, err := s.cardRepo.Reorder(ctx, columnID, cardIDs)
+		if err != nil || !changed {
+			return err
 		}
-		return err
+		return s.record(ctx, EventCardReordered, column.BoardID, columnID, CardsReorderedPayload{ColumnID: columnID, CardIDs: cardIDs})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.cardRepo.UpdatePositions(ctx, cards)
+	return s.cardRepo.GetByColumnID(ctx, columnID)
 }
 
-func (s *CardService) MoveCard(ctx context.Context, cardID, columnID uint, position int) error {
-	card, err := s.cardRepo.GetByID(ctx, cardID)
+// MoveCard ставит карточку на позицию position в ячейке колонки columnID и дорожки swimlaneID.
+// Пустой swimlaneID оставляет карточку в ее дорожке, нулевой переносит в дорожку по умолчанию.
+func (s *CardService) MoveCard(ctx context.Context, cardID, columnID uint, swimlaneID *uint, position int) error {
+	card, err := s.getCard(ctx, cardID)
 	if err != nil {
 		return err
 	}
 
-	_, err = s.columnRepo.GetByID(ctx, columnID)
+	column, err := s.columnRepo.GetByID(ctx, columnID)
 	if err != nil {
 		if errors.Is(err, models.ErrColumnNotFound) {
 			return models.ErrColumnNotFound
@@ -152,15 +356,63 @@ func (s *CardService) MoveCard(ctx context.Context, cardID, columnID uint, posit
 		return err
 	}
 
-	if card.ColumnID == columnID && card.Position == position {
+	swimlaneID, err = s.resolveSwimlane(ctx, column.BoardID, swimlaneID, card.SwimlaneID)
+	if err != nil {
+		return err
+	}
+
+	fromSwimlaneID := card.SwimlaneID
+	if card.ColumnID == columnID && sameSwimlane(fromSwimlaneID, swimlaneID) && card.Position == position {
 		return nil
 	}
 
-	return s.cardRepo.MoveToColumn(ctx, cardID, columnID, position)
+	fromColumnID := card.ColumnID
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if fromColumnID != columnID {
+			if err := s.checkWIPLimit(ctx, columnID); err != nil {
+				return err
+			}
+		}
+		moved, err := s.cardRepo.MoveToColumn(ctx, cardID, columnID, swimlaneID, position)
+		if err != nil {
+			return err
+		}
+
+		card.ColumnID = columnID
+		card.SwimlaneID = swimlaneID
+		card.Position = moved
+		if trackProgress(card, column, time.Now()) {
+			if err := s.cardRepo.SetProgress(ctx, cardID, card.StartedAt, card.CompletedAt); err != nil {
+				return err
+			}
+		}
+		return s.record(ctx, EventCardMoved, column.BoardID, columnID, CardMovedPayload{
+			Card:           cardPayload(card),
+			FromColumnID:   fromColumnID,
+			ToColumnID:     columnID,
+			FromSwimlaneID: fromSwimlaneID,
+			ToSwimlaneID:   swimlaneID,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if fromColumnID != columnID && card.DueDate != nil {
+		s.reminders.Plan(ctx, card)
+	}
+
+	s.watcherService.NotifyCardChange(ctx, CardChange{
+		Type:    NotificationCardMoved,
+		CardID:  cardID,
+		BoardID: column.BoardID,
+		Message: fmt.Sprintf("Card %q was moved to %q", card.Title, column.Title),
+	})
+	return nil
 }
 
 func (s *CardService) AssignCard(ctx context.Context, cardID, userID uint) error {
-	card, err := s.cardRepo.GetByID(ctx, cardID)
+	card, err := s.getCard(ctx, cardID)
 	if err != nil {
 		return err
 	}
@@ -173,30 +425,215 @@ func (s *CardService) AssignCard(ctx context.Context, cardID, userID uint) error
 		return err
 	}
 
+	column, err := s.columnRepo.GetByID(ctx, card.ColumnID)
+	if err != nil {
+		return err
+	}
+
 	card.AssignedTo = &userID
-	return s.cardRepo.Update(ctx, card)
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.cardRepo.Update(ctx, card); err != nil {
+			return err
+		}
+		return s.record(ctx, EventCardAssigned, column.BoardID, card.ColumnID, cardPayload(card))
+	})
+	if err != nil {
+		return err
+	}
+
+	actorID, _ := ActorFromContext(ctx)
+	if userID != actorID {
+		s.notifier.Notify(ctx, NotificationEvent{
+			Type:      NotificationCardAssigned,
+			UserID:    userID,
+			ActorID:   actorID,
+			BoardID:   column.BoardID,
+			CardID:    cardID,
+			Message:   fmt.Sprintf("You were assigned to card %q", card.Title),
+			CreatedAt: time.Now(),
+		})
+	}
+
+	s.watcherService.AutoWatch(ctx, cardID, userID)
+	s.watcherService.NotifyCardChange(ctx, CardChange{
+		Type:    NotificationCardAssigned,
+		CardID:  cardID,
+		BoardID: column.BoardID,
+		Message: fmt.Sprintf("Card %q was assigned", card.Title),
+		Exclude: []uint{userID},
+	})
+	return nil
 }
 
 func (s *CardService) UnassignCard(ctx context.Context, cardID uint) error {
-	card, err := s.cardRepo.GetByID(ctx, cardID)
+	card, err := s.getCard(ctx, cardID)
 	if err != nil {
 		return err
 	}
 
 	card.AssignedTo = nil
-	return s.cardRepo.Update(ctx, card)
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.cardRepo.Update(ctx, card); err != nil {
+			return err
+		}
+		return s.record(ctx, EventCardUnassigned, 0, card.ColumnID, cardPayload(card))
+	})
+	if err != nil {
+		return err
+	}
+
+	s.watcherService.NotifyCardChange(ctx, CardChange{
+		Type:    NotificationCardUnassigned,
+		CardID:  cardID,
+		Message: fmt.Sprintf("Card %q was unassigned", card.Title),
+	})
+	return nil
 }
 
 func (s *CardService) UpdateDueDate(ctx context.Context, cardID uint, dueDate *time.Time) error {
-	card, err := s.cardRepo.GetByID(ctx, cardID)
+	card, err := s.getCard(ctx, cardID)
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(ctx, card.Version); err != nil {
+		return err
+	}
 
 	if dueDate != nil && dueDate.Before(time.Now()) {
 		return models.NewValidationError("due_date", "due date cannot be in the past")
 	}
 
 	card.DueDate = dueDate
-	return s.cardRepo.Update(ctx, card)
-}
\ No newline at end of file
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.cardRepo.Update(ctx, card); err != nil {
+			return err
+		}
+		return s.record(ctx, EventCardDueDateChange, 0, card.ColumnID, cardPayload(card))
+	})
+	if err != nil {
+		return err
+	}
+
+	s.reminders.Plan(ctx, card)
+
+	s.watcherService.NotifyCardChange(ctx, CardChange{
+		Type:    NotificationDueDateChanged,
+		CardID:  cardID,
+		Message: fmt.Sprintf("Due date of card %q was changed", card.Title),
+	})
+	return nil
+}
+
+// getCard загружает карточку, если она видна пользователю запроса.
+func (s *CardService) getCard(ctx context.Context, id uint) (*models.Card, error) {
+	if err := s.visibility.Check(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.cardRepo.GetByID(ctx, id)
+}
+
+// checkWIPLimit проверяет, что в колонку можно добавить карточку. Вызывается в той же
+// транзакции, что и добавление: в режиме block колонка остается заблокированной до ее конца.
+func (s *CardService) checkWIPLimit(ctx context.Context, columnID uint) error {
+	usage, err := s.columnRepo.LockWIPUsage(ctx, columnID)
+	if err != nil {
+		return err
+	}
+	if usage.Full() {
+		return models.NewWIPLimitError(columnID, *usage.Limit, usage.Count)
+	}
+	return nil
+}
+
+// resolveSwimlane возвращает дорожку, в которой окажется карточка на доске boardID.
+// Пустой requested оставляет текущую дорожку, если она с той же доски, нулевой означает
+// дорожку по умолчанию. Дорожка другой доски — ошибка валидации.
+func (s *CardService) resolveSwimlane(ctx context.Context, boardID uint, requested, current *uint) (*uint, error) {
+	if requested == nil {
+		if current == nil {
+			return nil, nil
+		}
+		swimlane, err := s.swimlaneRepo.GetByID(ctx, *current)
+		if errors.Is(err, models.ErrSwimlaneNotFound) || (err == nil && swimlane.BoardID != boardID) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return current, nil
+	}
+	if *requested == 0 {
+		return nil, nil
+	}
+
+	swimlane, err := s.swimlaneRepo.GetByID(ctx, *requested)
+	if errors.Is(err, models.ErrSwimlaneNotFound) || (err == nil && swimlane.BoardID != boardID) {
+		return nil, models.NewValidationError("swimlane_id", "swimlane not found on this board")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return requested, nil
+}
+
+// record записывает событие карточки в outbox. Если доска неизвестна, она определяется по колонке.
+func (s *CardService) record(ctx context.Context, eventType string, boardID, columnID uint, data any) error {
+	if boardID == 0 {
+		column, err := s.columnRepo.GetByID(ctx, columnID)
+		if err != nil {
+			return err
+		}
+		boardID = column.BoardID
+	}
+
+	return s.events.Record(ctx, newBoardEvent(ctx, eventType, boardID, data))
+}
+
+// duplicateID возвращает первый повторяющийся идентификатор.
+func duplicateID(ids []uint) (uint, bool) {
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return id, true
+		}
+		seen[id] = true
+	}
+	return 0, false
+}
+
+// trackProgress выставляет отметки начала и завершения работы над карточкой, попавшей
+// в колонку column, и сбрасывает их, если карточка вернулась на более ранний этап.
+// Возвращает true, если отметки изменились.
+func trackProgress(card *models.Card, column *models.Column, now time.Time) bool {
+	startedAt, completedAt := card.StartedAt, card.CompletedAt
+	switch {
+	case !column.Started():
+		startedAt = nil
+	case startedAt == nil:
+		startedAt = &now
+	}
+	switch {
+	case !column.Done():
+		completedAt = nil
+	case completedAt == nil:
+		completedAt = &now
+	}
+
+	changed := (startedAt == nil) != (card.StartedAt == nil) || (completedAt == nil) != (card.CompletedAt == nil)
+	card.StartedAt, card.CompletedAt = startedAt, completedAt
+	return changed
+}
+
+func sameSwimlane(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameDueDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
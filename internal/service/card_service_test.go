@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+)
+
+func TestTrackProgress(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	earlier := now.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name          string
+		kind          string
+		started       *time.Time
+		completed     *time.Time
+		wantStarted   *time.Time
+		wantCompleted *time.Time
+		wantChanged   bool
+	}{
+		{name: "stays in backlog", kind: models.ColumnKindBacklog},
+		{name: "work starts", kind: models.ColumnKindInProgress, wantStarted: &now, wantChanged: true},
+		{name: "moves to review", kind: models.ColumnKindReview, started: &earlier, wantStarted: &earlier},
+		{name: "done", kind: models.ColumnKindDone, started: &earlier, wantStarted: &earlier, wantCompleted: &now, wantChanged: true},
+		{name: "done without starting", kind: models.ColumnKindDone, wantStarted: &now, wantCompleted: &now, wantChanged: true},
+		{name: "reopened", kind: models.ColumnKindInProgress, started: &earlier, completed: &earlier, wantStarted: &earlier, wantChanged: true},
+		{name: "back to todo", kind: models.ColumnKindTodo, started: &earlier, completed: &earlier, wantChanged: true},
+		{name: "done again keeps the first completion", kind: models.ColumnKindDone, started: &earlier, completed: &earlier, wantStarted: &earlier, wantCompleted: &earlier},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := &models.Card{StartedAt: tt.started, CompletedAt: tt.completed}
+			changed := trackProgress(card, &models.Column{Kind: tt.kind}, now)
+
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !sameDueDate(card.StartedAt, tt.wantStarted) {
+				t.Errorf("started at = %v, want %v", card.StartedAt, tt.wantStarted)
+			}
+			if !sameDueDate(card.CompletedAt, tt.wantCompleted) {
+				t.Errorf("completed at = %v, want %v", card.CompletedAt, tt.wantCompleted)
+			}
+		})
+	}
+}
+
+func TestDuplicateID(t *testing.T) {
+	tests := []struct {
+		ids    []uint
+		want   uint
+		wantOK bool
+	}{
+		{ids: nil},
+		{ids: []uint{1, 2, 3}},
+		{ids: []uint{1, 2, 1, 2}, want: 1, wantOK: true},
+		{ids: []uint{4, 5, 5}, want: 5, wantOK: true},
+	}
+	for _, tt := range tests {
+		if got, ok := duplicateID(tt.ids); got != tt.want || ok != tt.wantOK {
+			t.Errorf("duplicateID(%v) = %d, %v, want %d, %v", tt.ids, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/octaview/kanban-octaview/internal/models"
 	"github.com/octaview/kanban-octaview/internal/repository"
@@ -11,12 +14,16 @@ import (
 type ColumnService struct {
 	columnRepo repository.ColumnRepository
 	boardRepo  repository.BoardRepository
+	events     EventOutbox
+	visibility *CardVisibility
 }
 
-func NewColumnService(columnRepo repository.ColumnRepository, boardRepo repository.BoardRepository) *ColumnService {
+func NewColumnService(columnRepo repository.ColumnRepository, boardRepo repository.BoardRepository, events EventOutbox, visibility *CardVisibility) *ColumnService {
 	return &ColumnService{
 		columnRepo: columnRepo,
 		boardRepo:  boardRepo,
+		events:     events,
+		visibility: visibility,
 	}
 }
 
@@ -29,11 +36,31 @@ func (s *ColumnService) Create(ctx context.Context, column *models.Column) error
 		return err
 	}
 
-	return s.columnRepo.Create(ctx, column)
+	if err := normalizeWIPLimit(column, nil); err != nil {
+		return err
+	}
+	if err := normalizeKind(column, models.ColumnKindTodo); err != nil {
+		return err
+	}
+
+	return s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.columnRepo.Create(ctx, column); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventColumnCreated, column.BoardID, columnPayload(column)))
+	})
 }
 
 func (s *ColumnService) GetByID(ctx context.Context, id uint) (*models.Column, error) {
-	return s.columnRepo.GetByID(ctx, id)
+	column, err := s.columnRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	columns := []models.Column{*column}
+	if err := s.visibility.CountCards(ctx, column.BoardID, columns); err != nil {
+		return nil, err
+	}
+	return &columns[0], nil
 }
 
 func (s *ColumnService) GetByBoardID(ctx context.Context, boardID uint) ([]models.Column, error) {
@@ -45,7 +72,14 @@ func (s *ColumnService) GetByBoardID(ctx context.Context, boardID uint) ([]model
 		return nil, err
 	}
 
-	return s.columnRepo.GetByBoardID(ctx, boardID)
+	columns, err := s.columnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.visibility.CountCards(ctx, boardID, columns); err != nil {
+		return nil, err
+	}
+	return columns, nil
 }
 
 func (s *ColumnService) Update(ctx context.Context, column *models.Column) error {
@@ -53,6 +87,10 @@ func (s *ColumnService) Update(ctx context.Context, column *models.Column) error
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(ctx, existingColumn.Version); err != nil {
+		return err
+	}
+	column.Version = existingColumn.Version
 
 	if column.Position == 0 {
 		column.Position = existingColumn.Position
@@ -66,32 +104,86 @@ func (s *ColumnService) Update(ctx context.Context, column *models.Column) error
 		column.BoardID = existingColumn.BoardID
 	}
 
-	return s.columnRepo.Update(ctx, column)
+	if err := normalizeWIPLimit(column, existingColumn.WIPLimit); err != nil {
+		return err
+	}
+	if err := normalizeKind(column, existingColumn.Kind); err != nil {
+		return err
+	}
+
+	return s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.columnRepo.Update(ctx, column); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventColumnUpdated, column.BoardID, columnPayload(column)))
+	})
 }
 
 func (s *ColumnService) Delete(ctx context.Context, id uint) error {
-	return s.columnRepo.Delete(ctx, id)
+	column, err := s.columnRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(ctx, column.Version); err != nil {
+		return err
+	}
+
+	return s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.columnRepo.Delete(ctx, id, column.Version); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventColumnDeleted, column.BoardID, columnPayload(column)))
+	})
 }
 
-func (s *ColumnService) UpdatePositions(ctx context.Context, columns []models.Column) error {
-	if len(columns) == 0 {
-		return errors.New("no columns provided for position update")
+// Reorder расставляет колонки доски в порядке columnIDs и возвращает их в новом порядке.
+// columnIDs должен содержать ровно все колонки доски, иначе возвращается *models.OrderConflictError.
+func (s *ColumnService) Reorder(ctx context.Context, boardID uint, columnIDs []uint) ([]models.Column, error) {
+	if id, ok := duplicateID(columnIDs); ok {
+		return nil, models.NewValidationError("column_ids", fmt.Sprintf("column %d is listed more than once", id))
 	}
 
-	boardID := columns[0].BoardID
-	for _, col := range columns {
-		if col.BoardID != boardID {
-			return errors.New("all columns must belong to the same board")
-		}
+	if _, err := s.boardRepo.GetByID(ctx, boardID); err != nil {
+		return nil, err
 	}
 
-	_, err := s.boardRepo.GetByID(ctx, boardID)
-	if err != nil {
-		if errors.Is(err, models.ErrBoardNotFound) {
-			return models.ErrBoardNotFound
+	err := s.events.InTransaction(ctx, func(ctx context.Context) error {
+		changed, err := s.columnRepo.Reorder(ctx, boardID, columnIDs)
+		if err != nil || !changed {
+			return err
 		}
-		return err
+		return s.events.Record(ctx, newBoardEvent(ctx, EventColumnReordered, boardID, ColumnsReorderedPayload{BoardID: boardID, ColumnIDs: columnIDs}))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.columnRepo.GetByBoardID(ctx, boardID)
+}
+
+// normalizeWIPLimit приводит WIP-лимит из запроса к хранимому виду: пустой лимит оставляет
+// прежний, нулевой снимает ограничение.
+func normalizeWIPLimit(column *models.Column, existing *int) error {
+	switch {
+	case column.WIPLimit == nil:
+		column.WIPLimit = existing
+	case *column.WIPLimit < 0:
+		return models.NewValidationError("wip_limit", "WIP limit cannot be negative")
+	case *column.WIPLimit == 0:
+		column.WIPLimit = nil
 	}
+	return nil
+}
 
-	return s.columnRepo.UpdatePositions(ctx, columns)
+// normalizeKind проверяет вид колонки из запроса; пустой вид заменяется на fallback.
+// Смена вида не затрагивает отметки карточек, уже лежащих в колонке.
+func normalizeKind(column *models.Column, fallback string) error {
+	if column.Kind == "" {
+		column.Kind = fallback
+		return nil
+	}
+	if !slices.Contains(models.ColumnKinds, column.Kind) {
+		return models.NewValidationError("kind", fmt.Sprintf("kind must be one of %s", strings.Join(models.ColumnKinds, ", ")))
+	}
+	return nil
 }
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+)
+
+func TestNormalizeKind(t *testing.T) {
+	tests := []struct {
+		kind, fallback string
+		want           string
+		wantErr        bool
+	}{
+		{kind: "", fallback: models.ColumnKindTodo, want: models.ColumnKindTodo},
+		{kind: models.ColumnKindDone, fallback: models.ColumnKindTodo, want: models.ColumnKindDone},
+		{kind: "archived", fallback: models.ColumnKindTodo, wantErr: true},
+		{kind: "Done", fallback: models.ColumnKindTodo, wantErr: true},
+	}
+	for _, tt := range tests {
+		column := &models.Column{Kind: tt.kind}
+		err := normalizeKind(column, tt.fallback)
+
+		var validationErr *models.ValidationError
+		if tt.wantErr {
+			if !errors.As(err, &validationErr) {
+				t.Errorf("normalizeKind(%q) error = %v, want a validation error", tt.kind, err)
+			}
+			continue
+		}
+		if err != nil || column.Kind != tt.want {
+			t.Errorf("normalizeKind(%q) = %q, %v, want %q", tt.kind, column.Kind, err, tt.want)
+		}
+	}
+}
+
+func TestColumnServiceCountsOnlyCardsVisibleToGuests(t *testing.T) {
+	limit := 1
+	columns := &fakeColumnRepo{columns: map[uint]*models.Column{
+		5: {ID: 5, BoardID: 1, WIPLimit: &limit, CardCount: 2, OverLimit: true},
+		6: {ID: 6, BoardID: 1, CardCount: 1},
+	}}
+	members := &fakeMemberRepo{members: []models.BoardMember{
+		{BoardID: 1, UserID: 1, Role: models.BoardRoleMember},
+		{BoardID: 1, UserID: 2, Role: models.BoardRoleGuest},
+	}}
+	cards := &fakeCardRepo{cards: map[uint]*models.Card{
+		10: {ID: 10, ColumnID: 5, Column: models.Column{ID: 5, BoardID: 1}},
+		11: {ID: 11, ColumnID: 5, Column: models.Column{ID: 5, BoardID: 1}},
+		12: {ID: 12, ColumnID: 6, Column: models.Column{ID: 6, BoardID: 1}},
+	}}
+	guests := &fakeGuestRepo{members: members, cards: map[uint][]uint{2: {10}}, cardRepo: cards}
+	boards := &fakeBoardRepo{boards: map[uint]*models.Board{1: {ID: 1}}}
+	service := NewColumnService(columns, boards, nil, NewCardVisibility(members, guests))
+
+	type count struct {
+		cards     int
+		overLimit bool
+	}
+	tests := []struct {
+		userID uint
+		want   []count
+	}{
+		{userID: 1, want: []count{{2, true}, {1, false}}},
+		{userID: 2, want: []count{{1, false}, {0, false}}},
+	}
+	for _, tt := range tests {
+		ctx := WithActor(context.Background(), tt.userID)
+
+		list, err := service.GetByBoardID(ctx, 1)
+		if err != nil {
+			t.Fatalf("user %d: GetByBoardID: %v", tt.userID, err)
+		}
+		var got []count
+		for _, column := range list {
+			got = append(got, count{column.CardCount, column.OverLimit})
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("user %d: column counts = %v, want %v", tt.userID, got, tt.want)
+		}
+
+		column, err := service.GetByID(ctx, 5)
+		if err != nil {
+			t.Fatalf("user %d: GetByID: %v", tt.userID, err)
+		}
+		if got := (count{column.CardCount, column.OverLimit}); got != tt.want[0] {
+			t.Errorf("user %d: column 5 count = %v, want %v", tt.userID, got, tt.want[0])
+		}
+	}
+}
@@ -3,27 +3,53 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/octaview/kanban-octaview/internal/models"
 	"github.com/octaview/kanban-octaview/internal/repository"
 )
 
 type CommentService struct {
-	commentRepo repository.CommentRepository
-	cardRepo    repository.CardRepository
-	userRepo    repository.UserRepository
+	commentRepo    repository.CommentRepository
+	cardRepo       repository.CardRepository
+	columnRepo     repository.ColumnRepository
+	userRepo       repository.UserRepository
+	mentionService MentionServiceInterface
+	memberService  BoardMemberServiceInterface
+	renderer       MarkdownRenderer
+	watcherService CardWatcherServiceInterface
+	events         EventOutbox
+	visibility     *CardVisibility
 }
 
-func NewCommentService(commentRepo repository.CommentRepository, cardRepo repository.CardRepository, userRepo repository.UserRepository) *CommentService {
+func NewCommentService(
+	commentRepo repository.CommentRepository,
+	cardRepo repository.CardRepository,
+	columnRepo repository.ColumnRepository,
+	userRepo repository.UserRepository,
+	mentionService MentionServiceInterface,
+	memberService BoardMemberServiceInterface,
+	renderer MarkdownRenderer,
+	watcherService CardWatcherServiceInterface,
+	events EventOutbox,
+	visibility *CardVisibility,
+) *CommentService {
 	return &CommentService{
-		commentRepo: commentRepo,
-		cardRepo:    cardRepo,
-		userRepo:    userRepo,
+		commentRepo:    commentRepo,
+		cardRepo:       cardRepo,
+		columnRepo:     columnRepo,
+		userRepo:       userRepo,
+		mentionService: mentionService,
+		memberService:  memberService,
+		renderer:       renderer,
+		watcherService: watcherService,
+		events:         events,
+		visibility:     visibility,
 	}
 }
 
 func (s *CommentService) Create(ctx context.Context, comment *models.Comment) error {
-	card, err := s.cardRepo.GetByID(ctx, comment.CardID)
+	card, err := s.getCard(ctx, comment.CardID)
 	if err != nil {
 		if errors.Is(err, models.ErrCardNotFound) {
 			return models.ErrCardNotFound
@@ -42,15 +68,54 @@ func (s *CommentService) Create(ctx context.Context, comment *models.Comment) er
 	comment.Card = *card
 	comment.User = *user
 	
-	return s.commentRepo.Create(ctx, comment)
+	var mentions *MentionSync
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.commentRepo.Create(ctx, comment); err != nil {
+			return err
+		}
+		mentions, err = s.mentionService.Sync(ctx, models.MentionSourceComment, comment.ID, comment.CardID, comment.Content)
+		if err != nil {
+			return err
+		}
+		return s.record(ctx, EventCommentCreated, card.ColumnID, commentPayload(comment))
+	})
+	if err != nil {
+		return err
+	}
+	comment.Mentions = mentions.Spans
+	renderComment(s.renderer, comment)
+
+	s.mentionService.Notify(ctx, mentions)
+
+	s.watcherService.AutoWatch(ctx, card.ID, user.ID)
+	s.watcherService.NotifyCardChange(ctx, CardChange{
+		Type:      NotificationCommentAdded,
+		CardID:    card.ID,
+		CommentID: comment.ID,
+		Message:   fmt.Sprintf("%s commented on card %q", user.Name, card.Title),
+	})
+
+	return nil
 }
 
 func (s *CommentService) GetByID(ctx context.Context, id uint) (*models.Comment, error) {
-	return s.commentRepo.GetByID(ctx, id)
+	comment, err := s.getComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions, err := s.mentionService.Spans(ctx, models.MentionSourceComment, comment.ID)
+	if err != nil {
+		return nil, err
+	}
+	comment.Mentions = mentions
+	renderComment(s.renderer, comment)
+
+	return comment, nil
 }
 
 func (s *CommentService) GetByCardID(ctx context.Context, cardID uint) ([]models.Comment, error) {
-	_, err := s.cardRepo.GetByID(ctx, cardID)
+	_, err := s.getCard(ctx, cardID)
 	if err != nil {
 		if errors.Is(err, models.ErrCardNotFound) {
 			return nil, models.ErrCardNotFound
@@ -58,25 +123,247 @@ func (s *CommentService) GetByCardID(ctx context.Context, cardID uint) ([]models
 		return nil, err
 	}
 
-	return s.commentRepo.GetByCardID(ctx, cardID)
+	comments, err := s.commentRepo.GetByCardID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(comments))
+	for i := range comments {
+		if comments[i].DeletedAt.Valid {
+			comments[i].Content = ""
+			comments[i].EditedAt = nil
+			comments[i].Deleted = true
+			continue
+		}
+		ids = append(ids, comments[i].ID)
+	}
+
+	mentions, err := s.mentionService.SpansBySources(ctx, models.MentionSourceComment, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range comments {
+		if comments[i].Deleted {
+			continue
+		}
+		comments[i].Mentions = mentions[comments[i].ID]
+		renderComment(s.renderer, &comments[i])
+	}
+
+	return comments, nil
 }
 
 func (s *CommentService) Update(ctx context.Context, comment *models.Comment) error {
-	existingComment, err := s.commentRepo.GetByID(ctx, comment.ID)
+	existingComment, err := s.getComment(ctx, comment.ID)
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(ctx, existingComment.Version); err != nil {
+		return err
+	}
 
 	existingComment.Content = comment.Content
 	
-	return s.commentRepo.Update(ctx, existingComment)
+	editedBy, ok := ActorFromContext(ctx)
+	if !ok {
+		editedBy = existingComment.UserID
+	}
+
+	var (
+		changed  bool
+		mentions *MentionSync
+	)
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		changed, err = s.commentRepo.Update(ctx, existingComment, editedBy)
+		if err != nil || !changed {
+			return err
+		}
+		mentions, err = s.mentionService.Sync(ctx, models.MentionSourceComment, existingComment.ID, existingComment.CardID, existingComment.Content)
+		if err != nil {
+			return err
+		}
+		return s.record(ctx, EventCommentUpdated, 0, commentPayload(existingComment))
+	})
+	if err != nil {
+		return err
+	}
+	comment.EditedAt = existingComment.EditedAt
+	comment.Version = existingComment.Version
+	if !changed {
+		// Правка без изменений ничего не записала: упоминания остались прежними, а наблюдателям
+		// и подписчикам событий сообщать не о чем.
+		spans, err := s.mentionService.Spans(ctx, models.MentionSourceComment, comment.ID)
+		if err != nil {
+			return err
+		}
+		comment.Mentions = spans
+		renderComment(s.renderer, comment)
+		return nil
+	}
+	comment.Mentions = mentions.Spans
+	renderComment(s.renderer, comment)
+
+	s.mentionService.Notify(ctx, mentions)
+
+	s.watcherService.NotifyCardChange(ctx, CardChange{
+		Type:      NotificationCommentEdited,
+		CardID:    existingComment.CardID,
+		CommentID: existingComment.ID,
+		Message:   "A comment was edited",
+	})
+
+	return nil
 }
 
 func (s *CommentService) Delete(ctx context.Context, id uint) error {
-	_, err := s.commentRepo.GetByID(ctx, id)
+	comment, err := s.getComment(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(ctx, comment.Version); err != nil {
+		return err
+	}
 	
-	return s.commentRepo.Delete(ctx, id)
-}
\ No newline at end of file
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.commentRepo.Delete(ctx, id, comment.Version); err != nil {
+			return err
+		}
+		return s.record(ctx, EventCommentDeleted, 0, CommentPayload{ID: comment.ID, CardID: comment.CardID, UserID: comment.UserID, CreatedAt: comment.CreatedAt})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mentionService.Clear(ctx, models.MentionSourceComment, id)
+	s.watcherService.NotifyCardChange(ctx, CardChange{
+		Type:      NotificationCommentDeleted,
+		CardID:    comment.CardID,
+		CommentID: comment.ID,
+		Message:   "A comment was deleted",
+	})
+	return nil
+}
+
+// GetRevisions возвращает историю правок комментария. Историю удаленного комментария
+// видят только администраторы доски, остальным он не найден.
+func (s *CommentService) GetRevisions(ctx context.Context, id uint) ([]models.CommentRevision, error) {
+	comment, err := s.commentRepo.GetByIDUnscoped(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.visibility.Check(ctx, comment.CardID); err != nil {
+		if errors.Is(err, models.ErrCardNotFound) {
+			return nil, models.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	if comment.DeletedAt.Valid {
+		if _, err := s.checkAdmin(ctx, comment); err != nil {
+			if errors.Is(err, models.ErrInsufficientAccess) || errors.Is(err, models.ErrUnauthorized) {
+				return nil, models.ErrCommentNotFound
+			}
+			return nil, err
+		}
+	}
+
+	return s.commentRepo.GetRevisions(ctx, id)
+}
+
+// Purge безвозвратно удаляет комментарий. Доступно только администраторам доски.
+func (s *CommentService) Purge(ctx context.Context, id uint) error {
+	comment, err := s.commentRepo.GetByIDUnscoped(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	boardID, err := s.checkAdmin(ctx, comment)
+	if err != nil {
+		return err
+	}
+
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.commentRepo.Purge(ctx, id); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventCommentPurged, boardID,
+			CommentPayload{ID: comment.ID, CardID: comment.CardID, UserID: comment.UserID, CreatedAt: comment.CreatedAt}))
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mentionService.Clear(ctx, models.MentionSourceComment, id)
+	return nil
+}
+
+// checkAdmin возвращает доску комментария или ErrInsufficientAccess, если пользователь запроса
+// не ее администратор. Комментарий может быть удален вместе с карточкой, поэтому карточка
+// и колонка ищутся среди удаленных тоже.
+func (s *CommentService) checkAdmin(ctx context.Context, comment *models.Comment) (uint, error) {
+	card, err := s.cardRepo.GetByIDUnscoped(ctx, comment.CardID)
+	if err != nil {
+		return 0, err
+	}
+
+	column, err := s.columnRepo.GetByIDUnscoped(ctx, card.ColumnID)
+	if err != nil {
+		return 0, err
+	}
+
+	actorID, ok := ActorFromContext(ctx)
+	if !ok {
+		return 0, models.ErrUnauthorized
+	}
+
+	isAdmin, err := s.memberService.IsAdmin(ctx, column.BoardID, actorID)
+	if err != nil {
+		return 0, err
+	}
+	if !isAdmin {
+		return 0, models.ErrInsufficientAccess
+	}
+	return column.BoardID, nil
+}
+
+// getCard загружает карточку, если она видна пользователю запроса.
+func (s *CommentService) getCard(ctx context.Context, id uint) (*models.Card, error) {
+	if err := s.visibility.Check(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.cardRepo.GetByID(ctx, id)
+}
+
+// getComment загружает комментарий, если его карточка видна пользователю запроса.
+func (s *CommentService) getComment(ctx context.Context, id uint) (*models.Comment, error) {
+	comment, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.visibility.Check(ctx, comment.CardID); err != nil {
+		if errors.Is(err, models.ErrCardNotFound) {
+			return nil, models.ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+// record записывает событие комментария в outbox. Если колонка карточки неизвестна, она загружается.
+func (s *CommentService) record(ctx context.Context, eventType string, columnID uint, payload CommentPayload) error {
+	if columnID == 0 {
+		card, err := s.cardRepo.GetByID(ctx, payload.CardID)
+		if err != nil {
+			return err
+		}
+		columnID = card.ColumnID
+	}
+
+	column, err := s.columnRepo.GetByID(ctx, columnID)
+	if err != nil {
+		return err
+	}
+
+	return s.events.Record(ctx, newBoardEvent(ctx, eventType, column.BoardID, payload))
+}
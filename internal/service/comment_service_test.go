@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+	"gorm.io/gorm"
+)
+
+type fakeCommentRepo struct {
+	repository.CommentRepository
+	comments  map[uint]*models.Comment
+	revisions map[uint][]models.CommentRevision
+	purged    []uint
+}
+
+func (r *fakeCommentRepo) Create(ctx context.Context, comment *models.Comment) error {
+	comment.ID = uint(len(r.comments) + 1)
+	r.comments[comment.ID] = comment
+	return nil
+}
+
+func (r *fakeCommentRepo) GetByID(ctx context.Context, id uint) (*models.Comment, error) {
+	comment, ok := r.comments[id]
+	if !ok || comment.DeletedAt.Valid {
+		return nil, models.ErrCommentNotFound
+	}
+	copied := *comment
+	return &copied, nil
+}
+
+func (r *fakeCommentRepo) Update(ctx context.Context, comment *models.Comment, editedBy uint) (bool, error) {
+	stored := r.comments[comment.ID]
+	if stored.Content == comment.Content {
+		return false, nil
+	}
+	now := time.Now()
+	stored.Content = comment.Content
+	stored.EditedAt = &now
+	stored.Version++
+	comment.EditedAt = &now
+	comment.Version = stored.Version
+	return true, nil
+}
+
+func (r *fakeCommentRepo) GetByIDUnscoped(ctx context.Context, id uint) (*models.Comment, error) {
+	comment, ok := r.comments[id]
+	if !ok {
+		return nil, models.ErrCommentNotFound
+	}
+	copied := *comment
+	return &copied, nil
+}
+
+func (r *fakeCommentRepo) GetRevisions(ctx context.Context, commentID uint) ([]models.CommentRevision, error) {
+	return r.revisions[commentID], nil
+}
+
+func (r *fakeCommentRepo) Purge(ctx context.Context, id uint) error {
+	r.purged = append(r.purged, id)
+	return nil
+}
+
+func TestCommentServicePurgeOnDeletedCard(t *testing.T) {
+	deleted := gorm.DeletedAt{Time: time.Now(), Valid: true}
+	comments := &fakeCommentRepo{comments: map[uint]*models.Comment{
+		1: {ID: 1, CardID: 10, UserID: 2, DeletedAt: deleted},
+	}}
+	cards := &fakeCardRepo{cards: map[uint]*models.Card{10: {ID: 10, ColumnID: 5, DeletedAt: deleted}}}
+	columns := &fakeColumnRepo{columns: map[uint]*models.Column{5: {ID: 5, BoardID: 1, DeletedAt: deleted}}}
+	members := &fakeMemberRepo{}
+	outbox := &fakeOutbox{}
+	mentions := NewMentionService(&fakeMentionRepo{}, members, cards, columns, &recordingNotifier{}, nil)
+
+	service := NewCommentService(comments, cards, columns, nil, mentions,
+		&fakeMemberService{admins: map[uint]uint{1: 4}}, nil, nil, outbox,
+		NewCardVisibility(members, &fakeGuestRepo{members: members}))
+
+	if err := service.Purge(WithActor(context.Background(), 3), 1); !errors.Is(err, models.ErrInsufficientAccess) {
+		t.Fatalf("Purge by a member: error = %v, want ErrInsufficientAccess", err)
+	}
+	if err := service.Purge(WithActor(context.Background(), 4), 1); err != nil {
+		t.Fatalf("Purge by an admin: %v", err)
+	}
+	if !slices.Equal(comments.purged, []uint{1}) {
+		t.Errorf("purged comments = %v, want [1]", comments.purged)
+	}
+	if got := outbox.types(); !slices.Equal(got, []string{EventCommentPurged}) {
+		t.Errorf("recorded events = %v, want [%s]", got, EventCommentPurged)
+	}
+}
+
+func TestCommentServiceGetRevisions(t *testing.T) {
+	deleted := gorm.DeletedAt{Time: time.Now(), Valid: true}
+	comments := &fakeCommentRepo{
+		comments: map[uint]*models.Comment{
+			1: {ID: 1, CardID: 10, UserID: 2},
+			2: {ID: 2, CardID: 10, UserID: 2, DeletedAt: deleted},
+		},
+		revisions: map[uint][]models.CommentRevision{
+			1: {{ID: 1, CommentID: 1, Content: "first"}},
+			2: {{ID: 2, CommentID: 2, Content: "removed"}},
+		},
+	}
+	cards := &fakeCardRepo{cards: map[uint]*models.Card{10: {ID: 10, ColumnID: 5}}}
+	columns := &fakeColumnRepo{columns: map[uint]*models.Column{5: {ID: 5, BoardID: 1}}}
+	members := &fakeMemberRepo{members: []models.BoardMember{
+		{BoardID: 1, UserID: 3, Role: models.BoardRoleMember},
+		{BoardID: 1, UserID: 4, Role: models.BoardRoleAdmin},
+		{BoardID: 1, UserID: 5, Role: models.BoardRoleGuest},
+	}}
+	service := NewCommentService(comments, cards, columns, nil, nil,
+		&fakeMemberService{admins: map[uint]uint{1: 4}}, nil, nil, &fakeOutbox{},
+		NewCardVisibility(members, &fakeGuestRepo{members: members}))
+
+	tests := []struct {
+		name      string
+		actorID   uint
+		commentID uint
+		wantErr   error
+	}{
+		{name: "member reads a comment", actorID: 3, commentID: 1},
+		{name: "member reads a deleted comment", actorID: 3, commentID: 2, wantErr: models.ErrCommentNotFound},
+		{name: "admin reads a deleted comment", actorID: 4, commentID: 2},
+		{name: "card hidden from guest", actorID: 5, commentID: 1, wantErr: models.ErrCommentNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revisions, err := service.GetRevisions(WithActor(context.Background(), tt.actorID), tt.commentID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetRevisions error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (len(revisions) != 1 || revisions[0].CommentID != tt.commentID) {
+				t.Errorf("revisions = %+v", revisions)
+			}
+		})
+	}
+}
+
+func TestCommentServiceCreateSavesMentionsInTransaction(t *testing.T) {
+	members := &fakeMemberRepo{members: []models.BoardMember{
+		{BoardID: 1, UserID: 1, Role: models.BoardRoleMember, User: models.User{ID: 1, Email: "alice@example.com"}},
+		{BoardID: 1, UserID: 2, Role: models.BoardRoleMember, User: models.User{ID: 2, Email: "bob@example.com"}},
+	}}
+	cards := &fakeCardRepo{cards: map[uint]*models.Card{10: {ID: 10, ColumnID: 5}}}
+	columns := &fakeColumnRepo{columns: map[uint]*models.Column{5: {ID: 5, BoardID: 1}}}
+	users := &fakeUserRepo{users: map[uint]*models.User{2: {ID: 2, Name: "Bob"}}}
+	visibility := NewCardVisibility(members, &fakeGuestRepo{members: members})
+
+	tests := []struct {
+		name       string
+		mentionErr error
+		wantEvents []string
+		wantNotify []uint
+	}{
+		{name: "saved", wantEvents: []string{EventCommentCreated}, wantNotify: []uint{1}},
+		{name: "mentions fail", mentionErr: errors.New("mentions unavailable")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := &fakeOutbox{}
+			notifier := &recordingNotifier{}
+			mentions := NewMentionService(&fakeMentionRepo{err: tt.mentionErr}, members, cards, columns, notifier, visibility)
+			service := NewCommentService(&fakeCommentRepo{comments: map[uint]*models.Comment{}}, cards, columns, users,
+				mentions, nil, plainRenderer{}, &fakeWatcherService{}, outbox, visibility)
+
+			comment := &models.Comment{CardID: 10, UserID: 2, Content: "@alice take a look"}
+			err := service.Create(WithActor(context.Background(), 2), comment)
+			if !errors.Is(err, tt.mentionErr) {
+				t.Fatalf("Create error = %v, want %v", err, tt.mentionErr)
+			}
+
+			// Ошибка сохранения упоминаний откатывает транзакцию вместе с событием комментария.
+			if got := outbox.types(); !slices.Equal(got, tt.wantEvents) {
+				t.Errorf("recorded events = %v, want %v", got, tt.wantEvents)
+			}
+			if got := notifier.userIDs(); !slices.Equal(got, tt.wantNotify) {
+				t.Errorf("notified users = %v, want %v", got, tt.wantNotify)
+			}
+			if notifier.inTx {
+				t.Error("mentioned users were notified before the transaction committed")
+			}
+		})
+	}
+}
+
+func TestCommentServiceUpdateSkipsUnchangedContent(t *testing.T) {
+	members := &fakeMemberRepo{members: []models.BoardMember{
+		{BoardID: 1, UserID: 1, Role: models.BoardRoleMember, User: models.User{ID: 1, Email: "alice@example.com"}},
+		{BoardID: 1, UserID: 2, Role: models.BoardRoleMember, User: models.User{ID: 2, Email: "bob@example.com"}},
+		{BoardID: 1, UserID: 3, Role: models.BoardRoleMember, User: models.User{ID: 3, Email: "carol@example.com"}},
+	}}
+	cards := &fakeCardRepo{cards: map[uint]*models.Card{10: {ID: 10, ColumnID: 5}}}
+	columns := &fakeColumnRepo{columns: map[uint]*models.Column{5: {ID: 5, BoardID: 1}}}
+	visibility := NewCardVisibility(members, &fakeGuestRepo{members: members})
+	comments := &fakeCommentRepo{comments: map[uint]*models.Comment{
+		1: {ID: 1, CardID: 10, UserID: 2, Content: "@alice take a look", Version: 1},
+	}}
+	mentionRepo := &fakeMentionRepo{mentions: map[string][]models.Mention{
+		"comment:1": {{SourceType: models.MentionSourceComment, SourceID: 1, UserID: 1, Handle: "alice", Start: 0, End: 6}},
+	}}
+
+	tests := []struct {
+		name       string
+		content    string
+		wantEvents []string
+		wantNotify []uint
+		wantChange bool
+	}{
+		{name: "unchanged", content: "@alice take a look"},
+		{name: "edited", content: "@alice take a look, @carol too", wantEvents: []string{EventCommentUpdated}, wantNotify: []uint{3}, wantChange: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := &fakeOutbox{}
+			notifier := &recordingNotifier{}
+			watchers := &fakeWatcherService{}
+			mentions := NewMentionService(mentionRepo, members, cards, columns, notifier, visibility)
+			service := NewCommentService(comments, cards, columns, nil, mentions, nil, plainRenderer{}, watchers, outbox, visibility)
+
+			comment := &models.Comment{ID: 1, Content: tt.content}
+			if err := service.Update(WithActor(context.Background(), 2), comment); err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+
+			if got := outbox.types(); !slices.Equal(got, tt.wantEvents) {
+				t.Errorf("recorded events = %v, want %v", got, tt.wantEvents)
+			}
+			if got := notifier.userIDs(); !slices.Equal(got, tt.wantNotify) {
+				t.Errorf("notified users = %v, want %v", got, tt.wantNotify)
+			}
+			if got := len(watchers.changes) > 0; got != tt.wantChange {
+				t.Errorf("watchers notified = %v, want %v", got, tt.wantChange)
+			}
+			if len(comment.Mentions) == 0 || comment.Mentions[0].UserID != 1 {
+				t.Errorf("comment mentions = %+v, want alice first", comment.Mentions)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+)
+
+type actorKey struct{}
+
+type expectedVersionsKey struct{}
+
+// WithActor сохраняет в контексте ID пользователя, выполняющего запрос.
+func WithActor(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext возвращает ID пользователя, выполняющего запрос, если он известен.
+func ActorFromContext(ctx context.Context) (uint, bool) {
+	userID, ok := ctx.Value(actorKey{}).(uint)
+	return userID, ok && userID != 0
+}
+
+// WithExpectedVersions сохраняет в контексте версии ресурса, с которыми клиент готов его изменить
+// (заголовок If-Match). Пустой список означает, что ни одна версия не подходит.
+func WithExpectedVersions(ctx context.Context, versions []int) context.Context {
+	return context.WithValue(ctx, expectedVersionsKey{}, versions)
+}
+
+// checkVersion возвращает ErrVersionConflict, если клиент ожидал другую версию ресурса.
+// Без ожидаемых версий в контексте подходит любая.
+func checkVersion(ctx context.Context, current int) error {
+	versions, ok := ctx.Value(expectedVersionsKey{}).([]int)
+	if !ok || slices.Contains(versions, current) {
+		return nil
+	}
+	return models.ErrVersionConflict
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+)
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{name: "no If-Match", ctx: context.Background()},
+		{name: "current version", ctx: WithExpectedVersions(context.Background(), []int{3})},
+		{name: "one of several", ctx: WithExpectedVersions(context.Background(), []int{1, 3})},
+		{name: "stale version", ctx: WithExpectedVersions(context.Background(), []int{2}), want: models.ErrVersionConflict},
+		// Заголовок был, но в нем не нашлось ни одной версии, например только слабые ETag.
+		{name: "no usable versions", ctx: WithExpectedVersions(context.Background(), []int{}), want: models.ErrVersionConflict},
+	}
+	for _, tt := range tests {
+		if err := checkVersion(tt.ctx, 3); !errors.Is(err, tt.want) {
+			t.Errorf("%s: checkVersion error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,424 @@
+package service
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+	"github.com/octaview/kanban-octaview/pkg/mailer"
+)
+
+//go:embed templates/email/*.tmpl
+var emailTemplateFS embed.FS
+
+const (
+	emailBatchSize   = 50
+	emailClaimLease  = 5 * time.Minute
+	emailBaseBackoff = time.Minute
+	emailMaxBackoff  = time.Hour
+)
+
+// immediateEmailTypes — уведомления, которые отправляются письмом сразу, а не только в дайджесте.
+var immediateEmailTypes = map[string]string{
+	NotificationCardAssigned: "You were assigned to a card",
+	NotificationMention:      "You were mentioned",
+}
+
+// LoadEmailTemplates загружает встроенные шаблоны писем.
+func LoadEmailTemplates() (*mailer.Templates, error) {
+	fsys, err := fs.Sub(emailTemplateFS, "templates/email")
+	if err != nil {
+		return nil, err
+	}
+	return mailer.NewTemplates(fsys)
+}
+
+type emailLinks struct {
+	baseURL string
+}
+
+func (l emailLinks) card(id uint) string {
+	return fmt.Sprintf("%s/cards/%d", l.baseURL, id)
+}
+
+func (l emailLinks) board(id uint) string {
+	return fmt.Sprintf("%s/boards/%d", l.baseURL, id)
+}
+
+func (l emailLinks) inbox() string {
+	return l.baseURL + "/notifications"
+}
+
+// EmailNotifier ставит в очередь письма о назначениях и упоминаниях.
+type EmailNotifier struct {
+	emailRepo        repository.EmailRepository
+	notificationRepo repository.NotificationRepository
+	userRepo         repository.UserRepository
+	templates        *mailer.Templates
+	links            emailLinks
+}
+
+func NewEmailNotifier(
+	emailRepo repository.EmailRepository,
+	notificationRepo repository.NotificationRepository,
+	userRepo repository.UserRepository,
+	templates *mailer.Templates,
+	baseURL string,
+) *EmailNotifier {
+	return &EmailNotifier{
+		emailRepo:        emailRepo,
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		templates:        templates,
+		links:            emailLinks{baseURL: strings.TrimRight(baseURL, "/")},
+	}
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, event NotificationEvent) {
+	title, ok := immediateEmailTypes[event.Type]
+	if !ok {
+		return
+	}
+
+	muted, err := n.notificationRepo.IsMuted(ctx, event.UserID, event.Type, event.BoardID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check notification preferences", slog.Any("error", err))
+		return
+	}
+	if muted {
+		return
+	}
+
+	pref, err := n.emailRepo.GetPreference(ctx, event.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get email preference", slog.Any("error", err))
+		return
+	}
+	if pref != nil && !pref.Immediate {
+		return
+	}
+
+	user, err := n.userRepo.GetByID(ctx, event.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get notification recipient", slog.Any("error", err))
+		return
+	}
+
+	var url string
+	if event.CardID != 0 {
+		url = n.links.card(event.CardID)
+	}
+
+	msg, err := n.templates.Render("notification", map[string]any{
+		"Title":   title,
+		"Name":    user.Name,
+		"Message": event.Message,
+		"URL":     url,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to render notification email", slog.Any("error", err))
+		return
+	}
+
+	if err := n.emailRepo.Enqueue(ctx, &models.OutboundEmail{
+		UserID:    user.ID,
+		Kind:      models.EmailKindImmediate,
+		ToAddress: (&mail.Address{Name: user.Name, Address: user.Email}).String(),
+		Subject:   msg.Subject,
+		TextBody:  msg.Text,
+		HTMLBody:  msg.HTML,
+	}); err != nil {
+		slog.ErrorContext(ctx, "failed to enqueue notification email", slog.Any("error", err))
+	}
+}
+
+// EmailDispatcher отправляет письма из очереди, повторяя неудачные попытки
+// с экспоненциальной задержкой.
+type EmailDispatcher struct {
+	emailRepo   repository.EmailRepository
+	sender      mailer.Sender
+	from        string
+	maxAttempts int
+}
+
+func NewEmailDispatcher(emailRepo repository.EmailRepository, sender mailer.Sender, from string, maxAttempts int) *EmailDispatcher {
+	return &EmailDispatcher{
+		emailRepo:   emailRepo,
+		sender:      sender,
+		from:        from,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Run обрабатывает очередь с заданным интервалом, пока не будет отменен контекст.
+func (d *EmailDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.Dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch отправляет все письма, готовые к отправке на данный момент.
+func (d *EmailDispatcher) Dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := time.Now()
+		emails, err := d.emailRepo.ClaimDue(ctx, now, emailBatchSize, emailClaimLease)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to claim emails", slog.Any("error", err))
+			return
+		}
+
+		for _, email := range emails {
+			d.send(ctx, email)
+		}
+
+		if len(emails) < emailBatchSize {
+			return
+		}
+	}
+}
+
+func (d *EmailDispatcher) send(ctx context.Context, email models.OutboundEmail) {
+	err := d.sender.Send(ctx, &mailer.Message{
+		From:    d.from,
+		To:      []string{email.ToAddress},
+		Subject: email.Subject,
+		Text:    email.TextBody,
+		HTML:    email.HTMLBody,
+	})
+	if err == nil {
+		if err := d.emailRepo.MarkSent(ctx, email.ID, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "failed to mark email sent", slog.Any("error", err))
+		}
+		return
+	}
+
+	var nextAttempt time.Time
+	attempts := email.Attempts + 1
+	if attempts < d.maxAttempts {
+		nextAttempt = time.Now().Add(emailBackoff(attempts))
+	}
+
+	slog.WarnContext(ctx, "failed to send email",
+		slog.Uint64("email_id", uint64(email.ID)),
+		slog.Int("attempt", attempts),
+		slog.Bool("final", nextAttempt.IsZero()),
+		slog.Any("error", err),
+	)
+
+	if err := d.emailRepo.MarkAttemptFailed(ctx, email.ID, err.Error(), nextAttempt); err != nil {
+		slog.ErrorContext(ctx, "failed to record email attempt", slog.Any("error", err))
+	}
+}
+
+func emailBackoff(attempts int) time.Duration {
+	backoff := emailBaseBackoff << (attempts - 1)
+	if backoff <= 0 || backoff > emailMaxBackoff {
+		return emailMaxBackoff
+	}
+	return backoff
+}
+
+// DigestScheduler раз в день или неделю собирает для пользователей сводку изменений
+// на их досках и ставит ее в очередь писем.
+type DigestScheduler struct {
+	emailRepo        repository.EmailRepository
+	memberRepo       repository.BoardMemberRepository
+	boardRepo        repository.BoardRepository
+	cardRepo         repository.CardRepository
+	notificationRepo repository.NotificationRepository
+	userRepo         repository.UserRepository
+	visibility       *CardVisibility
+	templates        *mailer.Templates
+	links            emailLinks
+	hour             int
+}
+
+func NewDigestScheduler(
+	repos *repository.Repositories,
+	visibility *CardVisibility,
+	templates *mailer.Templates,
+	baseURL string,
+	hour int,
+) *DigestScheduler {
+	return &DigestScheduler{
+		emailRepo:        repos.Email,
+		memberRepo:       repos.Member,
+		boardRepo:        repos.Board,
+		cardRepo:         repos.Card,
+		notificationRepo: repos.Notification,
+		userRepo:         repos.User,
+		visibility:       visibility,
+		templates:        templates,
+		links:            emailLinks{baseURL: strings.TrimRight(baseURL, "/")},
+		hour:             hour,
+	}
+}
+
+func (s *DigestScheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.Schedule(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Schedule отправляет дайджесты, срок которых наступил к моменту now.
+// Дайджесты формируются в час s.hour по UTC, еженедельные — по понедельникам.
+func (s *DigestScheduler) Schedule(ctx context.Context, now time.Time) {
+	now = now.UTC()
+	daily := time.Date(now.Year(), now.Month(), now.Day(), s.hour, 0, 0, 0, time.UTC)
+	if now.Before(daily) {
+		daily = daily.AddDate(0, 0, -1)
+	}
+	weekly := daily.AddDate(0, 0, -((int(daily.Weekday()) + 6) % 7))
+
+	s.scheduleDigests(ctx, models.DigestDaily, daily, daily.AddDate(0, 0, -1))
+	s.scheduleDigests(ctx, models.DigestWeekly, weekly, weekly.AddDate(0, 0, -7))
+}
+
+func (s *DigestScheduler) scheduleDigests(ctx context.Context, digest string, boundary, defaultSince time.Time) {
+	prefs, err := s.emailRepo.GetDigestRecipients(ctx, digest, boundary)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get digest recipients", slog.Any("error", err))
+		return
+	}
+
+	for _, pref := range prefs {
+		since := defaultSince
+		if pref.LastDigestAt != nil && pref.LastDigestAt.After(since) {
+			since = *pref.LastDigestAt
+		}
+
+		if err := s.enqueueDigest(ctx, pref.UserID, digest, since, boundary); err != nil {
+			slog.ErrorContext(ctx, "failed to build digest",
+				slog.Uint64("user_id", uint64(pref.UserID)),
+				slog.Any("error", err),
+			)
+			continue
+		}
+
+		if err := s.emailRepo.SetLastDigest(ctx, pref.UserID, boundary); err != nil {
+			slog.ErrorContext(ctx, "failed to update digest time", slog.Any("error", err))
+		}
+	}
+}
+
+type digestCard struct {
+	Title string
+	URL   string
+}
+
+type digestBoard struct {
+	Title   string
+	URL     string
+	Created []digestCard
+	Updated []digestCard
+}
+
+func (s *DigestScheduler) enqueueDigest(ctx context.Context, userID uint, digest string, since, until time.Time) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	boardIDs, err := s.memberRepo.GetUserBoardIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	cards, err := s.cardRepo.GetUpdatedSince(ctx, boardIDs, since)
+	if err != nil {
+		return err
+	}
+	// Задача выполняется без пользователя запроса, поэтому видимость проверяется от имени
+	// получателя: гостю доски не нужно знать о закрытых от него карточках.
+	cards, err = s.visibility.Filter(WithActor(ctx, userID), cards)
+	if err != nil {
+		return err
+	}
+
+	boards := make(map[uint]*digestBoard)
+	for _, card := range cards {
+		if card.UpdatedAt.After(until) {
+			continue
+		}
+
+		boardID := card.Column.BoardID
+		b, ok := boards[boardID]
+		if !ok {
+			board, err := s.boardRepo.GetByID(ctx, boardID)
+			if err != nil {
+				continue
+			}
+			b = &digestBoard{Title: board.Title, URL: s.links.board(boardID)}
+			boards[boardID] = b
+		}
+
+		item := digestCard{Title: card.Title, URL: s.links.card(card.ID)}
+		if card.CreatedAt.After(since) {
+			b.Created = append(b.Created, item)
+		} else {
+			b.Updated = append(b.Updated, item)
+		}
+	}
+
+	unread, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(boards) == 0 && unread == 0 {
+		return nil
+	}
+
+	sorted := make([]digestBoard, 0, len(boards))
+	for _, b := range boards {
+		sorted = append(sorted, *b)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Title < sorted[j].Title })
+
+	msg, err := s.templates.Render("digest", map[string]any{
+		"Name":        user.Name,
+		"Period":      digest,
+		"Since":       since,
+		"Boards":      sorted,
+		"UnreadCount": unread,
+		"InboxURL":    s.links.inbox(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.emailRepo.Enqueue(ctx, &models.OutboundEmail{
+		UserID:    user.ID,
+		Kind:      models.EmailKindDigest,
+		ToAddress: (&mail.Address{Name: user.Name, Address: user.Email}).String(),
+		Subject:   msg.Subject,
+		TextBody:  msg.Text,
+		HTMLBody:  msg.HTML,
+	})
+}
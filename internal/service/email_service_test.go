@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+	"github.com/octaview/kanban-octaview/pkg/mailer"
+	"github.com/octaview/kanban-octaview/pkg/mailer/smtptest"
+)
+
+type fakeEmailRepo struct {
+	repository.EmailRepository
+	enqueued []models.OutboundEmail
+}
+
+func (r *fakeEmailRepo) Enqueue(ctx context.Context, email *models.OutboundEmail) error {
+	email.ID = uint(len(r.enqueued) + 1)
+	email.Status = models.EmailStatusPending
+	r.enqueued = append(r.enqueued, *email)
+	return nil
+}
+
+func (r *fakeEmailRepo) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.OutboundEmail, error) {
+	var emails []models.OutboundEmail
+	for i := range r.enqueued {
+		email := &r.enqueued[i]
+		if email.Status == models.EmailStatusPending && !email.NextAttemptAt.After(now) && len(emails) < limit {
+			email.NextAttemptAt = now.Add(lease)
+			emails = append(emails, *email)
+		}
+	}
+	return emails, nil
+}
+
+func (r *fakeEmailRepo) MarkSent(ctx context.Context, id uint, sentAt time.Time) error {
+	r.enqueued[id-1].Status = models.EmailStatusSent
+	r.enqueued[id-1].SentAt = &sentAt
+	return nil
+}
+
+func (r *fakeEmailRepo) MarkAttemptFailed(ctx context.Context, id uint, lastError string, nextAttempt time.Time) error {
+	email := &r.enqueued[id-1]
+	email.Attempts++
+	email.LastError = lastError
+	if nextAttempt.IsZero() {
+		email.Status = models.EmailStatusFailed
+	} else {
+		email.NextAttemptAt = nextAttempt
+	}
+	return nil
+}
+
+type fakeNotificationRepo struct {
+	repository.NotificationRepository
+}
+
+func (r *fakeNotificationRepo) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	return 0, nil
+}
+
+func TestDigestSchedulerHidesCardsFromGuests(t *testing.T) {
+	since := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+	updated := since.Add(time.Hour)
+
+	members := &fakeMemberRepo{members: []models.BoardMember{
+		{BoardID: 1, UserID: 1, Role: models.BoardRoleMember},
+		{BoardID: 1, UserID: 2, Role: models.BoardRoleGuest},
+	}}
+	guests := &fakeGuestRepo{members: members, cards: map[uint][]uint{2: {10}}}
+	column := models.Column{ID: 5, BoardID: 1}
+	cards := &fakeCardRepo{cards: map[uint]*models.Card{
+		10: {ID: 10, Title: "Shared with vendor", Column: column, CreatedAt: since.Add(-time.Hour), UpdatedAt: updated},
+		11: {ID: 11, Title: "Internal pricing", Column: column, CreatedAt: since.Add(-time.Hour), UpdatedAt: updated},
+	}}
+	emails := &fakeEmailRepo{}
+	users := &fakeUserRepo{users: map[uint]*models.User{
+		1: {ID: 1, Name: "Alice", Email: "alice@example.com"},
+		2: {ID: 2, Name: "Vendor", Email: "vendor@example.com"},
+	}}
+
+	templates, err := LoadEmailTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheduler := NewDigestScheduler(&repository.Repositories{
+		Email:        emails,
+		Member:       members,
+		Board:        &fakeBoardRepo{boards: map[uint]*models.Board{1: {ID: 1, Title: "Launch"}}},
+		Card:         cards,
+		Notification: &fakeNotificationRepo{},
+		User:         users,
+	}, NewCardVisibility(members, guests), templates, "https://kanban.example.com", 8)
+
+	tests := []struct {
+		userID uint
+		want   []string
+		hidden []string
+	}{
+		{userID: 1, want: []string{"Shared with vendor", "Internal pricing"}},
+		{userID: 2, want: []string{"Shared with vendor"}, hidden: []string{"Internal pricing", "/cards/11"}},
+	}
+	for _, tt := range tests {
+		emails.enqueued = nil
+		if err := scheduler.enqueueDigest(context.Background(), tt.userID, models.DigestDaily, since, until); err != nil {
+			t.Fatalf("user %d: enqueueDigest: %v", tt.userID, err)
+		}
+		if len(emails.enqueued) != 1 {
+			t.Fatalf("user %d: enqueued %d emails, want 1", tt.userID, len(emails.enqueued))
+		}
+
+		email := emails.enqueued[0]
+		for _, body := range []string{email.TextBody, email.HTMLBody} {
+			for _, text := range tt.want {
+				if !strings.Contains(body, text) {
+					t.Errorf("user %d: digest does not mention %q:\n%s", tt.userID, text, body)
+				}
+			}
+			for _, text := range tt.hidden {
+				if strings.Contains(body, text) {
+					t.Errorf("user %d: digest leaks %q:\n%s", tt.userID, text, body)
+				}
+			}
+		}
+	}
+}
+
+func TestEmailDispatcherRetriesFailedSend(t *testing.T) {
+	server, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	server.FailNext(1)
+
+	emails := &fakeEmailRepo{}
+	if err := emails.Enqueue(context.Background(), &models.OutboundEmail{
+		ToAddress: "anna@example.com",
+		Subject:   "Reminder",
+		TextBody:  "Card is due",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sender := mailer.NewSMTPSender(mailer.Config{Host: server.Host(), Port: server.Port()})
+	dispatcher := NewEmailDispatcher(emails, sender, "noreply@kanban.example.com", 3)
+
+	start := time.Now()
+	dispatcher.Dispatch(context.Background())
+
+	email := emails.enqueued[0]
+	if email.Status != models.EmailStatusPending || email.Attempts != 1 || email.LastError == "" {
+		t.Fatalf("after failure: status %s, attempts %d, error %q", email.Status, email.Attempts, email.LastError)
+	}
+	if !email.NextAttemptAt.After(start.Add(emailBaseBackoff - time.Second)) {
+		t.Errorf("next attempt at %v, want about %v later", email.NextAttemptAt, emailBaseBackoff)
+	}
+
+	// До следующей попытки письмо не отправляется повторно.
+	dispatcher.Dispatch(context.Background())
+	if got := emails.enqueued[0].Attempts; got != 1 || len(server.Messages()) != 0 {
+		t.Fatalf("retried before backoff: attempts %d, messages %d", got, len(server.Messages()))
+	}
+
+	emails.enqueued[0].NextAttemptAt = start
+	dispatcher.Dispatch(context.Background())
+
+	if email := emails.enqueued[0]; email.Status != models.EmailStatusSent || email.SentAt == nil {
+		t.Fatalf("after retry: status %s, sent at %v", email.Status, email.SentAt)
+	}
+	messages := server.Messages()
+	if len(messages) != 1 || messages[0].To[0] != "anna@example.com" {
+		t.Fatalf("server received %+v", messages)
+	}
+}
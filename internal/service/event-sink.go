@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/repository"
+	"github.com/octaview/kanban-octaview/pkg/natspub"
+)
+
+const (
+	boardEventsChannel    = "board_events"
+	boardBroadcastChannel = "board_broadcast"
+	brokerReconnectPeriod = 5 * time.Second
+)
+
+// EventBroker доставляет опубликованные события подписчикам на всех экземплярах сервера.
+type EventBroker interface {
+	EventSink
+	// Broadcast доставляет подписчикам эфемерное событие в обход outbox и журнала:
+	// у него нет Seq, оно не повторяется при переподключении и не уходит в вебхуки.
+	Broadcast(ctx context.Context, event BoardEvent) error
+	// Subscribe регистрирует обработчик и возвращает функцию отписки.
+	// Обработчик вызывается синхронно и не должен блокироваться.
+	Subscribe(handler func(BoardEvent)) func()
+}
+
+// EventBus раздает события досок подписчикам внутри процесса. Подходит как брокер,
+// если сервер запущен в одном экземпляре.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[int]func(BoardEvent)
+	nextID      int
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: make(map[int]func(BoardEvent))}
+}
+
+func (b *EventBus) Name() string {
+	return "bus"
+}
+
+// Publish синхронно вызывает всех подписчиков, поэтому они не должны блокироваться.
+func (b *EventBus) Publish(ctx context.Context, event BoardEvent) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, handler := range b.subscribers {
+		handler(event)
+	}
+	return nil
+}
+
+func (b *EventBus) Broadcast(ctx context.Context, event BoardEvent) error {
+	return b.Publish(ctx, event)
+}
+
+func (b *EventBus) Subscribe(handler func(BoardEvent)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, id)
+	}
+}
+
+// PostgresBroker рассылает события всем экземплярам сервера через LISTEN/NOTIFY.
+// В уведомлении передается только Seq события, само событие читается из журнала,
+// поэтому размер события не ограничен размером уведомления. Эфемерные события
+// небольшие и передаются в уведомлении целиком.
+type PostgresBroker struct {
+	pubsub    repository.PubSubRepository
+	eventRepo repository.BoardEventRepository
+	local     *EventBus
+}
+
+func NewPostgresBroker(pubsub repository.PubSubRepository, eventRepo repository.BoardEventRepository) *PostgresBroker {
+	return &PostgresBroker{
+		pubsub:    pubsub,
+		eventRepo: eventRepo,
+		local:     NewEventBus(),
+	}
+}
+
+func (b *PostgresBroker) Name() string {
+	return "postgres"
+}
+
+func (b *PostgresBroker) Publish(ctx context.Context, event BoardEvent) error {
+	return b.pubsub.Notify(ctx, boardEventsChannel, strconv.FormatUint(event.Seq, 10))
+}
+
+func (b *PostgresBroker) Broadcast(ctx context.Context, event BoardEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event %s: %w", event.Type, err)
+	}
+	return b.pubsub.Notify(ctx, boardBroadcastChannel, string(payload))
+}
+
+func (b *PostgresBroker) Subscribe(handler func(BoardEvent)) func() {
+	return b.local.Subscribe(handler)
+}
+
+// Run слушает канал событий и раздает их локальным подписчикам, пока ctx не отменен.
+// После разрыва соединения подписка восстанавливается; события, опубликованные
+// во время разрыва, не доставляются.
+func (b *PostgresBroker) Run(ctx context.Context) {
+	for {
+		err := b.pubsub.Listen(ctx, []string{boardEventsChannel, boardBroadcastChannel}, func(channel, payload string) {
+			if channel == boardBroadcastChannel {
+				b.dispatchBroadcast(ctx, payload)
+				return
+			}
+			b.dispatch(ctx, payload)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		slog.ErrorContext(ctx, "board events subscription lost", slog.Any("error", err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(brokerReconnectPeriod):
+		}
+	}
+}
+
+func (b *PostgresBroker) dispatch(ctx context.Context, payload string) {
+	seq, err := strconv.ParseUint(payload, 10, 64)
+	if err != nil {
+		slog.ErrorContext(ctx, "invalid broadcast event notification", slog.String("payload", payload))
+		return
+	}
+
+	logged, err := b.eventRepo.GetByID(ctx, seq)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load broadcast event", slog.Uint64("seq", seq), slog.Any("error", err))
+		return
+	}
+
+	event, err := decodeBoardEvent(logged.Payload)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to decode broadcast event", slog.Uint64("seq", seq), slog.Any("error", err))
+		return
+	}
+	event.Seq = logged.ID
+
+	_ = b.local.Publish(ctx, event)
+}
+
+func (b *PostgresBroker) dispatchBroadcast(ctx context.Context, payload string) {
+	event, err := decodeBoardEvent(payload)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to decode ephemeral event", slog.Any("error", err))
+		return
+	}
+
+	_ = b.local.Publish(ctx, event)
+}
+
+// NATSSink публикует события в NATS в темы вида <prefix>.board.<board_id>.<type>.
+type NATSSink struct {
+	publisher *natspub.Publisher
+	prefix    string
+}
+
+func NewNATSSink(publisher *natspub.Publisher, prefix string) *NATSSink {
+	return &NATSSink{publisher: publisher, prefix: prefix}
+}
+
+func (s *NATSSink) Name() string {
+	return "nats"
+}
+
+func (s *NATSSink) Publish(ctx context.Context, event BoardEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("%s.board.%d.%s", s.prefix, event.BoardID, event.Type)
+	return s.publisher.Publish(ctx, subject, data)
+}
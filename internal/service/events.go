@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+)
+
+const (
+	EventCardCreated       = "card.created"
+	EventCardUpdated       = "card.updated"
+	EventCardMoved         = "card.moved"
+	EventCardReordered     = "card.reordered"
+	EventCardDeleted       = "card.deleted"
+	EventCardAssigned      = "card.assigned"
+	EventCardUnassigned    = "card.unassigned"
+	EventCardDueDateChange = "card.due_date_changed"
+	EventCardLabelsChanged = "card.labels_changed"
+
+	EventColumnCreated   = "column.created"
+	EventColumnUpdated   = "column.updated"
+	EventColumnDeleted   = "column.deleted"
+	EventColumnReordered = "column.reordered"
+
+	EventSwimlaneCreated   = "swimlane.created"
+	EventSwimlaneUpdated   = "swimlane.updated"
+	EventSwimlaneDeleted   = "swimlane.deleted"
+	EventSwimlaneReordered = "swimlane.reordered"
+
+	EventLabelCreated = "label.created"
+	EventLabelUpdated = "label.updated"
+	EventLabelDeleted = "label.deleted"
+
+	EventCommentCreated = "comment.created"
+	EventCommentUpdated = "comment.updated"
+	EventCommentDeleted = "comment.deleted"
+	EventCommentPurged  = "comment.purged"
+)
+
+// EventTypes — все типы событий досок, на которые можно подписаться.
+var EventTypes = []string{
+	EventCardCreated, EventCardUpdated, EventCardMoved, EventCardReordered, EventCardDeleted,
+	EventCardAssigned, EventCardUnassigned, EventCardDueDateChange, EventCardLabelsChanged,
+	EventColumnCreated, EventColumnUpdated, EventColumnDeleted, EventColumnReordered,
+	EventSwimlaneCreated, EventSwimlaneUpdated, EventSwimlaneDeleted, EventSwimlaneReordered,
+	EventLabelCreated, EventLabelUpdated, EventLabelDeleted,
+	EventCommentCreated, EventCommentUpdated, EventCommentDeleted, EventCommentPurged,
+}
+
+// BoardEvent — изменение на доске, о котором сообщается внешним потребителям.
+// Seq — порядковый номер, выданный при публикации; до публикации он равен нулю.
+type BoardEvent struct {
+	ID         string    `json:"id"`
+	Seq        uint64    `json:"seq,omitempty"`
+	Type       string    `json:"type"`
+	BoardID    uint      `json:"board_id"`
+	ActorID    uint      `json:"actor_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// EventOutbox сохраняет события досок в одной транзакции с изменением, которое их вызвало.
+type EventOutbox interface {
+	// InTransaction выполняет fn в транзакции. Изменения репозиториев и события,
+	// записанные с контекстом fn, фиксируются вместе.
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Record(ctx context.Context, event BoardEvent) error
+}
+
+// EventSink получает события из ретранслятора outbox. Доставка «хотя бы один раз»:
+// одно событие может прийти повторно, получатели различают их по ID.
+type EventSink interface {
+	Name() string
+	Publish(ctx context.Context, event BoardEvent) error
+}
+
+func newBoardEvent(ctx context.Context, eventType string, boardID uint, data any) BoardEvent {
+	actorID, _ := ActorFromContext(ctx)
+	return BoardEvent{
+		ID:         newEventID(),
+		Type:       eventType,
+		BoardID:    boardID,
+		ActorID:    actorID,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Полезная нагрузка событий не содержит вложенных связей моделей, чтобы формат
+// событий не зависел от того, что было загружено из базы.
+
+type CardPayload struct {
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Position    int        `json:"position"`
+	ColumnID    uint       `json:"column_id"`
+	SwimlaneID  *uint      `json:"swimlane_id,omitempty"`
+	AssignedTo  *uint      `json:"assigned_to,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func cardPayload(card *models.Card) CardPayload {
+	return CardPayload{
+		ID:          card.ID,
+		Title:       card.Title,
+		Description: card.Description,
+		Position:    card.Position,
+		ColumnID:    card.ColumnID,
+		SwimlaneID:  card.SwimlaneID,
+		AssignedTo:  card.AssignedTo,
+		DueDate:     card.DueDate,
+		StartedAt:   card.StartedAt,
+		CompletedAt: card.CompletedAt,
+		CreatedAt:   card.CreatedAt,
+		UpdatedAt:   card.UpdatedAt,
+	}
+}
+
+type ColumnPayload struct {
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Position int    `json:"position"`
+	BoardID  uint   `json:"board_id"`
+	Kind     string `json:"kind"`
+	WIPLimit *int   `json:"wip_limit,omitempty"`
+}
+
+func columnPayload(column *models.Column) ColumnPayload {
+	return ColumnPayload{
+		ID:       column.ID,
+		Title:    column.Title,
+		Position: column.Position,
+		BoardID:  column.BoardID,
+		Kind:     column.Kind,
+		WIPLimit: column.WIPLimit,
+	}
+}
+
+type SwimlanePayload struct {
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Position int    `json:"position"`
+	BoardID  uint   `json:"board_id"`
+}
+
+func swimlanePayload(swimlane *models.Swimlane) SwimlanePayload {
+	return SwimlanePayload{
+		ID:       swimlane.ID,
+		Title:    swimlane.Title,
+		Position: swimlane.Position,
+		BoardID:  swimlane.BoardID,
+	}
+}
+
+type LabelPayload struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Color   string `json:"color"`
+	BoardID uint   `json:"board_id"`
+}
+
+func labelPayload(label *models.Label) LabelPayload {
+	return LabelPayload{
+		ID:      label.ID,
+		Name:    label.Name,
+		Color:   label.Color,
+		BoardID: label.BoardID,
+	}
+}
+
+type CommentPayload struct {
+	ID        uint       `json:"id"`
+	CardID    uint       `json:"card_id"`
+	UserID    uint       `json:"user_id"`
+	Content   string     `json:"content,omitempty"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func commentPayload(comment *models.Comment) CommentPayload {
+	return CommentPayload{
+		ID:        comment.ID,
+		CardID:    comment.CardID,
+		UserID:    comment.UserID,
+		Content:   comment.Content,
+		EditedAt:  comment.EditedAt,
+		CreatedAt: comment.CreatedAt,
+	}
+}
+
+type CardMovedPayload struct {
+	Card           CardPayload `json:"card"`
+	FromColumnID   uint        `json:"from_column_id"`
+	ToColumnID     uint        `json:"to_column_id"`
+	FromSwimlaneID *uint       `json:"from_swimlane_id,omitempty"`
+	ToSwimlaneID   *uint       `json:"to_swimlane_id,omitempty"`
+}
+
+type CardsReorderedPayload struct {
+	ColumnID uint   `json:"column_id"`
+	CardIDs  []uint `json:"card_ids"`
+}
+
+type ColumnsReorderedPayload struct {
+	BoardID   uint   `json:"board_id"`
+	ColumnIDs []uint `json:"column_ids"`
+}
+
+type SwimlanesReorderedPayload struct {
+	BoardID     uint   `json:"board_id"`
+	SwimlaneIDs []uint `json:"swimlane_ids"`
+}
+
+type CardLabelsPayload struct {
+	CardID   uint   `json:"card_id"`
+	LabelIDs []uint `json:"label_ids"`
+}
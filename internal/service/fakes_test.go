@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+// Подделки репозиториев встраивают интерфейс: тест реализует только нужные ему методы,
+// вызов остальных паникует на nil-интерфейсе.
+
+type fakeCardRepo struct {
+	repository.CardRepository
+	cards map[uint]*models.Card
+}
+
+func (r *fakeCardRepo) GetByID(ctx context.Context, id uint) (*models.Card, error) {
+	card, ok := r.cards[id]
+	if !ok || card.DeletedAt.Valid {
+		return nil, models.ErrCardNotFound
+	}
+	copied := *card
+	return &copied, nil
+}
+
+// GetUpdatedSince возвращает карточки досок boardIDs; у карточек должна быть заполнена Column.
+func (r *fakeCardRepo) GetUpdatedSince(ctx context.Context, boardIDs []uint, since time.Time) ([]models.Card, error) {
+	var cards []models.Card
+	for _, id := range slices.Sorted(maps.Keys(r.cards)) {
+		card := r.cards[id]
+		if slices.Contains(boardIDs, card.Column.BoardID) && card.UpdatedAt.After(since) {
+			cards = append(cards, *card)
+		}
+	}
+	return cards, nil
+}
+
+func (r *fakeCardRepo) GetByIDUnscoped(ctx context.Context, id uint) (*models.Card, error) {
+	card, ok := r.cards[id]
+	if !ok {
+		return nil, models.ErrCardNotFound
+	}
+	copied := *card
+	return &copied, nil
+}
+
+type fakeColumnRepo struct {
+	repository.ColumnRepository
+	columns map[uint]*models.Column
+}
+
+func (r *fakeColumnRepo) GetByID(ctx context.Context, id uint) (*models.Column, error) {
+	column, ok := r.columns[id]
+	if !ok || column.DeletedAt.Valid {
+		return nil, models.ErrColumnNotFound
+	}
+	copied := *column
+	return &copied, nil
+}
+
+func (r *fakeColumnRepo) GetByIDUnscoped(ctx context.Context, id uint) (*models.Column, error) {
+	column, ok := r.columns[id]
+	if !ok {
+		return nil, models.ErrColumnNotFound
+	}
+	copied := *column
+	return &copied, nil
+}
+
+func (r *fakeColumnRepo) GetByBoardID(ctx context.Context, boardID uint) ([]models.Column, error) {
+	var columns []models.Column
+	for _, id := range slices.Sorted(maps.Keys(r.columns)) {
+		if column := r.columns[id]; column.BoardID == boardID && !column.DeletedAt.Valid {
+			columns = append(columns, *column)
+		}
+	}
+	return columns, nil
+}
+
+// fakeMemberRepo хранит участников одной доски.
+type fakeMemberRepo struct {
+	repository.BoardMemberRepository
+	members []models.BoardMember
+}
+
+func (r *fakeMemberRepo) Get(ctx context.Context, boardID, userID uint) (*models.BoardMember, error) {
+	for _, member := range r.members {
+		if member.BoardID == boardID && member.UserID == userID {
+			return &member, nil
+		}
+	}
+	return nil, models.ErrMemberNotFound
+}
+
+func (r *fakeMemberRepo) GetUserBoardIDs(ctx context.Context, userID uint) ([]uint, error) {
+	var ids []uint
+	for _, member := range r.members {
+		if member.UserID == userID {
+			ids = append(ids, member.BoardID)
+		}
+	}
+	return ids, nil
+}
+
+func (r *fakeMemberRepo) GetBoardUsers(ctx context.Context, boardID uint) ([]models.User, error) {
+	var users []models.User
+	for _, member := range r.members {
+		if member.BoardID == boardID {
+			users = append(users, member.User)
+		}
+	}
+	return users, nil
+}
+
+// fakeGuestRepo открывает гостям карточки из cards; гостями считаются участники members
+// с ролью guest. Колонки и доски карточек для подсчета берутся из cardRepo.
+type fakeGuestRepo struct {
+	repository.CardGuestRepository
+	members  *fakeMemberRepo
+	cards    map[uint][]uint
+	cardRepo *fakeCardRepo
+}
+
+func (r *fakeGuestRepo) isGuest(userID uint) bool {
+	for _, member := range r.members.members {
+		if member.UserID == userID && member.Role == models.BoardRoleGuest {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *fakeGuestRepo) GetHiddenCardIDs(ctx context.Context, userID uint, cardIDs []uint) ([]uint, error) {
+	if !r.isGuest(userID) {
+		return nil, nil
+	}
+	var hidden []uint
+	for _, cardID := range cardIDs {
+		if !slices.Contains(r.cards[userID], cardID) {
+			hidden = append(hidden, cardID)
+		}
+	}
+	return hidden, nil
+}
+
+func (r *fakeGuestRepo) CountByColumn(ctx context.Context, boardID, userID uint) (map[uint]int, error) {
+	counts := make(map[uint]int)
+	for _, cardID := range r.cards[userID] {
+		card, ok := r.cardRepo.cards[cardID]
+		if ok && !card.DeletedAt.Valid && card.Column.BoardID == boardID {
+			counts[card.ColumnID]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *fakeGuestRepo) Remove(ctx context.Context, cardID, userID uint) error {
+	r.cards[userID] = slices.DeleteFunc(r.cards[userID], func(id uint) bool { return id == cardID })
+	return nil
+}
+
+func (r *fakeGuestRepo) GetHiddenUserIDs(ctx context.Context, cardID uint, userIDs []uint) ([]uint, error) {
+	var hidden []uint
+	for _, userID := range userIDs {
+		if r.isGuest(userID) && !slices.Contains(r.cards[userID], cardID) {
+			hidden = append(hidden, userID)
+		}
+	}
+	return hidden, nil
+}
+
+type fakeMentionRepo struct {
+	repository.MentionRepository
+	mentions map[string][]models.Mention
+	// err возвращается из ReplaceForSource, если задана.
+	err error
+}
+
+func (r *fakeMentionRepo) key(sourceType string, sourceID uint) string {
+	return fmt.Sprintf("%s:%d", sourceType, sourceID)
+}
+
+func (r *fakeMentionRepo) ReplaceForSource(ctx context.Context, sourceType string, sourceID uint, mentions []models.Mention) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.mentions == nil {
+		r.mentions = map[string][]models.Mention{}
+	}
+	r.mentions[r.key(sourceType, sourceID)] = mentions
+	return nil
+}
+
+func (r *fakeMentionRepo) GetBySource(ctx context.Context, sourceType string, sourceID uint) ([]models.Mention, error) {
+	return r.mentions[r.key(sourceType, sourceID)], nil
+}
+
+// recordingNotifier запоминает отправленные уведомления.
+type recordingNotifier struct {
+	mu     sync.Mutex
+	events []NotificationEvent
+	// inTx отмечает уведомления, отправленные до фиксации транзакции.
+	inTx bool
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, event NotificationEvent) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, event)
+	n.inTx = n.inTx || inFakeTx(ctx)
+}
+
+func (n *recordingNotifier) userIDs() []uint {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ids := make([]uint, 0, len(n.events))
+	for _, event := range n.events {
+		ids = append(ids, event.UserID)
+	}
+	return ids
+}
+
+type txKey struct{}
+
+// fakeOutbox выполняет fn сразу и сохраняет события только успешных транзакций.
+type fakeOutbox struct {
+	mu     sync.Mutex
+	events []BoardEvent
+	// commits считает зафиксированные транзакции.
+	commits int
+}
+
+func inFakeTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*[]BoardEvent)
+	return ok
+}
+
+func (o *fakeOutbox) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inFakeTx(ctx) {
+		return fn(ctx)
+	}
+	var events []BoardEvent
+	if err := fn(context.WithValue(ctx, txKey{}, &events)); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, events...)
+	o.commits++
+	return nil
+}
+
+func (o *fakeOutbox) Record(ctx context.Context, event BoardEvent) error {
+	events, ok := ctx.Value(txKey{}).(*[]BoardEvent)
+	if !ok {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		o.events = append(o.events, event)
+		return nil
+	}
+	*events = append(*events, event)
+	return nil
+}
+
+func (o *fakeOutbox) types() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	types := make([]string, 0, len(o.events))
+	for _, event := range o.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// fakeMemberService разрешает администрирование досок из admins.
+type fakeMemberService struct {
+	BoardMemberServiceInterface
+	admins map[uint]uint
+}
+
+func (s *fakeMemberService) IsAdmin(ctx context.Context, boardID, userID uint) (bool, error) {
+	return s.admins[boardID] == userID, nil
+}
+
+type fakeBoardRepo struct {
+	repository.BoardRepository
+	boards   map[uint]*models.Board
+	archived map[uint]bool
+	touched  []uint
+}
+
+func (r *fakeBoardRepo) GetByID(ctx context.Context, id uint) (*models.Board, error) {
+	board, ok := r.boards[id]
+	if !ok {
+		return nil, models.ErrBoardNotFound
+	}
+	copied := *board
+	return &copied, nil
+}
+
+func (r *fakeBoardRepo) TouchActivity(ctx context.Context, boardID uint, at time.Time) error {
+	if r.archived[boardID] {
+		return models.ErrBoardArchived
+	}
+	r.touched = append(r.touched, boardID)
+	return nil
+}
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[uint]*models.User
+}
+
+func (r *fakeUserRepo) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, models.ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+// fakeWatcherService никого не подписывает и только запоминает изменения, о которых
+// оповестил бы наблюдателей.
+type fakeWatcherService struct {
+	CardWatcherServiceInterface
+	changes []CardChange
+}
+
+func (s *fakeWatcherService) AutoWatch(ctx context.Context, cardID, userID uint) {}
+
+func (s *fakeWatcherService) NotifyCardChange(ctx context.Context, change CardChange) {
+	s.changes = append(s.changes, change)
+}
+
+// plainRenderer возвращает исходный текст без разметки.
+type plainRenderer struct{}
+
+func (plainRenderer) Render(source string, mentions map[string]uint) string { return source }
@@ -11,12 +11,14 @@ import (
 type LabelService struct {
 	labelRepo repository.LabelRepository
 	boardRepo repository.BoardRepository
+	events    EventOutbox
 }
 
-func NewLabelService(labelRepo repository.LabelRepository, boardRepo repository.BoardRepository) *LabelService {
+func NewLabelService(labelRepo repository.LabelRepository, boardRepo repository.BoardRepository, events EventOutbox) *LabelService {
 	return &LabelService{
 		labelRepo: labelRepo,
 		boardRepo: boardRepo,
+		events:    events,
 	}
 }
 
@@ -37,7 +39,12 @@ func (s *LabelService) Create(ctx context.Context, label *models.Label) error {
 		return models.NewValidationError("name", "name is required")
 	}
 
-	return s.labelRepo.Create(ctx, label)
+	return s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.labelRepo.Create(ctx, label); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventLabelCreated, label.BoardID, labelPayload(label)))
+	})
 }
 
 func (s *LabelService) GetByID(ctx context.Context, id uint) (*models.Label, error) {
@@ -61,6 +68,10 @@ func (s *LabelService) Update(ctx context.Context, label *models.Label) error {
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(ctx, existingLabel.Version); err != nil {
+		return err
+	}
+	label.Version = existingLabel.Version
 
 	if label.BoardID != 0 && label.BoardID != existingLabel.BoardID {
 		return errors.New("cannot change board ID of a label")
@@ -69,6 +80,7 @@ func (s *LabelService) Update(ctx context.Context, label *models.Label) error {
 	if label.BoardID == 0 {
 		label.BoardID = existingLabel.BoardID
 	}
+	label.WorkspaceID = existingLabel.WorkspaceID
 
 	if label.Name == "" {
 		return models.NewValidationError("name", "name is required")
@@ -78,14 +90,67 @@ func (s *LabelService) Update(ctx context.Context, label *models.Label) error {
 		return models.NewValidationError("color", "color is required")
 	}
 
-	return s.labelRepo.Update(ctx, label)
+	return s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.labelRepo.Update(ctx, label); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventLabelUpdated, label.BoardID, labelPayload(label)))
+	})
 }
 
 func (s *LabelService) Delete(ctx context.Context, id uint) error {
-	_, err := s.labelRepo.GetByID(ctx, id)
+	label, err := s.labelRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(ctx, label.Version); err != nil {
+		return err
+	}
+
+	return s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.labelRepo.Delete(ctx, id, label.Version); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventLabelDeleted, label.BoardID, labelPayload(label)))
+	})
+}
+
+// Share делает метку общей для рабочего пространства ее доски (shared) или снова меткой только
+// своей доски. Когда метка перестает быть общей, она снимается с карточек других досок.
+func (s *LabelService) Share(ctx context.Context, id uint, shared bool) (*models.Label, error) {
+	label, err := s.labelRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(ctx, label.Version); err != nil {
+		return nil, err
+	}
 
-	return s.labelRepo.Delete(ctx, id)
-}
\ No newline at end of file
+	var workspaceID *uint
+	if shared {
+		board, err := s.boardRepo.GetByID(ctx, label.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		workspaceID = &board.WorkspaceID
+	}
+
+	err = s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.labelRepo.Share(ctx, id, workspaceID); err != nil {
+			return err
+		}
+		if label, err = s.labelRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventLabelUpdated, label.BoardID, labelPayload(label)))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// GetByWorkspaceID возвращает общие метки рабочего пространства.
+func (s *LabelService) GetByWorkspaceID(ctx context.Context, workspaceID uint) ([]models.Label, error) {
+	return s.labelRepo.GetByWorkspaceID(ctx, workspaceID)
+}
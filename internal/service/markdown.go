@@ -0,0 +1,27 @@
+package service
+
+import (
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/pkg/markdown"
+)
+
+// MarkdownRenderer рендерит пользовательский Markdown в безопасный HTML.
+type MarkdownRenderer interface {
+	Render(source string, mentions map[string]uint) string
+}
+
+func mentionLinks(spans []models.MentionSpan) map[string]uint {
+	links := make(map[string]uint, len(spans))
+	for _, span := range spans {
+		links[markdown.Handle(span.Handle)] = span.UserID
+	}
+	return links
+}
+
+func renderCard(renderer MarkdownRenderer, card *models.Card) {
+	card.DescriptionHTML = renderer.Render(card.Description, mentionLinks(card.Mentions))
+}
+
+func renderComment(renderer MarkdownRenderer, comment *models.Comment) {
+	comment.ContentHTML = renderer.Render(comment.Content, mentionLinks(comment.Mentions))
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+	"github.com/octaview/kanban-octaview/pkg/markdown"
+)
+
+type parsedMention struct {
+	Handle string
+	Start  int
+	End    int
+}
+
+// parseMentions возвращает упоминания в тексте с позициями в рунах. Упоминания в коде
+// и в тексте ссылок не считаются: Render не показывает их ссылками.
+func parseMentions(text string) []parsedMention {
+	var result []parsedMention
+	for _, m := range markdown.Mentions(text) {
+		start := utf8.RuneCountInString(text[:m.Start])
+		result = append(result, parsedMention{
+			Handle: m.Handle,
+			Start:  start,
+			End:    start + utf8.RuneCountInString(text[m.Start:m.End]),
+		})
+	}
+	return result
+}
+
+// userHandle — имя пользователя для упоминаний: локальная часть email.
+func userHandle(user *models.User) string {
+	local, _, _ := strings.Cut(user.Email, "@")
+	return strings.ToLower(local)
+}
+
+// matchMentionedUser находит участника доски по упоминанию: полному email или имени из
+// userHandle. Если имя совпадает у нескольких участников (alice@a.com и alice@b.com),
+// упоминание не разрешается — нужно указать email полностью.
+func matchMentionedUser(handle string, users []models.User) *models.User {
+	if strings.Contains(handle, "@") {
+		for i := range users {
+			if strings.EqualFold(users[i].Email, handle) {
+				return &users[i]
+			}
+		}
+		return nil
+	}
+
+	handle = strings.ToLower(handle)
+	var match *models.User
+	for i := range users {
+		if userHandle(&users[i]) != handle {
+			continue
+		}
+		if match != nil && match.ID != users[i].ID {
+			return nil
+		}
+		match = &users[i]
+	}
+	return match
+}
+
+type MentionService struct {
+	mentionRepo repository.MentionRepository
+	memberRepo  repository.BoardMemberRepository
+	cardRepo    repository.CardRepository
+	columnRepo  repository.ColumnRepository
+	notifier    Notifier
+	visibility  *CardVisibility
+}
+
+func NewMentionService(
+	mentionRepo repository.MentionRepository,
+	memberRepo repository.BoardMemberRepository,
+	cardRepo repository.CardRepository,
+	columnRepo repository.ColumnRepository,
+	notifier Notifier,
+	visibility *CardVisibility,
+) *MentionService {
+	return &MentionService{
+		mentionRepo: mentionRepo,
+		memberRepo:  memberRepo,
+		cardRepo:    cardRepo,
+		columnRepo:  columnRepo,
+		notifier:    notifier,
+		visibility:  visibility,
+	}
+}
+
+// MentionSync — результат сохранения упоминаний источника: упоминания для ответа и
+// уведомления, которые нужно отправить после фиксации транзакции.
+type MentionSync struct {
+	Spans         []models.MentionSpan
+	notifications []NotificationEvent
+}
+
+// Sync разбирает упоминания в тексте источника и сохраняет их. Вызывается в транзакции,
+// в которой сохраняется сам источник; уведомления отправляет Notify после ее фиксации.
+// Уведомляются только пользователи, которые не были упомянуты в этом источнике раньше.
+// Гости доски, от которых карточка скрыта, не упоминаются.
+func (s *MentionService) Sync(ctx context.Context, sourceType string, sourceID, cardID uint, text string) (*MentionSync, error) {
+	parsed := parseMentions(text)
+
+	var boardID uint
+	var users []models.User
+	if len(parsed) > 0 {
+		card, err := s.cardRepo.GetByID(ctx, cardID)
+		if err != nil {
+			return nil, err
+		}
+		column, err := s.columnRepo.GetByID(ctx, card.ColumnID)
+		if err != nil {
+			return nil, err
+		}
+		boardID = column.BoardID
+
+		users, err = s.memberRepo.GetBoardUsers(ctx, boardID)
+		if err != nil {
+			return nil, err
+		}
+		users, err = s.visibleUsers(ctx, cardID, users)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	authorID, _ := ActorFromContext(ctx)
+
+	mentions := make([]models.Mention, 0, len(parsed))
+	for _, p := range parsed {
+		user := matchMentionedUser(p.Handle, users)
+		if user == nil {
+			continue
+		}
+		mentions = append(mentions, models.Mention{
+			SourceType: sourceType,
+			SourceID:   sourceID,
+			CardID:     cardID,
+			UserID:     user.ID,
+			AuthorID:   authorID,
+			Handle:     p.Handle,
+			Start:      p.Start,
+			End:        p.End,
+		})
+	}
+
+	previous, err := s.mentionRepo.GetBySource(ctx, sourceType, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mentionRepo.ReplaceForSource(ctx, sourceType, sourceID, mentions); err != nil {
+		return nil, err
+	}
+
+	notified := make(map[uint]bool, len(previous))
+	for _, m := range previous {
+		notified[m.UserID] = true
+	}
+	notified[authorID] = true
+
+	result := &MentionSync{Spans: toMentionSpans(mentions)}
+	for _, m := range mentions {
+		if notified[m.UserID] {
+			continue
+		}
+		notified[m.UserID] = true
+
+		event := NotificationEvent{
+			Type:      NotificationMention,
+			UserID:    m.UserID,
+			ActorID:   authorID,
+			BoardID:   boardID,
+			CardID:    cardID,
+			Message:   fmt.Sprintf("You were mentioned in a %s", sourceType),
+			CreatedAt: time.Now(),
+		}
+		if sourceType == models.MentionSourceComment {
+			event.CommentID = sourceID
+		}
+		result.notifications = append(result.notifications, event)
+	}
+
+	return result, nil
+}
+
+// Notify уведомляет новых упомянутых пользователей.
+func (s *MentionService) Notify(ctx context.Context, mentions *MentionSync) {
+	for _, event := range mentions.notifications {
+		s.notifier.Notify(ctx, event)
+	}
+}
+
+// visibleUsers оставляет в users только тех, кому видна карточка.
+func (s *MentionService) visibleUsers(ctx context.Context, cardID uint, users []models.User) ([]models.User, error) {
+	ids := make([]uint, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	hidden, err := s.visibility.HiddenFrom(ctx, cardID, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(hidden) == 0 {
+		return users, nil
+	}
+	return slices.DeleteFunc(users, func(user models.User) bool {
+		return slices.Contains(hidden, user.ID)
+	}), nil
+}
+
+func (s *MentionService) Spans(ctx context.Context, sourceType string, sourceID uint) ([]models.MentionSpan, error) {
+	mentions, err := s.mentionRepo.GetBySource(ctx, sourceType, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	return toMentionSpans(mentions), nil
+}
+
+func (s *MentionService) SpansBySources(ctx context.Context, sourceType string, sourceIDs []uint) (map[uint][]models.MentionSpan, error) {
+	mentions, err := s.mentionRepo.GetBySources(ctx, sourceType, sourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint][]models.MentionSpan, len(sourceIDs))
+	for _, m := range mentions {
+		result[m.SourceID] = append(result[m.SourceID], toMentionSpan(m))
+	}
+	return result, nil
+}
+
+// Clear удаляет упоминания источника, например при удалении комментария.
+func (s *MentionService) Clear(ctx context.Context, sourceType string, sourceID uint) {
+	if err := s.mentionRepo.ReplaceForSource(ctx, sourceType, sourceID, nil); err != nil {
+		slog.ErrorContext(ctx, "failed to clear mentions", slog.Any("error", err))
+	}
+}
+
+func toMentionSpan(m models.Mention) models.MentionSpan {
+	return models.MentionSpan{
+		UserID: m.UserID,
+		Handle: m.Handle,
+		Start:  m.Start,
+		End:    m.End,
+	}
+}
+
+func toMentionSpans(mentions []models.Mention) []models.MentionSpan {
+	spans := make([]models.MentionSpan, 0, len(mentions))
+	for _, m := range mentions {
+		spans = append(spans, toMentionSpan(m))
+	}
+	return spans
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+)
+
+func newTestMentionService() (*MentionService, *fakeMentionRepo, *recordingNotifier) {
+	members := &fakeMemberRepo{members: []models.BoardMember{
+		{BoardID: 1, UserID: 1, Role: models.BoardRoleMember, User: models.User{ID: 1, Email: "alice@example.com"}},
+		{BoardID: 1, UserID: 2, Role: models.BoardRoleGuest, User: models.User{ID: 2, Email: "bob@example.com"}},
+		{BoardID: 1, UserID: 3, Role: models.BoardRoleGuest, User: models.User{ID: 3, Email: "carol@example.com"}},
+		{BoardID: 1, UserID: 4, Role: models.BoardRoleAdmin, User: models.User{ID: 4, Email: "dave@example.com"}},
+	}}
+	guests := &fakeGuestRepo{members: members, cards: map[uint][]uint{2: {10}, 3: {11}}}
+	cards := &fakeCardRepo{cards: map[uint]*models.Card{
+		10: {ID: 10, ColumnID: 5},
+		11: {ID: 11, ColumnID: 5},
+	}}
+	columns := &fakeColumnRepo{columns: map[uint]*models.Column{5: {ID: 5, BoardID: 1}}}
+	mentions := &fakeMentionRepo{}
+	notifier := &recordingNotifier{}
+
+	service := NewMentionService(mentions, members, cards, columns, notifier, NewCardVisibility(members, guests))
+	return service, mentions, notifier
+}
+
+func TestMentionServiceSync(t *testing.T) {
+	service, _, notifier := newTestMentionService()
+	ctx := WithActor(context.Background(), 4)
+
+	mentions, err := service.Sync(ctx, models.MentionSourceComment, 100, 10, "@alice @bob and @carol, cc @dave@example.com")
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if got := notifier.userIDs(); len(got) != 0 {
+		t.Fatalf("users notified before Notify: %v", got)
+	}
+	service.Notify(ctx, mentions)
+
+	var mentioned []uint
+	for _, span := range mentions.Spans {
+		mentioned = append(mentioned, span.UserID)
+	}
+	// carol — гость без доступа к карточке, dave — автор: его упоминание сохраняется, но без уведомления.
+	if want := []uint{1, 2, 4}; !slices.Equal(mentioned, want) {
+		t.Errorf("mentioned users = %v, want %v", mentioned, want)
+	}
+	if got, want := notifier.userIDs(), []uint{1, 2}; !slices.Equal(got, want) {
+		t.Errorf("notified users = %v, want %v", got, want)
+	}
+}
+
+func TestMentionServiceSyncNotifiesOnlyNewMentions(t *testing.T) {
+	service, _, notifier := newTestMentionService()
+	ctx := WithActor(context.Background(), 4)
+
+	for _, text := range []string{"@alice", "@alice @bob"} {
+		mentions, err := service.Sync(ctx, models.MentionSourceCard, 10, 10, text)
+		if err != nil {
+			t.Fatalf("Sync(%q): %v", text, err)
+		}
+		service.Notify(ctx, mentions)
+	}
+
+	if got, want := notifier.userIDs(), []uint{1, 2}; !slices.Equal(got, want) {
+		t.Errorf("notified users = %v, want %v", got, want)
+	}
+}
+
+func TestMatchMentionedUser(t *testing.T) {
+	users := []models.User{
+		{ID: 1, Email: "alice@example.com"},
+		{ID: 2, Email: "Alice@partner.org"},
+		{ID: 3, Email: "bob@example.com"},
+		// Один и тот же пользователь может попасть в список дважды, например как владелец и участник.
+		{ID: 3, Email: "bob@example.com"},
+	}
+
+	tests := []struct {
+		handle string
+		want   uint
+	}{
+		{handle: "bob", want: 3},
+		{handle: "BOB", want: 3},
+		{handle: "alice"},
+		{handle: "alice@partner.org", want: 2},
+		{handle: "ALICE@example.com", want: 1},
+		{handle: "carol"},
+	}
+	for _, tt := range tests {
+		var got uint
+		if user := matchMentionedUser(tt.handle, users); user != nil {
+			got = user.ID
+		}
+		if got != tt.want {
+			t.Errorf("matchMentionedUser(%q) = user %d, want %d", tt.handle, got, tt.want)
+		}
+	}
+}
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		text string
+		want []parsedMention
+	}{
+		{text: "привет, @alice!", want: []parsedMention{{Handle: "alice", Start: 8, End: 14}}},
+		{text: "@bob@example.com", want: []parsedMention{{Handle: "bob@example.com", Start: 0, End: 16}}},
+		{text: "пишите на dave@example.com"},
+		{text: "`@alice` и @bob", want: []parsedMention{{Handle: "bob", Start: 11, End: 15}}},
+		{text: "```\n@alice\n```"},
+		{text: "[@alice](https://example.com)"},
+	}
+	for _, tt := range tests {
+		if got := parseMentions(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("parseMentions(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+const (
+	defaultNotificationLimit = 50
+	maxNotificationLimit     = 200
+)
+
+type NotificationService struct {
+	notificationRepo repository.NotificationRepository
+	emailRepo        repository.EmailRepository
+}
+
+func NewNotificationService(notificationRepo repository.NotificationRepository, emailRepo repository.EmailRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		emailRepo:        emailRepo,
+	}
+}
+
+func (s *NotificationService) GetInbox(ctx context.Context, userID uint, filter repository.NotificationFilter) ([]models.Notification, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultNotificationLimit
+	}
+	if filter.Limit > maxNotificationLimit {
+		filter.Limit = maxNotificationLimit
+	}
+
+	return s.notificationRepo.GetByUserID(ctx, userID, filter)
+}
+
+// GetInboxGrouped группирует уведомления по карточкам, сохраняя порядок от новых к старым.
+func (s *NotificationService) GetInboxGrouped(ctx context.Context, userID uint, filter repository.NotificationFilter) ([]models.NotificationGroup, error) {
+	notifications, err := s.GetInbox(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]models.NotificationGroup, 0)
+	index := make(map[uint]int)
+	for _, n := range notifications {
+		var key uint
+		if n.CardID != nil {
+			key = *n.CardID
+		}
+
+		i, ok := index[key]
+		if !ok {
+			groups = append(groups, models.NotificationGroup{CardID: n.CardID})
+			i = len(groups) - 1
+			index[key] = i
+		}
+
+		groups[i].Notifications = append(groups[i].Notifications, n)
+		if n.ReadAt == nil {
+			groups[i].UnreadCount++
+		}
+	}
+
+	return groups, nil
+}
+
+func (s *NotificationService) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	return s.notificationRepo.CountUnread(ctx, userID)
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, id, userID uint) error {
+	return s.notificationRepo.SetRead(ctx, id, userID, true)
+}
+
+func (s *NotificationService) MarkUnread(ctx context.Context, id, userID uint) error {
+	return s.notificationRepo.SetRead(ctx, id, userID, false)
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uint) (int64, error) {
+	return s.notificationRepo.MarkAllRead(ctx, userID)
+}
+
+func (s *NotificationService) GetPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error) {
+	return s.notificationRepo.GetPreferences(ctx, userID)
+}
+
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID uint, prefs []models.NotificationPreference) error {
+	for _, pref := range prefs {
+		if pref.Type == "" && pref.BoardID == nil {
+			return models.NewValidationError("preferences", "each preference must set type or board_id")
+		}
+	}
+
+	return s.notificationRepo.ReplacePreferences(ctx, userID, prefs)
+}
+
+// GetEmailPreference возвращает настройки писем; по умолчанию письма приходят сразу, без дайджестов.
+func (s *NotificationService) GetEmailPreference(ctx context.Context, userID uint) (*models.EmailPreference, error) {
+	pref, err := s.emailRepo.GetPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if pref == nil {
+		pref = &models.EmailPreference{UserID: userID, Immediate: true, Digest: models.DigestNone}
+	}
+	return pref, nil
+}
+
+func (s *NotificationService) UpdateEmailPreference(ctx context.Context, userID uint, pref *models.EmailPreference) error {
+	switch pref.Digest {
+	case "":
+		pref.Digest = models.DigestNone
+	case models.DigestNone, models.DigestDaily, models.DigestWeekly:
+	default:
+		return models.NewValidationError("digest", "must be one of none, daily or weekly")
+	}
+
+	pref.UserID = userID
+	return s.emailRepo.SavePreference(ctx, pref)
+}
+
+// InboxNotifier сохраняет уведомления во входящие пользователя с учетом его настроек.
+type InboxNotifier struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewInboxNotifier(notificationRepo repository.NotificationRepository) *InboxNotifier {
+	return &InboxNotifier{
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (n *InboxNotifier) Notify(ctx context.Context, event NotificationEvent) {
+	muted, err := n.notificationRepo.IsMuted(ctx, event.UserID, event.Type, event.BoardID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check notification preferences", slog.Any("error", err))
+		return
+	}
+	if muted {
+		return
+	}
+
+	notification := &models.Notification{
+		UserID:    event.UserID,
+		Type:      event.Type,
+		ActorID:   optionalID(event.ActorID),
+		BoardID:   optionalID(event.BoardID),
+		CardID:    optionalID(event.CardID),
+		CommentID: optionalID(event.CommentID),
+		Message:   event.Message,
+		CreatedAt: event.CreatedAt,
+	}
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	if err := n.notificationRepo.Create(ctx, notification); err != nil {
+		slog.ErrorContext(ctx, "failed to store notification", slog.Any("error", err))
+	}
+}
+
+func optionalID(id uint) *uint {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
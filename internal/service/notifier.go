@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	NotificationMention        = "mention"
+	NotificationCardCreated    = "card_created"
+	NotificationCardUpdated    = "card_updated"
+	NotificationCardMoved      = "card_moved"
+	NotificationCardDeleted    = "card_deleted"
+	NotificationCardAssigned   = "card_assigned"
+	NotificationCardUnassigned = "card_unassigned"
+	NotificationDueDateChanged = "due_date_changed"
+	NotificationCommentAdded   = "comment_added"
+	NotificationCommentEdited  = "comment_edited"
+	NotificationCommentDeleted = "comment_deleted"
+	NotificationLabelsChanged  = "labels_changed"
+	NotificationDueSoon        = "due_soon"
+	NotificationOverdue        = "card_overdue"
+	NotificationBoardInvite    = "board_invitation"
+)
+
+// NotificationEvent — уведомление для конкретного пользователя.
+type NotificationEvent struct {
+	Type      string
+	UserID    uint
+	ActorID   uint
+	BoardID   uint
+	CardID    uint
+	CommentID uint
+	Message   string
+	CreatedAt time.Time
+}
+
+// Notifier доставляет уведомления пользователям. Реализации не должны блокировать
+// вызывающий сервис и сами обрабатывают ошибки доставки.
+type Notifier interface {
+	Notify(ctx context.Context, event NotificationEvent)
+}
+
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, event NotificationEvent) {
+	slog.InfoContext(ctx, "notification",
+		slog.String("type", event.Type),
+		slog.Uint64("user_id", uint64(event.UserID)),
+		slog.Uint64("actor_id", uint64(event.ActorID)),
+		slog.Uint64("card_id", uint64(event.CardID)),
+		slog.String("message", event.Message),
+	)
+}
+
+// MultiNotifier передает каждое уведомление всем вложенным получателям по очереди.
+type MultiNotifier []Notifier
+
+func NewMultiNotifier(notifiers ...Notifier) MultiNotifier {
+	return MultiNotifier(notifiers)
+}
+
+func (m MultiNotifier) Notify(ctx context.Context, event NotificationEvent) {
+	for _, n := range m {
+		n.Notify(ctx, event)
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+const (
+	outboxBatchSize       = 100
+	outboxCleanupInterval = time.Hour
+
+	AggregateBoard    = "board"
+	AggregateColumn   = "column"
+	AggregateCard     = "card"
+	AggregateSwimlane = "swimlane"
+	AggregateLabel    = "label"
+	AggregateComment  = "comment"
+)
+
+type Outbox struct {
+	outboxRepo repository.OutboxRepository
+	boardRepo  repository.BoardRepository
+	transactor repository.Transactor
+	wake       chan struct{}
+}
+
+func NewOutbox(outboxRepo repository.OutboxRepository, boardRepo repository.BoardRepository, transactor repository.Transactor) *Outbox {
+	return &Outbox{
+		outboxRepo: outboxRepo,
+		boardRepo:  boardRepo,
+		transactor: transactor,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+func (o *Outbox) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := o.transactor.WithinTransaction(ctx, fn); err != nil {
+		return err
+	}
+
+	// Ретранслятор будится сразу после фиксации, чтобы не ждать следующего опроса.
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Record также отмечает активность на доске. Событие пишется при каждом изменении
+// содержимого доски, поэтому здесь же отклоняются изменения архивных досок.
+func (o *Outbox) Record(ctx context.Context, event BoardEvent) error {
+	if err := o.boardRepo.TouchActivity(ctx, event.BoardID, event.OccurredAt); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event %s: %w", event.Type, err)
+	}
+
+	aggregateType, aggregateID := eventAggregate(event)
+	return o.outboxRepo.Append(ctx, &models.OutboxEvent{
+		EventID:       event.ID,
+		EventType:     event.Type,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		BoardID:       event.BoardID,
+		Payload:       string(payload),
+	})
+}
+
+// eventAggregate определяет сущность, в пределах которой события публикуются строго по порядку.
+func eventAggregate(event BoardEvent) (string, uint) {
+	switch data := event.Data.(type) {
+	case CardPayload:
+		return AggregateCard, data.ID
+	case CardMovedPayload:
+		return AggregateCard, data.Card.ID
+	case CardLabelsPayload:
+		return AggregateCard, data.CardID
+	case CardsReorderedPayload:
+		return AggregateColumn, data.ColumnID
+	case ColumnPayload:
+		return AggregateColumn, data.ID
+	case SwimlanePayload:
+		return AggregateSwimlane, data.ID
+	case LabelPayload:
+		return AggregateLabel, data.ID
+	case CommentPayload:
+		return AggregateComment, data.ID
+	default:
+		return AggregateBoard, event.BoardID
+	}
+}
+
+// EventRetention задает, сколько хранить опубликованные события в outbox и в журнале событий досок.
+type EventRetention struct {
+	Outbox time.Duration
+	Log    time.Duration
+}
+
+// EventRelay публикует события из outbox во все приемники. Одновременно работает только один
+// ретранслятор на все экземпляры сервера. Перед отправкой в приемники событие записывается
+// в журнал и получает порядковый номер Seq. Если приемник не принял событие, последующие
+// события того же агрегата откладываются до следующей попытки, чтобы сохранить порядок.
+type EventRelay struct {
+	outboxRepo  repository.OutboxRepository
+	eventRepo   repository.BoardEventRepository
+	sinks       []EventSink
+	wake        <-chan struct{}
+	retention   EventRetention
+	lastCleanup time.Time
+}
+
+func NewEventRelay(
+	outboxRepo repository.OutboxRepository,
+	eventRepo repository.BoardEventRepository,
+	outbox *Outbox,
+	retention EventRetention,
+	sinks ...EventSink,
+) *EventRelay {
+	return &EventRelay{
+		outboxRepo: outboxRepo,
+		eventRepo:  eventRepo,
+		sinks:      sinks,
+		wake:       outbox.wake,
+		retention:  retention,
+	}
+}
+
+// Run опрашивает outbox с заданным интервалом и после каждой транзакции с событиями, пока ctx не отменен.
+func (r *EventRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for r.Relay(ctx) == outboxBatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+		}
+		r.cleanup(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// Relay обрабатывает одну пачку событий и возвращает ее размер.
+func (r *EventRelay) Relay(ctx context.Context) int {
+	var processed int
+	_, err := r.outboxRepo.WithRelayLock(ctx, func(ctx context.Context) error {
+		events, err := r.outboxRepo.GetPending(ctx, outboxBatchSize)
+		if err != nil {
+			return err
+		}
+		processed = len(events)
+
+		blocked := make(map[string]bool)
+		published := make([]uint64, 0, len(events))
+		for _, stored := range events {
+			aggregate := fmt.Sprintf("%s:%d", stored.AggregateType, stored.AggregateID)
+			if blocked[aggregate] {
+				continue
+			}
+
+			if err := r.publish(ctx, &stored); err != nil {
+				blocked[aggregate] = true
+				slog.ErrorContext(ctx, "failed to publish outbox event",
+					slog.String("event_id", stored.EventID),
+					slog.String("type", stored.EventType),
+					slog.Any("error", err))
+				if err := r.outboxRepo.MarkFailed(ctx, stored.ID, err.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+			published = append(published, stored.ID)
+		}
+
+		return r.outboxRepo.MarkPublished(ctx, published, time.Now())
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to relay outbox events", slog.Any("error", err))
+		return 0
+	}
+	return processed
+}
+
+func (r *EventRelay) publish(ctx context.Context, stored *models.OutboxEvent) error {
+	event, err := decodeBoardEvent(stored.Payload)
+	if err != nil {
+		return err
+	}
+
+	logged := &models.BoardEventLog{
+		EventID:   stored.EventID,
+		BoardID:   stored.BoardID,
+		EventType: stored.EventType,
+		Payload:   stored.Payload,
+	}
+	if err := r.eventRepo.Append(ctx, logged); err != nil {
+		return fmt.Errorf("event log: %w", err)
+	}
+	event.Seq = logged.ID
+
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			return fmt.Errorf("%s: %w", sink.Name(), err)
+		}
+	}
+	return nil
+}
+
+func (r *EventRelay) cleanup(ctx context.Context) {
+	if time.Since(r.lastCleanup) < outboxCleanupInterval {
+		return
+	}
+	r.lastCleanup = time.Now()
+
+	deleted, err := r.outboxRepo.DeletePublishedBefore(ctx, time.Now().Add(-r.retention.Outbox))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to clean up outbox", slog.Any("error", err))
+	} else if deleted > 0 {
+		slog.InfoContext(ctx, "outbox cleaned up", slog.Int64("deleted", deleted))
+	}
+
+	deleted, err = r.eventRepo.DeleteBefore(ctx, time.Now().Add(-r.retention.Log))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to clean up board event log", slog.Any("error", err))
+	} else if deleted > 0 {
+		slog.InfoContext(ctx, "board event log cleaned up", slog.Int64("deleted", deleted))
+	}
+}
+
+// decodeBoardEvent восстанавливает сохраненное событие. Data остается в виде исходного JSON.
+func decodeBoardEvent(payload string) (BoardEvent, error) {
+	var data json.RawMessage
+	event := BoardEvent{Data: &data}
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return BoardEvent{}, fmt.Errorf("decoding event payload: %w", err)
+	}
+	event.Data = data
+	return event, nil
+}
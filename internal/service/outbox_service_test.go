@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+// fakeOutboxRepo хранит outbox в памяти; busy имитирует блокировку, занятую другим экземпляром.
+type fakeOutboxRepo struct {
+	repository.OutboxRepository
+	events []models.OutboxEvent
+	busy   bool
+}
+
+func (r *fakeOutboxRepo) Append(ctx context.Context, event *models.OutboxEvent) error {
+	event.ID = uint64(len(r.events) + 1)
+	r.events = append(r.events, *event)
+	return nil
+}
+
+func (r *fakeOutboxRepo) WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	if r.busy {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
+func (r *fakeOutboxRepo) GetPending(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	var pending []models.OutboxEvent
+	for _, event := range r.events {
+		if event.PublishedAt == nil && len(pending) < limit {
+			pending = append(pending, event)
+		}
+	}
+	return pending, nil
+}
+
+func (r *fakeOutboxRepo) MarkPublished(ctx context.Context, ids []uint64, at time.Time) error {
+	for i := range r.events {
+		if slices.Contains(ids, r.events[i].ID) {
+			r.events[i].PublishedAt = &at
+		}
+	}
+	return nil
+}
+
+func (r *fakeOutboxRepo) MarkFailed(ctx context.Context, id uint64, lastError string) error {
+	for i := range r.events {
+		if r.events[i].ID == id {
+			r.events[i].Attempts++
+			r.events[i].LastError = lastError
+		}
+	}
+	return nil
+}
+
+// fakeEventLog повторяет журнал событий: повторная запись события возвращает прежний Seq.
+type fakeEventLog struct {
+	repository.BoardEventRepository
+	events []models.BoardEventLog
+}
+
+func (r *fakeEventLog) Append(ctx context.Context, event *models.BoardEventLog) error {
+	for _, logged := range r.events {
+		if logged.EventID == event.EventID {
+			event.ID = logged.ID
+			return nil
+		}
+	}
+	event.ID = uint64(len(r.events) + 1)
+	r.events = append(r.events, *event)
+	return nil
+}
+
+// recordingSink запоминает принятые события и отклоняет события из failing.
+type recordingSink struct {
+	received []BoardEvent
+	failing  map[string]bool
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Publish(ctx context.Context, event BoardEvent) error {
+	if s.failing[event.ID] {
+		return errors.New("sink unavailable")
+	}
+	s.received = append(s.received, event)
+	return nil
+}
+
+func (s *recordingSink) ids() []string {
+	ids := make([]string, 0, len(s.received))
+	for _, event := range s.received {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+// newTestRelay записывает события через Outbox.Record и возвращает ретранслятор для них.
+func newTestRelay(t *testing.T, sink *recordingSink, events ...BoardEvent) (*EventRelay, *fakeOutboxRepo, *fakeEventLog) {
+	t.Helper()
+	outboxRepo := &fakeOutboxRepo{}
+	eventLog := &fakeEventLog{}
+	outbox := NewOutbox(outboxRepo, &fakeBoardRepo{}, nil)
+	for _, event := range events {
+		if err := outbox.Record(context.Background(), event); err != nil {
+			t.Fatalf("Record(%s): %v", event.ID, err)
+		}
+	}
+	return NewEventRelay(outboxRepo, eventLog, outbox, EventRetention{}, sink), outboxRepo, eventLog
+}
+
+func cardEvent(id string, cardID uint) BoardEvent {
+	return BoardEvent{ID: id, Type: EventCardUpdated, BoardID: 1, Data: CardPayload{ID: cardID, Title: id}}
+}
+
+func TestEventRelayPublishesInOrder(t *testing.T) {
+	sink := &recordingSink{}
+	relay, outboxRepo, _ := newTestRelay(t, sink, cardEvent("e1", 1), cardEvent("e2", 2), cardEvent("e3", 1))
+
+	if n := relay.Relay(context.Background()); n != 3 {
+		t.Fatalf("Relay processed %d events, want 3", n)
+	}
+	if got := sink.ids(); !slices.Equal(got, []string{"e1", "e2", "e3"}) {
+		t.Fatalf("published events = %v, want [e1 e2 e3]", got)
+	}
+	for i, event := range sink.received {
+		if event.Seq != uint64(i+1) {
+			t.Errorf("event %s seq = %d, want %d", event.ID, event.Seq, i+1)
+		}
+		var data CardPayload
+		if err := json.Unmarshal(event.Data.(json.RawMessage), &data); err != nil || data.Title != event.ID {
+			t.Errorf("event %s data = %s, want the recorded payload", event.ID, event.Data)
+		}
+	}
+	for _, stored := range outboxRepo.events {
+		if stored.PublishedAt == nil {
+			t.Errorf("outbox event %s is not marked published", stored.EventID)
+		}
+	}
+
+	if n := relay.Relay(context.Background()); n != 0 {
+		t.Errorf("second Relay processed %d events, want 0", n)
+	}
+}
+
+func TestEventRelayHoldsBackAggregateAfterFailure(t *testing.T) {
+	sink := &recordingSink{failing: map[string]bool{"e1": true}}
+	relay, outboxRepo, eventLog := newTestRelay(t, sink, cardEvent("e1", 1), cardEvent("e2", 2), cardEvent("e3", 1))
+
+	relay.Relay(context.Background())
+
+	// e3 относится к той же карточке, что и e1, и ждет его, а e2 публикуется.
+	if got := sink.ids(); !slices.Equal(got, []string{"e2"}) {
+		t.Fatalf("published events = %v, want [e2]", got)
+	}
+	failed := outboxRepo.events[0]
+	if failed.PublishedAt != nil || failed.Attempts != 1 || failed.LastError == "" {
+		t.Errorf("failed event = %+v, want it pending with one failed attempt", failed)
+	}
+	if outboxRepo.events[2].PublishedAt != nil || outboxRepo.events[2].Attempts != 0 {
+		t.Errorf("held back event = %+v, want it pending without attempts", outboxRepo.events[2])
+	}
+
+	delete(sink.failing, "e1")
+	relay.Relay(context.Background())
+
+	if got := sink.ids(); !slices.Equal(got, []string{"e2", "e1", "e3"}) {
+		t.Fatalf("published events = %v, want [e2 e1 e3]", got)
+	}
+	// Событие попало в журнал при первой попытке и сохраняет свой Seq.
+	if seq := sink.received[1].Seq; seq != 1 {
+		t.Errorf("retried event seq = %d, want 1", seq)
+	}
+	if len(eventLog.events) != 3 {
+		t.Errorf("event log holds %d events, want 3", len(eventLog.events))
+	}
+}
+
+func TestEventRelaySkipsWhenLocked(t *testing.T) {
+	sink := &recordingSink{}
+	relay, outboxRepo, _ := newTestRelay(t, sink, cardEvent("e1", 1))
+	outboxRepo.busy = true
+
+	if n := relay.Relay(context.Background()); n != 0 || len(sink.received) != 0 {
+		t.Errorf("Relay with a busy lock processed %d events and published %v", n, sink.ids())
+	}
+}
+
+func TestOutboxRecord(t *testing.T) {
+	boards := &fakeBoardRepo{archived: map[uint]bool{2: true}}
+	outboxRepo := &fakeOutboxRepo{}
+	outbox := NewOutbox(outboxRepo, boards, nil)
+
+	tests := []struct {
+		data          any
+		wantAggregate string
+		wantID        uint
+	}{
+		{data: CardPayload{ID: 7}, wantAggregate: AggregateCard, wantID: 7},
+		{data: CardsReorderedPayload{ColumnID: 3}, wantAggregate: AggregateColumn, wantID: 3},
+		{data: CommentPayload{ID: 9}, wantAggregate: AggregateComment, wantID: 9},
+		{data: nil, wantAggregate: AggregateBoard, wantID: 1},
+	}
+	for _, tt := range tests {
+		outboxRepo.events = nil
+		if err := outbox.Record(context.Background(), BoardEvent{ID: "e", Type: EventCardUpdated, BoardID: 1, Data: tt.data}); err != nil {
+			t.Fatalf("Record(%T): %v", tt.data, err)
+		}
+		stored := outboxRepo.events[0]
+		if stored.AggregateType != tt.wantAggregate || stored.AggregateID != tt.wantID {
+			t.Errorf("Record(%T) aggregate = %s:%d, want %s:%d", tt.data, stored.AggregateType, stored.AggregateID, tt.wantAggregate, tt.wantID)
+		}
+	}
+
+	outboxRepo.events = nil
+	err := outbox.Record(context.Background(), BoardEvent{ID: "e", Type: EventCardUpdated, BoardID: 2})
+	if !errors.Is(err, models.ErrBoardArchived) || len(outboxRepo.events) != 0 {
+		t.Errorf("Record on an archived board: error = %v, %d events stored", err, len(outboxRepo.events))
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+const (
+	// PresenceTTL — сколько живет отметка присутствия без heartbeat.
+	PresenceTTL = 60 * time.Second
+
+	maxPresenceSessionLength = 64
+
+	// События присутствия эфемерны: они не сохраняются в журнал и не доступны вебхукам.
+	EventPresenceJoined  = "presence.joined"
+	EventPresenceUpdated = "presence.updated"
+	EventPresenceLeft    = "presence.left"
+)
+
+// PresencePayload — данные событий присутствия. CardID задан, пока пользователь
+// редактирует карточку; для presence.left он не передается.
+type PresencePayload struct {
+	UserID    uint   `json:"user_id"`
+	SessionID string `json:"session_id"`
+	CardID    *uint  `json:"card_id,omitempty"`
+}
+
+// PresenceService отслеживает, кто открыл доску и какую карточку редактирует. Каждое соединение
+// или вкладка — отдельная сессия; отметка сессии истекает, если клиент не присылает heartbeat
+// дольше PresenceTTL. Об изменениях сообщается подписчикам доски через брокер.
+type PresenceService struct {
+	presenceRepo repository.BoardPresenceRepository
+	cardRepo     repository.CardRepository
+	columnRepo   repository.ColumnRepository
+	broker       EventBroker
+}
+
+func NewPresenceService(
+	presenceRepo repository.BoardPresenceRepository,
+	cardRepo repository.CardRepository,
+	columnRepo repository.ColumnRepository,
+	broker EventBroker,
+) *PresenceService {
+	return &PresenceService{
+		presenceRepo: presenceRepo,
+		cardRepo:     cardRepo,
+		columnRepo:   columnRepo,
+		broker:       broker,
+	}
+}
+
+// Touch отмечает сессию на доске и продлевает ее. cardID — карточка, которую пользователь
+// сейчас редактирует, или nil, если он только просматривает доску.
+func (s *PresenceService) Touch(ctx context.Context, boardID, userID uint, sessionID string, cardID *uint) (*models.BoardPresence, error) {
+	return s.touch(ctx, boardID, userID, sessionID, cardID, false)
+}
+
+// Refresh продлевает сессию, не меняя редактируемую карточку. Если сессии нет, она создается.
+func (s *PresenceService) Refresh(ctx context.Context, boardID, userID uint, sessionID string) (*models.BoardPresence, error) {
+	return s.touch(ctx, boardID, userID, sessionID, nil, true)
+}
+
+func (s *PresenceService) touch(ctx context.Context, boardID, userID uint, sessionID string, cardID *uint, keepCard bool) (*models.BoardPresence, error) {
+	if sessionID == "" || len(sessionID) > maxPresenceSessionLength {
+		return nil, models.NewValidationError("session_id", "session ID must be 1 to 64 characters long")
+	}
+	if cardID != nil {
+		if err := s.checkCard(ctx, boardID, *cardID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	presence := &models.BoardPresence{
+		BoardID:    boardID,
+		UserID:     userID,
+		SessionID:  sessionID,
+		CardID:     cardID,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(PresenceTTL),
+	}
+
+	previous, err := s.presenceRepo.Touch(ctx, presence, keepCard)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case previous == nil:
+		s.broadcast(ctx, EventPresenceJoined, presence)
+	case !sameCard(previous.CardID, presence.CardID):
+		s.broadcast(ctx, EventPresenceUpdated, presence)
+	}
+	return presence, nil
+}
+
+// Leave снимает отметку сессии. Повторный вызов ничего не делает.
+func (s *PresenceService) Leave(ctx context.Context, boardID, userID uint, sessionID string) error {
+	removed, err := s.presenceRepo.Remove(ctx, boardID, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if removed != nil {
+		removed.CardID = nil
+		s.broadcast(ctx, EventPresenceLeft, removed)
+	}
+	return nil
+}
+
+// Snapshot возвращает активные сессии доски вместе с пользователями.
+func (s *PresenceService) Snapshot(ctx context.Context, boardID uint) ([]models.BoardPresence, error) {
+	return s.presenceRepo.GetActive(ctx, boardID, time.Now().UTC())
+}
+
+// Run удаляет истекшие отметки с заданным интервалом и сообщает об уходе их пользователей,
+// пока ctx не отменен. Может работать на всех экземплярах сервера одновременно.
+func (s *PresenceService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+func (s *PresenceService) sweep(ctx context.Context) {
+	expired, err := s.presenceRepo.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to expire board presences", slog.Any("error", err))
+		return
+	}
+
+	for i := range expired {
+		expired[i].CardID = nil
+		s.broadcast(ctx, EventPresenceLeft, &expired[i])
+	}
+}
+
+func (s *PresenceService) checkCard(ctx context.Context, boardID, cardID uint) error {
+	card, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		return err
+	}
+	column, err := s.columnRepo.GetByID(ctx, card.ColumnID)
+	if err != nil {
+		return err
+	}
+	if column.BoardID != boardID {
+		return models.NewValidationError("card_id", "card does not belong to this board")
+	}
+	return nil
+}
+
+// broadcast не возвращает ошибку: присутствие восстановится при следующем heartbeat
+// или снимке, поэтому потерянное событие не должно срывать запрос.
+func (s *PresenceService) broadcast(ctx context.Context, eventType string, presence *models.BoardPresence) {
+	event := newBoardEvent(ctx, eventType, presence.BoardID, PresencePayload{
+		UserID:    presence.UserID,
+		SessionID: presence.SessionID,
+		CardID:    presence.CardID,
+	})
+	if err := s.broker.Broadcast(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "failed to broadcast presence",
+			slog.String("type", eventType),
+			slog.Uint64("board_id", uint64(presence.BoardID)),
+			slog.Any("error", err))
+	}
+}
+
+func sameCard(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+const (
+	// rankMaxLength — длина ранга, после которой ранги списка перераздаются заново.
+	rankMaxLength = 12
+	// rankRebalanceBatch — сколько списков каждого вида перераздается за один проход.
+	rankRebalanceBatch = 100
+)
+
+// RankBalancer перераздает ранги колонок, дорожек и карточек в списках, где ранги стали слишком
+// длинными после многих вставок в одно место или совпали после одновременных вставок.
+// Порядок записей при этом не меняется.
+type RankBalancer struct {
+	columnRepo   repository.ColumnRepository
+	swimlaneRepo repository.SwimlaneRepository
+	cardRepo     repository.CardRepository
+}
+
+func NewRankBalancer(
+	columnRepo repository.ColumnRepository,
+	swimlaneRepo repository.SwimlaneRepository,
+	cardRepo repository.CardRepository,
+) *RankBalancer {
+	return &RankBalancer{
+		columnRepo:   columnRepo,
+		swimlaneRepo: swimlaneRepo,
+		cardRepo:     cardRepo,
+	}
+}
+
+// Run перераздает ранги с заданным интервалом, пока ctx не отменен.
+func (b *RankBalancer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		b.Rebalance(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *RankBalancer) Rebalance(ctx context.Context) {
+	boardIDs, err := b.columnRepo.GetUnbalancedBoardIDs(ctx, rankMaxLength, rankRebalanceBatch)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find boards to rebalance", slog.Any("error", err))
+	}
+	for _, boardID := range boardIDs {
+		if err := b.columnRepo.Rebalance(ctx, boardID); err != nil {
+			slog.ErrorContext(ctx, "failed to rebalance column ranks", slog.Uint64("board_id", uint64(boardID)), slog.Any("error", err))
+		}
+	}
+
+	laneBoardIDs, err := b.swimlaneRepo.GetUnbalancedBoardIDs(ctx, rankMaxLength, rankRebalanceBatch)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find boards to rebalance swimlanes", slog.Any("error", err))
+	}
+	for _, boardID := range laneBoardIDs {
+		if err := b.swimlaneRepo.Rebalance(ctx, boardID); err != nil {
+			slog.ErrorContext(ctx, "failed to rebalance swimlane ranks", slog.Uint64("board_id", uint64(boardID)), slog.Any("error", err))
+		}
+	}
+
+	columnIDs, err := b.cardRepo.GetUnbalancedColumnIDs(ctx, rankMaxLength, rankRebalanceBatch)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to find columns to rebalance", slog.Any("error", err))
+	}
+	for _, columnID := range columnIDs {
+		if err := b.cardRepo.Rebalance(ctx, columnID); err != nil {
+			slog.ErrorContext(ctx, "failed to rebalance card ranks", slog.Uint64("column_id", uint64(columnID)), slog.Any("error", err))
+		}
+	}
+
+	if len(boardIDs)+len(laneBoardIDs)+len(columnIDs) > 0 {
+		slog.InfoContext(ctx, "ranks rebalanced", slog.Int("boards", len(boardIDs)),
+			slog.Int("swimlane_boards", len(laneBoardIDs)), slog.Int("columns", len(columnIDs)))
+	}
+}
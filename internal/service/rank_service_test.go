@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/octaview/kanban-octaview/internal/repository"
+	"github.com/octaview/kanban-octaview/pkg/lexorank"
+)
+
+// rankLists — списки с длинными рангами одного вида; перераздача списка failing завершается ошибкой.
+type rankLists struct {
+	unbalanced []uint
+	failing    uint
+	rebalanced []uint
+	maxLength  int
+}
+
+func (l *rankLists) find(maxRankLength, limit int) ([]uint, error) {
+	l.maxLength = maxRankLength
+	return l.unbalanced[:min(limit, len(l.unbalanced))], nil
+}
+
+func (l *rankLists) rebalance(id uint) error {
+	if id == l.failing {
+		return errors.New("rebalance failed")
+	}
+	l.rebalanced = append(l.rebalanced, id)
+	return nil
+}
+
+type rankColumnRepo struct {
+	repository.ColumnRepository
+	rankLists
+}
+
+func (r *rankColumnRepo) GetUnbalancedBoardIDs(ctx context.Context, maxRankLength, limit int) ([]uint, error) {
+	return r.find(maxRankLength, limit)
+}
+
+func (r *rankColumnRepo) Rebalance(ctx context.Context, boardID uint) error {
+	return r.rebalance(boardID)
+}
+
+type rankSwimlaneRepo struct {
+	repository.SwimlaneRepository
+	rankLists
+}
+
+func (r *rankSwimlaneRepo) GetUnbalancedBoardIDs(ctx context.Context, maxRankLength, limit int) ([]uint, error) {
+	return r.find(maxRankLength, limit)
+}
+
+func (r *rankSwimlaneRepo) Rebalance(ctx context.Context, boardID uint) error {
+	return r.rebalance(boardID)
+}
+
+type rankCardRepo struct {
+	repository.CardRepository
+	rankLists
+}
+
+func (r *rankCardRepo) GetUnbalancedColumnIDs(ctx context.Context, maxRankLength, limit int) ([]uint, error) {
+	return r.find(maxRankLength, limit)
+}
+
+func (r *rankCardRepo) Rebalance(ctx context.Context, columnID uint) error {
+	return r.rebalance(columnID)
+}
+
+func TestRankBalancerRebalance(t *testing.T) {
+	columns := &rankColumnRepo{rankLists: rankLists{unbalanced: []uint{1, 2, 3}, failing: 2}}
+	swimlanes := &rankSwimlaneRepo{rankLists: rankLists{unbalanced: []uint{4}}}
+	cards := &rankCardRepo{rankLists: rankLists{unbalanced: []uint{7, 8}, failing: 7}}
+
+	NewRankBalancer(columns, swimlanes, cards).Rebalance(context.Background())
+
+	// Ошибка в одном списке не мешает перераздать остальные.
+	for _, tt := range []struct {
+		name string
+		got  []uint
+		want []uint
+	}{
+		{"columns", columns.rebalanced, []uint{1, 3}},
+		{"swimlanes", swimlanes.rebalanced, []uint{4}},
+		{"cards", cards.rebalanced, []uint{8}},
+	} {
+		if !slices.Equal(tt.got, tt.want) {
+			t.Errorf("rebalanced %s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if columns.maxLength != rankMaxLength || cards.maxLength != rankMaxLength {
+		t.Errorf("lists searched with max rank length %d/%d, want %d", columns.maxLength, cards.maxLength, rankMaxLength)
+	}
+}
+
+// TestRankMaxLengthLeavesRoomForMoves проверяет, что порог перераздачи не срабатывает слишком
+// рано: в одно и то же место списка помещается много перемещений подряд.
+func TestRankMaxLengthLeavesRoomForMoves(t *testing.T) {
+	ranks := lexorank.Spread(rankRebalanceBatch)
+	lower, upper := ranks[0], ranks[1]
+
+	moves := 0
+	for len(upper) <= rankMaxLength {
+		rank, err := lexorank.Between(lower, upper)
+		if err != nil {
+			t.Fatal(err)
+		}
+		upper = rank
+		moves++
+	}
+	if moves < 50 {
+		t.Errorf("ranks reached %d characters after %d moves, want at least 50", rankMaxLength, moves)
+	}
+}
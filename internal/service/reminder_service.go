@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+const reminderBatchSize = 100
+
+// ReminderPolicy задает, когда напоминать о сроке карточки.
+type ReminderPolicy struct {
+	Offsets []time.Duration
+	Overdue bool
+}
+
+// ReminderService планирует напоминания о сроках карточек и рассылает их
+// исполнителю и наблюдателям.
+type ReminderService struct {
+	reminderRepo repository.CardReminderRepository
+	cardRepo     repository.CardRepository
+	columnRepo   repository.ColumnRepository
+	watcherRepo  repository.CardWatcherRepository
+	notifier     Notifier
+	visibility   *CardVisibility
+	policy       ReminderPolicy
+}
+
+func NewReminderService(
+	reminderRepo repository.CardReminderRepository,
+	cardRepo repository.CardRepository,
+	columnRepo repository.ColumnRepository,
+	watcherRepo repository.CardWatcherRepository,
+	notifier Notifier,
+	visibility *CardVisibility,
+	policy ReminderPolicy,
+) *ReminderService {
+	offsets := append([]time.Duration(nil), policy.Offsets...)
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] > offsets[j] })
+	policy.Offsets = offsets
+
+	return &ReminderService{
+		reminderRepo: reminderRepo,
+		cardRepo:     cardRepo,
+		columnRepo:   columnRepo,
+		watcherRepo:  watcherRepo,
+		notifier:     notifier,
+		visibility:   visibility,
+		policy:       policy,
+	}
+}
+
+// Plan пересчитывает напоминания карточки после изменения срока или колонки.
+// Для карточек без срока или в завершающей колонке напоминания отменяются.
+func (s *ReminderService) Plan(ctx context.Context, card *models.Card) {
+	if err := s.plan(ctx, card, time.Now()); err != nil {
+		slog.ErrorContext(ctx, "failed to plan reminders",
+			slog.Uint64("card_id", uint64(card.ID)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (s *ReminderService) plan(ctx context.Context, card *models.Card, now time.Time) error {
+	if card.DueDate == nil {
+		return s.reminderRepo.CancelPending(ctx, card.ID)
+	}
+
+	column, err := s.columnRepo.GetByID(ctx, card.ColumnID)
+	if err != nil {
+		return err
+	}
+	if column.Done() {
+		return s.reminderRepo.CancelPending(ctx, card.ID)
+	}
+
+	due := *card.DueDate
+	reminders := make([]models.CardReminder, 0, len(s.policy.Offsets)+1)
+	for _, offset := range s.policy.Offsets {
+		fireAt := due.Add(-offset)
+		if !fireAt.After(now) {
+			continue
+		}
+		reminders = append(reminders, models.CardReminder{
+			Kind:    models.ReminderBeforeDue,
+			Offset:  int64(offset / time.Second),
+			DueDate: due,
+			FireAt:  fireAt,
+		})
+	}
+	if s.policy.Overdue && due.After(now) {
+		reminders = append(reminders, models.CardReminder{
+			Kind:    models.ReminderOverdue,
+			DueDate: due,
+			FireAt:  due,
+		})
+	}
+
+	return s.reminderRepo.Replace(ctx, card.ID, reminders)
+}
+
+// Cancel отменяет неотправленные напоминания карточки.
+func (s *ReminderService) Cancel(ctx context.Context, cardID uint) {
+	if err := s.reminderRepo.CancelPending(ctx, cardID); err != nil {
+		slog.ErrorContext(ctx, "failed to cancel reminders",
+			slog.Uint64("card_id", uint64(cardID)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// Run планирует напоминания для карточек, у которых их еще нет, и затем
+// рассылает наступившие напоминания с заданным интервалом.
+func (s *ReminderService) Run(ctx context.Context, interval time.Duration) {
+	s.backfill(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.Dispatch(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ReminderService) backfill(ctx context.Context) {
+	now := time.Now()
+	cards, err := s.reminderRepo.GetUnplannedCards(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get cards without reminders", slog.Any("error", err))
+		return
+	}
+
+	for i := range cards {
+		if err := s.plan(ctx, &cards[i], now); err != nil {
+			slog.ErrorContext(ctx, "failed to plan reminders",
+				slog.Uint64("card_id", uint64(cards[i].ID)),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+// Dispatch рассылает напоминания, время которых наступило к моменту now.
+func (s *ReminderService) Dispatch(ctx context.Context, now time.Time) {
+	for ctx.Err() == nil {
+		reminders, err := s.reminderRepo.ClaimDue(ctx, now, reminderBatchSize)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to claim reminders", slog.Any("error", err))
+			return
+		}
+
+		for _, reminder := range reminders {
+			s.send(ctx, reminder)
+		}
+
+		if len(reminders) < reminderBatchSize {
+			return
+		}
+	}
+}
+
+func (s *ReminderService) send(ctx context.Context, reminder models.CardReminder) {
+	card, err := s.cardRepo.GetByID(ctx, reminder.CardID)
+	if err != nil {
+		return
+	}
+	// Срок могли изменить между планированием и отправкой.
+	if card.DueDate == nil || !card.DueDate.Equal(reminder.DueDate) {
+		return
+	}
+
+	column, err := s.columnRepo.GetByID(ctx, card.ColumnID)
+	if err != nil || column.Done() {
+		return
+	}
+
+	recipients, err := s.watcherRepo.GetWatcherIDs(ctx, card.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get card watchers", slog.Any("error", err))
+		return
+	}
+	if card.AssignedTo != nil {
+		recipients = append(recipients, *card.AssignedTo)
+	}
+
+	notificationType := NotificationDueSoon
+	message := fmt.Sprintf("Card %q is due in %s", card.Title, formatReminderOffset(time.Duration(reminder.Offset)*time.Second))
+	if reminder.Kind == models.ReminderOverdue {
+		notificationType = NotificationOverdue
+		message = fmt.Sprintf("Card %q is overdue", card.Title)
+	}
+
+	seen := make(map[uint]bool, len(recipients))
+	// Гость мог остаться наблюдателем или исполнителем карточки, которую от него закрыли.
+	hidden, err := s.visibility.HiddenFrom(ctx, card.ID, recipients)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check card visibility for reminder recipients", slog.Any("error", err))
+		return
+	}
+	for _, userID := range hidden {
+		seen[userID] = true
+	}
+	for _, userID := range recipients {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		s.notifier.Notify(ctx, NotificationEvent{
+			Type:      notificationType,
+			UserID:    userID,
+			BoardID:   column.BoardID,
+			CardID:    card.ID,
+			Message:   message,
+			CreatedAt: time.Now(),
+		})
+	}
+}
+
+func formatReminderOffset(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		if days := d / (24 * time.Hour); days != 1 {
+			return fmt.Sprintf("%d days", days)
+		}
+		return "1 day"
+	case d%time.Hour == 0:
+		if hours := d / time.Hour; hours != 1 {
+			return fmt.Sprintf("%d hours", hours)
+		}
+		return "1 hour"
+	default:
+		return fmt.Sprintf("%d minutes", d/time.Minute)
+	}
+}
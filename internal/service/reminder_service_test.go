@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+// fakeReminderRepo запоминает последний план напоминаний карточки; nil означает отмену.
+type fakeReminderRepo struct {
+	repository.CardReminderRepository
+	planned map[uint][]models.CardReminder
+}
+
+func (r *fakeReminderRepo) Replace(ctx context.Context, cardID uint, reminders []models.CardReminder) error {
+	r.planned[cardID] = reminders
+	return nil
+}
+
+func (r *fakeReminderRepo) CancelPending(ctx context.Context, cardID uint) error {
+	r.planned[cardID] = nil
+	return nil
+}
+
+type fakeWatcherRepo struct {
+	repository.CardWatcherRepository
+	watchers map[uint][]uint
+}
+
+func (r *fakeWatcherRepo) GetWatcherIDs(ctx context.Context, cardID uint) ([]uint, error) {
+	return r.watchers[cardID], nil
+}
+
+func (r *fakeWatcherRepo) Unwatch(ctx context.Context, cardID, userID uint) error {
+	if !slices.Contains(r.watchers[cardID], userID) {
+		return models.ErrNotWatching
+	}
+	r.watchers[cardID] = slices.DeleteFunc(r.watchers[cardID], func(id uint) bool { return id == userID })
+	return nil
+}
+
+func newTestReminderService(cards *fakeCardRepo, notifier Notifier) (*ReminderService, *fakeReminderRepo) {
+	columns := &fakeColumnRepo{columns: map[uint]*models.Column{
+		1: {ID: 1, BoardID: 1, Kind: models.ColumnKindInProgress},
+		2: {ID: 2, BoardID: 1, Kind: models.ColumnKindDone},
+	}}
+	reminders := &fakeReminderRepo{planned: map[uint][]models.CardReminder{}}
+	// Пользователь 5 — гость доски, от которого карточка 10 закрыта.
+	watchers := &fakeWatcherRepo{watchers: map[uint][]uint{10: {3, 4, 5}}}
+	members := &fakeMemberRepo{members: []models.BoardMember{{BoardID: 1, UserID: 5, Role: models.BoardRoleGuest}}}
+	visibility := NewCardVisibility(members, &fakeGuestRepo{members: members})
+	policy := ReminderPolicy{Offsets: []time.Duration{time.Hour, 24 * time.Hour}, Overdue: true}
+	return NewReminderService(reminders, cards, columns, watchers, notifier, visibility, policy), reminders
+}
+
+func TestReminderServicePlan(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		due := now.Add(d)
+		return &due
+	}
+
+	tests := []struct {
+		name     string
+		columnID uint
+		due      *time.Time
+		// want — смещения запланированных напоминаний; -1 обозначает напоминание о просрочке.
+		want []time.Duration
+	}{
+		{name: "due in two days", columnID: 1, due: at(48 * time.Hour), want: []time.Duration{24 * time.Hour, time.Hour, -1}},
+		{name: "due in two hours", columnID: 1, due: at(2 * time.Hour), want: []time.Duration{time.Hour, -1}},
+		{name: "already overdue", columnID: 1, due: at(-time.Hour), want: []time.Duration{}},
+		{name: "no due date", columnID: 1},
+		{name: "done column", columnID: 2, due: at(48 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, reminders := newTestReminderService(&fakeCardRepo{}, &recordingNotifier{})
+			card := &models.Card{ID: 10, ColumnID: tt.columnID, DueDate: tt.due}
+
+			if err := service.plan(context.Background(), card, now); err != nil {
+				t.Fatalf("plan: %v", err)
+			}
+
+			planned := reminders.planned[10]
+			if tt.want == nil {
+				if planned != nil {
+					t.Fatalf("planned %+v, want reminders cancelled", planned)
+				}
+				return
+			}
+			got := []time.Duration{}
+			for _, reminder := range planned {
+				offset := time.Duration(reminder.Offset) * time.Second
+				if reminder.Kind == models.ReminderOverdue {
+					offset = -1
+				}
+				if !reminder.DueDate.Equal(*tt.due) {
+					t.Errorf("reminder due date = %v, want %v", reminder.DueDate, *tt.due)
+				}
+				if want := tt.due.Add(-max(offset, 0)); !reminder.FireAt.Equal(want) {
+					t.Errorf("reminder %v fires at %v, want %v", offset, reminder.FireAt, want)
+				}
+				got = append(got, offset)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("planned offsets = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReminderServiceSend(t *testing.T) {
+	due := time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC)
+	assignee := uint(4)
+	cards := &fakeCardRepo{cards: map[uint]*models.Card{
+		10: {ID: 10, ColumnID: 1, Title: "Release", DueDate: &due, AssignedTo: &assignee},
+	}}
+
+	tests := []struct {
+		name     string
+		reminder models.CardReminder
+		want     []uint
+	}{
+		{
+			name:     "watchers and assignee once, hidden guest skipped",
+			reminder: models.CardReminder{CardID: 10, Kind: models.ReminderBeforeDue, Offset: 3600, DueDate: due},
+			want:     []uint{3, 4},
+		},
+		{
+			name:     "due date changed since planning",
+			reminder: models.CardReminder{CardID: 10, Kind: models.ReminderBeforeDue, Offset: 3600, DueDate: due.Add(-time.Hour)},
+		},
+		{
+			name:     "card deleted",
+			reminder: models.CardReminder{CardID: 11, Kind: models.ReminderOverdue, DueDate: due},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &recordingNotifier{}
+			service, _ := newTestReminderService(cards, notifier)
+
+			service.send(context.Background(), tt.reminder)
+
+			if got := notifier.userIDs(); !slices.Equal(got, tt.want) {
+				t.Fatalf("notified users = %v, want %v", got, tt.want)
+			}
+			for _, event := range notifier.events {
+				if event.Type != NotificationDueSoon || event.Message != `Card "Release" is due in 1 hour` {
+					t.Errorf("notification = %s %q", event.Type, event.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatReminderOffset(t *testing.T) {
+	for offset, want := range map[time.Duration]string{
+		24 * time.Hour:   "1 day",
+		72 * time.Hour:   "3 days",
+		time.Hour:        "1 hour",
+		36 * time.Hour:   "36 hours",
+		15 * time.Minute: "15 minutes",
+		90 * time.Minute: "90 minutes",
+	} {
+		if got := formatReminderOffset(offset); got != want {
+			t.Errorf("formatReminderOffset(%v) = %q, want %q", offset, got, want)
+		}
+	}
+}
@@ -2,11 +2,19 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/octaview/kanban-octaview/internal/config"
 	"github.com/octaview/kanban-octaview/internal/models"
 	"github.com/octaview/kanban-octaview/internal/repository"
+	"github.com/octaview/kanban-octaview/pkg/mailer"
+	"github.com/octaview/kanban-octaview/pkg/markdown"
+	"github.com/octaview/kanban-octaview/pkg/natspub"
+)
+
+const (
+	markdownCacheSize = 1024
 )
 
 type AuthServiceInterface interface {
@@ -31,6 +39,36 @@ type BoardServiceInterface interface {
 	GetByOwnerID(ctx context.Context, ownerID uint) ([]models.Board, error)
 	Update(ctx context.Context, board *models.Board) error
 	Delete(ctx context.Context, id uint) error
+	Restore(ctx context.Context, id uint) (*models.Board, error)
+	Purge(ctx context.Context, id uint) error
+	List(ctx context.Context, userID uint, query BoardListQuery) (*BoardPage, error)
+	GetRecent(ctx context.Context, userID uint, limit int) ([]models.Board, error)
+	RecordView(ctx context.Context, boardID, userID uint)
+	Archive(ctx context.Context, id uint) (*models.Board, error)
+	Unarchive(ctx context.Context, id uint) (*models.Board, error)
+	Star(ctx context.Context, boardID, userID uint) error
+	Unstar(ctx context.Context, boardID, userID uint) error
+}
+
+type BoardSnapshotServiceInterface interface {
+	Revision(ctx context.Context, boardID uint, fields SnapshotFields) (string, error)
+	Snapshot(ctx context.Context, boardID uint, fields SnapshotFields) (*BoardSnapshot, error)
+}
+
+type BoardShareServiceInterface interface {
+	Create(ctx context.Context, link *models.BoardShareLink, password string) error
+	GetByBoardID(ctx context.Context, boardID uint) ([]models.BoardShareLink, error)
+	Revoke(ctx context.Context, boardID, id uint) (*models.BoardShareLink, error)
+	PublicSnapshot(ctx context.Context, token, password string) (*BoardSnapshot, error)
+}
+
+type BoardTemplateServiceInterface interface {
+	Clone(ctx context.Context, sourceID, ownerID uint, opts CloneOptions) (*models.Board, error)
+	Gallery(ctx context.Context, ownerID uint) ([]models.BoardTemplate, error)
+	GetTemplate(ctx context.Context, ownerID uint, ref string) (*models.BoardTemplate, error)
+	SaveTemplate(ctx context.Context, boardID, ownerID uint, name, description string) (*models.BoardTemplate, error)
+	DeleteTemplate(ctx context.Context, ownerID uint, ref string) error
+	CreateFromTemplate(ctx context.Context, ownerID uint, ref string, board *models.Board) error
 }
 
 type ColumnServiceInterface interface {
@@ -39,7 +77,7 @@ type ColumnServiceInterface interface {
 	GetByBoardID(ctx context.Context, boardID uint) ([]models.Column, error)
 	Update(ctx context.Context, column *models.Column) error
 	Delete(ctx context.Context, id uint) error
-	UpdatePositions(ctx context.Context, columns []models.Column) error
+	Reorder(ctx context.Context, boardID uint, columnIDs []uint) ([]models.Column, error)
 }
 
 type CardServiceInterface interface {
@@ -48,27 +86,124 @@ type CardServiceInterface interface {
 	GetByColumnID(ctx context.Context, columnID uint) ([]models.Card, error)
 	Update(ctx context.Context, card *models.Card) error
 	Delete(ctx context.Context, id uint) error
-	UpdatePositions(ctx context.Context, cards []models.Card) error
-	MoveCard(ctx context.Context, cardID, columnID uint, position int) error
+	Reorder(ctx context.Context, columnID uint, cardIDs []uint) ([]models.Card, error)
+	MoveCard(ctx context.Context, cardID, columnID uint, swimlaneID *uint, position int) error
 	AssignCard(ctx context.Context, cardID, userID uint) error
 	UnassignCard(ctx context.Context, cardID uint) error
 	UpdateDueDate(ctx context.Context, cardID uint, dueDate *time.Time) error
 }
 
+type SwimlaneServiceInterface interface {
+	Create(ctx context.Context, swimlane *models.Swimlane) error
+	GetByID(ctx context.Context, boardID, id uint) (*models.Swimlane, error)
+	GetByBoardID(ctx context.Context, boardID uint) ([]models.Swimlane, error)
+	Update(ctx context.Context, swimlane *models.Swimlane) error
+	Delete(ctx context.Context, boardID, id uint) error
+	Reorder(ctx context.Context, boardID uint, swimlaneIDs []uint) ([]models.Swimlane, error)
+	GetBoardLanes(ctx context.Context, boardID uint) (*BoardLanes, error)
+}
+
 type CommentServiceInterface interface {
 	Create(ctx context.Context, comment *models.Comment) error
 	GetByID(ctx context.Context, id uint) (*models.Comment, error)
 	GetByCardID(ctx context.Context, cardID uint) ([]models.Comment, error)
 	Update(ctx context.Context, comment *models.Comment) error
 	Delete(ctx context.Context, id uint) error
+	GetRevisions(ctx context.Context, id uint) ([]models.CommentRevision, error)
+	Purge(ctx context.Context, id uint) error
 }
 
 type LabelServiceInterface interface {
 	Create(ctx context.Context, label *models.Label) error
 	GetByID(ctx context.Context, id uint) (*models.Label, error)
 	GetByBoardID(ctx context.Context, boardID uint) ([]models.Label, error)
+	GetByWorkspaceID(ctx context.Context, workspaceID uint) ([]models.Label, error)
 	Update(ctx context.Context, label *models.Label) error
 	Delete(ctx context.Context, id uint) error
+	Share(ctx context.Context, id uint, shared bool) (*models.Label, error)
+}
+
+type WorkspaceServiceInterface interface {
+	Create(ctx context.Context, workspace *models.Workspace) error
+	CreatePersonal(ctx context.Context, user *models.User) (*models.Workspace, error)
+	GetByID(ctx context.Context, id uint) (*models.Workspace, error)
+	GetUserWorkspaces(ctx context.Context, userID uint) ([]models.Workspace, error)
+	Update(ctx context.Context, workspace *models.Workspace) error
+	Delete(ctx context.Context, id uint) error
+	GetMembers(ctx context.Context, workspaceID uint) ([]models.WorkspaceMember, error)
+	AddMember(ctx context.Context, workspaceID, userID uint, role string) (*models.WorkspaceMember, error)
+	UpdateRole(ctx context.Context, workspaceID, userID uint, role string) error
+	RemoveMember(ctx context.Context, workspaceID, userID uint) error
+	Role(ctx context.Context, workspaceID, userID uint) (string, error)
+}
+
+type BoardMemberServiceInterface interface {
+	AddMember(ctx context.Context, boardID, userID uint, role string) (*models.BoardMember, error)
+	GetMembers(ctx context.Context, boardID uint) ([]models.BoardMember, error)
+	UpdateRole(ctx context.Context, boardID, userID uint, role string) error
+	RemoveMember(ctx context.Context, boardID, userID uint) error
+	HasAccess(ctx context.Context, boardID, userID uint) (bool, error)
+	CanView(ctx context.Context, boardID, userID uint) (bool, error)
+	IsAdmin(ctx context.Context, boardID, userID uint) (bool, error)
+	GetGuests(ctx context.Context, boardID uint) ([]GuestAccess, error)
+	AddGuestCard(ctx context.Context, boardID, userID, cardID uint) error
+	RemoveGuestCard(ctx context.Context, boardID, userID, cardID uint) error
+}
+
+type MentionServiceInterface interface {
+	Sync(ctx context.Context, sourceType string, sourceID, cardID uint, text string) (*MentionSync, error)
+	Notify(ctx context.Context, mentions *MentionSync)
+	Spans(ctx context.Context, sourceType string, sourceID uint) ([]models.MentionSpan, error)
+	SpansBySources(ctx context.Context, sourceType string, sourceIDs []uint) (map[uint][]models.MentionSpan, error)
+	Clear(ctx context.Context, sourceType string, sourceID uint)
+}
+
+type CardWatcherServiceInterface interface {
+	Watch(ctx context.Context, cardID, userID uint) error
+	Unwatch(ctx context.Context, cardID, userID uint) error
+	GetWatchers(ctx context.Context, cardID uint) ([]models.CardWatcher, error)
+	GetWatchedCards(ctx context.Context, userID uint) ([]models.Card, error)
+	AutoWatch(ctx context.Context, cardID, userID uint)
+	NotifyCardChange(ctx context.Context, change CardChange)
+}
+
+type ReminderServiceInterface interface {
+	Plan(ctx context.Context, card *models.Card)
+	Cancel(ctx context.Context, cardID uint)
+}
+
+type WebhookServiceInterface interface {
+	GetByBoardID(ctx context.Context, boardID uint) ([]models.Webhook, error)
+	GetByID(ctx context.Context, boardID, id uint) (*models.Webhook, error)
+	Create(ctx context.Context, webhook *models.Webhook) error
+	Update(ctx context.Context, webhook *models.Webhook) error
+	Delete(ctx context.Context, boardID, id uint) error
+	GetDeliveries(ctx context.Context, boardID, webhookID uint, limit int) ([]models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, boardID, webhookID, deliveryID uint) (*models.WebhookDelivery, error)
+}
+
+type BoardEventServiceInterface interface {
+	Replay(ctx context.Context, boardID uint, afterSeq uint64) (*EventReplay, error)
+}
+
+type PresenceServiceInterface interface {
+	Touch(ctx context.Context, boardID, userID uint, sessionID string, cardID *uint) (*models.BoardPresence, error)
+	Refresh(ctx context.Context, boardID, userID uint, sessionID string) (*models.BoardPresence, error)
+	Leave(ctx context.Context, boardID, userID uint, sessionID string) error
+	Snapshot(ctx context.Context, boardID uint) ([]models.BoardPresence, error)
+}
+
+type NotificationServiceInterface interface {
+	GetInbox(ctx context.Context, userID uint, filter repository.NotificationFilter) ([]models.Notification, error)
+	GetInboxGrouped(ctx context.Context, userID uint, filter repository.NotificationFilter) ([]models.NotificationGroup, error)
+	CountUnread(ctx context.Context, userID uint) (int64, error)
+	MarkRead(ctx context.Context, id, userID uint) error
+	MarkUnread(ctx context.Context, id, userID uint) error
+	MarkAllRead(ctx context.Context, userID uint) (int64, error)
+	GetPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error)
+	UpdatePreferences(ctx context.Context, userID uint, prefs []models.NotificationPreference) error
+	GetEmailPreference(ctx context.Context, userID uint) (*models.EmailPreference, error)
+	UpdateEmailPreference(ctx context.Context, userID uint, pref *models.EmailPreference) error
 }
 
 type Services struct {
@@ -79,16 +214,146 @@ type Services struct {
 	Card    CardServiceInterface
 	Comment CommentServiceInterface
 	Label   LabelServiceInterface
+	Member  BoardMemberServiceInterface
+	Mention MentionServiceInterface
+	Watcher CardWatcherServiceInterface
+
+	// CardLabel привязывает метки к карточкам.
+	CardLabel *CardLabelService
+	// Swimlane управляет горизонтальными дорожками досок.
+	Swimlane SwimlaneServiceInterface
+	// Snapshot загружает доску со всем содержимым одним ответом.
+	Snapshot BoardSnapshotServiceInterface
+	// Share выпускает публичные ссылки на доски и отдает по ним снимки только для чтения.
+	Share BoardShareServiceInterface
+	// Template копирует доски и создает их из шаблонов.
+	Template BoardTemplateServiceInterface
+	// Workspace управляет рабочими пространствами, которые объединяют доски и пользователей.
+	Workspace WorkspaceServiceInterface
+
+	Notification NotificationServiceInterface
+	Reminder     *ReminderService
+
+	Webhook  WebhookServiceInterface
+	Webhooks *WebhookDispatcher
+	// Events сохраняет события досок в outbox, Relay публикует их в приемники, в том числе в Broker,
+	// из которого Hub раздает события клиентам realtime-каналов.
+	Events EventOutbox
+	Relay  *EventRelay
+	Broker EventBroker
+	Hub    *BoardHub
+	// BoardEvents восстанавливает пропущенные события из журнала.
+	BoardEvents BoardEventServiceInterface
+	// Presence отслеживает открытые доски и редактируемые карточки и рассылает изменения через Broker.
+	Presence *PresenceService
+	// Ranks перераздает ранги колонок, дорожек и карточек, когда они становятся слишком длинными.
+	Ranks *RankBalancer
+	// PostgresBroker задан, только если события рассылаются между экземплярами через PostgreSQL.
+	PostgresBroker *PostgresBroker
+	// Emails и Digests заданы, только если отправка писем включена в конфигурации.
+	Emails  *EmailDispatcher
+	Digests *DigestScheduler
 }
 
 func NewServices(repos *repository.Repositories, cfg *config.Config) *Services {
+	var notifier Notifier = NewInboxNotifier(repos.Notification)
+	webhookService := NewWebhookService(repos.Webhook, repos.Board)
+	events := NewOutbox(repos.Outbox, repos.Board, repos.Transactor)
+
+	var broker EventBroker = NewEventBus()
+	var postgresBroker *PostgresBroker
+	if cfg.Events.Broker == "postgres" {
+		postgresBroker = NewPostgresBroker(repos.PubSub, repos.BoardEvent)
+		broker = postgresBroker
+	}
+
+	sinks := []EventSink{broker, webhookService}
+	if cfg.Events.NATSURL != "" {
+		publisher, err := natspub.New(cfg.Events.NATSURL, cfg.Events.NATSTimeout)
+		if err != nil {
+			panic(fmt.Sprintf("configuring NATS publisher: %v", err))
+		}
+		sinks = append(sinks, NewNATSSink(publisher, cfg.Events.NATSSubjectPrefix))
+	}
+
+	// Одна проверка видимости карточек для всех сервисов: гости доски видят только выданные им карточки.
+	visibility := NewCardVisibility(repos.Member, repos.CardGuest)
+
+	var emails *EmailDispatcher
+	var digests *DigestScheduler
+	if cfg.Email.Enabled {
+		templates, err := LoadEmailTemplates()
+		if err != nil {
+			panic(fmt.Sprintf("loading email templates: %v", err))
+		}
+
+		sender := mailer.NewSMTPSender(mailer.Config{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			Timeout:  cfg.SMTP.Timeout,
+		})
+
+		notifier = NewMultiNotifier(
+			notifier,
+			NewEmailNotifier(repos.Email, repos.Notification, repos.User, templates, cfg.Email.BaseURL),
+		)
+		emails = NewEmailDispatcher(repos.Email, sender, cfg.SMTP.From, cfg.Email.MaxAttempts)
+		digests = NewDigestScheduler(repos, visibility, templates, cfg.Email.BaseURL, cfg.Email.DigestHour)
+	}
+
+	renderer := markdown.NewRenderer(markdown.Config{CacheSize: markdownCacheSize})
+	mentionService := NewMentionService(repos.Mention, repos.Member, repos.Card, repos.Column, notifier, visibility)
+	memberService := NewBoardMemberService(repos.Member, repos.Board, repos.User, repos.CardGuest, repos.Card, repos.Column, repos.Watcher, notifier)
+	watcherService := NewCardWatcherService(repos.Watcher, repos.Card, repos.Column, notifier, visibility)
+	reminderService := NewReminderService(repos.Reminder, repos.Card, repos.Column, repos.Watcher, notifier, visibility, ReminderPolicy{
+		Offsets: cfg.Reminder.Offsets,
+		Overdue: cfg.Reminder.Overdue,
+	})
+
+	snapshotService := NewBoardSnapshotService(repos, visibility)
+	workspaceService := NewWorkspaceService(repos.Workspace, repos.WorkspaceMember, repos.User, repos.Transactor)
+
 	return &Services{
-		Auth:    NewAuthService(repos.User, cfg),
+		Auth:    NewAuthService(repos.User, workspaceService, repos.Transactor, cfg),
 		User:    NewUserService(repos.User),
-		Board:   NewBoardService(repos.Board, repos.User),
-		Column:  NewColumnService(repos.Column, repos.Board),
-		Card:    NewCardService(repos.Card, repos.Column, repos.User),
-		Comment: NewCommentService(repos.Comment, repos.Card, repos.User),
-		Label:   NewLabelService(repos.Label, repos.Board),
+		Board:   NewBoardService(repos.Board, repos.BoardStar, repos.BoardView, repos.Member, repos.WorkspaceMember, repos.Label, repos.User, repos.Transactor),
+		Column:  NewColumnService(repos.Column, repos.Board, events, visibility),
+		Card:    NewCardService(repos.Card, repos.Column, repos.Swimlane, repos.User, mentionService, renderer, watcherService, reminderService, notifier, events, visibility),
+		Comment: NewCommentService(repos.Comment, repos.Card, repos.Column, repos.User, mentionService, memberService, renderer, watcherService, events, visibility),
+		Label:   NewLabelService(repos.Label, repos.Board, events),
+
+		Member:  memberService,
+		Mention: mentionService,
+		Watcher: watcherService,
+
+		CardLabel: NewCardLabelService(repos.CardLabel, repos.Card, repos.Label, repos.Board, repos.Column, watcherService, events, visibility),
+		Swimlane:  NewSwimlaneService(repos.Swimlane, repos.Board, repos.Column, repos.Card, events, visibility),
+		Snapshot:  snapshotService,
+		Share:     NewBoardShareService(repos.BoardShare, repos.Board, repos.Comment, snapshotService, memberService),
+		Template:  NewBoardTemplateService(repos),
+		Workspace: workspaceService,
+
+		Notification: NewNotificationService(repos.Notification, repos.Email),
+		Reminder:     reminderService,
+
+		Webhook:  webhookService,
+		Webhooks: NewWebhookDispatcher(repos.Webhook),
+		Events:   events,
+		Relay: NewEventRelay(repos.Outbox, repos.BoardEvent, events, EventRetention{
+			Outbox: cfg.Events.Retention,
+			Log:    cfg.Events.LogRetention,
+		}, sinks...),
+		Broker:   broker,
+		Hub:      NewBoardHub(broker),
+
+		BoardEvents: NewBoardEventService(repos.BoardEvent),
+		Presence:    NewPresenceService(repos.Presence, repos.Card, repos.Column, broker),
+		Ranks:       NewRankBalancer(repos.Column, repos.Swimlane, repos.Card),
+
+		PostgresBroker: postgresBroker,
+		Emails:       emails,
+		Digests:      digests,
 	}
 }
\ No newline at end of file
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+// BoardLanes — карточки доски, разложенные по дорожкам и колонкам.
+type BoardLanes struct {
+	BoardID uint            `json:"board_id"`
+	Columns []models.Column `json:"columns"`
+	Lanes   []LaneRow       `json:"lanes"`
+}
+
+// LaneRow — дорожка доски с ячейками в порядке колонок. Swimlane равен nil у дорожки
+// по умолчанию, в которой лежат карточки без дорожки; она всегда идет последней.
+type LaneRow struct {
+	Swimlane *models.Swimlane `json:"swimlane"`
+	Cells    []LaneCell       `json:"cells"`
+}
+
+// LaneCell — карточки одной колонки в пределах дорожки.
+type LaneCell struct {
+	ColumnID uint          `json:"column_id"`
+	Cards    []models.Card `json:"cards"`
+}
+
+type SwimlaneService struct {
+	swimlaneRepo repository.SwimlaneRepository
+	boardRepo    repository.BoardRepository
+	columnRepo   repository.ColumnRepository
+	cardRepo     repository.CardRepository
+	events       EventOutbox
+	visibility   *CardVisibility
+}
+
+func NewSwimlaneService(
+	swimlaneRepo repository.SwimlaneRepository,
+	boardRepo repository.BoardRepository,
+	columnRepo repository.ColumnRepository,
+	cardRepo repository.CardRepository,
+	events EventOutbox,
+	visibility *CardVisibility,
+) *SwimlaneService {
+	return &SwimlaneService{
+		swimlaneRepo: swimlaneRepo,
+		boardRepo:    boardRepo,
+		columnRepo:   columnRepo,
+		cardRepo:     cardRepo,
+		events:       events,
+		visibility:   visibility,
+	}
+}
+
+func (s *SwimlaneService) Create(ctx context.Context, swimlane *models.Swimlane) error {
+	if swimlane.Title == "" {
+		return models.NewValidationError("title", "swimlane title is required")
+	}
+	if _, err := s.boardRepo.GetByID(ctx, swimlane.BoardID); err != nil {
+		return err
+	}
+
+	return s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.swimlaneRepo.Create(ctx, swimlane); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventSwimlaneCreated, swimlane.BoardID, swimlanePayload(swimlane)))
+	})
+}
+
+// GetByID возвращает дорожку доски boardID; дорожки других досок считаются ненайденными.
+func (s *SwimlaneService) GetByID(ctx context.Context, boardID, id uint) (*models.Swimlane, error) {
+	swimlane, err := s.swimlaneRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if swimlane.BoardID != boardID {
+		return nil, models.ErrSwimlaneNotFound
+	}
+	return swimlane, nil
+}
+
+func (s *SwimlaneService) GetByBoardID(ctx context.Context, boardID uint) ([]models.Swimlane, error) {
+	if _, err := s.boardRepo.GetByID(ctx, boardID); err != nil {
+		return nil, err
+	}
+	return s.swimlaneRepo.GetByBoardID(ctx, boardID)
+}
+
+func (s *SwimlaneService) Update(ctx context.Context, swimlane *models.Swimlane) error {
+	existing, err := s.GetByID(ctx, swimlane.BoardID, swimlane.ID)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(ctx, existing.Version); err != nil {
+		return err
+	}
+
+	if swimlane.Title == "" {
+		swimlane.Title = existing.Title
+	}
+	swimlane.Version = existing.Version
+	swimlane.Position = existing.Position
+	swimlane.CreatedAt = existing.CreatedAt
+
+	return s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.swimlaneRepo.Update(ctx, swimlane); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventSwimlaneUpdated, swimlane.BoardID, swimlanePayload(swimlane)))
+	})
+}
+
+// Delete удаляет дорожку; ее карточки остаются в своих колонках в дорожке по умолчанию.
+func (s *SwimlaneService) Delete(ctx context.Context, boardID, id uint) error {
+	swimlane, err := s.GetByID(ctx, boardID, id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(ctx, swimlane.Version); err != nil {
+		return err
+	}
+
+	return s.events.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.swimlaneRepo.Delete(ctx, id, swimlane.Version); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventSwimlaneDeleted, boardID, swimlanePayload(swimlane)))
+	})
+}
+
+// Reorder расставляет дорожки доски в порядке swimlaneIDs и возвращает их в новом порядке.
+// swimlaneIDs должен содержать ровно все дорожки доски, иначе возвращается *models.OrderConflictError.
+func (s *SwimlaneService) Reorder(ctx context.Context, boardID uint, swimlaneIDs []uint) ([]models.Swimlane, error) {
+	if id, ok := duplicateID(swimlaneIDs); ok {
+		return nil, models.NewValidationError("swimlane_ids", fmt.Sprintf("swimlane %d is listed more than once", id))
+	}
+
+	if _, err := s.boardRepo.GetByID(ctx, boardID); err != nil {
+		return nil, err
+	}
+
+	err := s.events.InTransaction(ctx, func(ctx context.Context) error {
+		changed, err := s.swimlaneRepo.Reorder(ctx, boardID, swimlaneIDs)
+		if err != nil || !changed {
+			return err
+		}
+		return s.events.Record(ctx, newBoardEvent(ctx, EventSwimlaneReordered, boardID, SwimlanesReorderedPayload{BoardID: boardID, SwimlaneIDs: swimlaneIDs}))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.swimlaneRepo.GetByBoardID(ctx, boardID)
+}
+
+// GetBoardLanes возвращает карточки доски, сгруппированные по дорожкам и колонкам.
+// Каждая дорожка содержит ячейки всех колонок, в том числе пустые.
+func (s *SwimlaneService) GetBoardLanes(ctx context.Context, boardID uint) (*BoardLanes, error) {
+	if _, err := s.boardRepo.GetByID(ctx, boardID); err != nil {
+		if errors.Is(err, models.ErrBoardNotFound) {
+			return nil, models.ErrBoardNotFound
+		}
+		return nil, err
+	}
+
+	columns, err := s.columnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	swimlanes, err := s.swimlaneRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.cardRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if cards, err = s.visibility.Filter(ctx, cards); err != nil {
+		return nil, err
+	}
+
+	rows := make([]LaneRow, 0, len(swimlanes)+1)
+	rowIndex := make(map[uint]int, len(swimlanes))
+	for i := range swimlanes {
+		rowIndex[swimlanes[i].ID] = len(rows)
+		rows = append(rows, LaneRow{Swimlane: &swimlanes[i]})
+	}
+	defaultRow := len(rows)
+	rows = append(rows, LaneRow{})
+
+	columnIndex := make(map[uint]int, len(columns))
+	for i, column := range columns {
+		columnIndex[column.ID] = i
+	}
+	for i := range rows {
+		rows[i].Cells = make([]LaneCell, len(columns))
+		for j, column := range columns {
+			rows[i].Cells[j] = LaneCell{ColumnID: column.ID, Cards: []models.Card{}}
+		}
+	}
+
+	// Карточки приходят упорядоченными внутри ячеек, поэтому порядок сохраняется.
+	for _, card := range cards {
+		col, ok := columnIndex[card.ColumnID]
+		if !ok {
+			continue
+		}
+		row := defaultRow
+		if card.SwimlaneID != nil {
+			if i, ok := rowIndex[*card.SwimlaneID]; ok {
+				row = i
+			}
+		}
+		rows[row].Cells[col].Cards = append(rows[row].Cells[col].Cards, card)
+	}
+
+	return &BoardLanes{BoardID: boardID, Columns: columns, Lanes: rows}, nil
+}
@@ -0,0 +1,464 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+const (
+	// WebhookSignatureHeader содержит подпись вида "t=<unix time>,v1=<hex>", где v1 —
+	// HMAC-SHA256 от строки "<unix time>.<тело запроса>" с секретом вебхука.
+	WebhookSignatureHeader = "X-Octaview-Signature"
+	WebhookEventHeader     = "X-Octaview-Event"
+	WebhookDeliveryHeader  = "X-Octaview-Delivery"
+
+	webhookAllEvents        = "*"
+	webhookMaxAttempts      = 8
+	webhookBaseBackoff      = 30 * time.Second
+	webhookMaxBackoff       = 6 * time.Hour
+	webhookDisableThreshold = 20
+	webhookTimeout          = 10 * time.Second
+	webhookClaimLease       = time.Minute
+	webhookBatchSize        = 50
+	webhookResponseLimit    = 4096
+
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+// WebhookService управляет подписками на события досок и ставит события в очередь доставки.
+type WebhookService struct {
+	webhookRepo repository.WebhookRepository
+	boardRepo   repository.BoardRepository
+}
+
+func NewWebhookService(webhookRepo repository.WebhookRepository, boardRepo repository.BoardRepository) *WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		boardRepo:   boardRepo,
+	}
+}
+
+func (s *WebhookService) GetByBoardID(ctx context.Context, boardID uint) ([]models.Webhook, error) {
+	if _, err := s.boardRepo.GetByID(ctx, boardID); err != nil {
+		return nil, err
+	}
+
+	return s.webhookRepo.GetByBoardID(ctx, boardID)
+}
+
+func (s *WebhookService) GetByID(ctx context.Context, boardID, id uint) (*models.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.BoardID != boardID {
+		return nil, models.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// Create создает вебхук. Если секрет не задан, он генерируется и возвращается в webhook.Secret.
+func (s *WebhookService) Create(ctx context.Context, webhook *models.Webhook) error {
+	if _, err := s.boardRepo.GetByID(ctx, webhook.BoardID); err != nil {
+		return err
+	}
+
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return fmt.Errorf("generating webhook secret: %w", err)
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	if actorID, ok := ActorFromContext(ctx); ok {
+		webhook.CreatedBy = actorID
+	}
+	webhook.Active = true
+	webhook.FailureCount = 0
+	webhook.DisabledAt = nil
+
+	return s.webhookRepo.Create(ctx, webhook)
+}
+
+// Update меняет адрес, секрет, фильтр событий и состояние вебхука.
+// Повторное включение сбрасывает счетчик неудачных доставок.
+func (s *WebhookService) Update(ctx context.Context, webhook *models.Webhook) error {
+	existing, err := s.GetByID(ctx, webhook.BoardID, webhook.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+
+	existing.URL = webhook.URL
+	existing.Events = webhook.Events
+	if webhook.Secret != "" {
+		existing.Secret = webhook.Secret
+	}
+	if webhook.Active && !existing.Active {
+		existing.FailureCount = 0
+		existing.DisabledAt = nil
+	}
+	existing.Active = webhook.Active
+
+	if err := s.webhookRepo.Update(ctx, existing); err != nil {
+		return err
+	}
+
+	*webhook = *existing
+	return nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, boardID, id uint) error {
+	if _, err := s.GetByID(ctx, boardID, id); err != nil {
+		return err
+	}
+
+	return s.webhookRepo.Delete(ctx, id)
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, boardID, webhookID uint, limit int) ([]models.WebhookDelivery, error) {
+	if _, err := s.GetByID(ctx, boardID, webhookID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+
+	return s.webhookRepo.GetDeliveries(ctx, webhookID, limit)
+}
+
+// Redeliver ставит в очередь повторную доставку события с тем же телом.
+func (s *WebhookService) Redeliver(ctx context.Context, boardID, webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	if _, err := s.GetByID(ctx, boardID, webhookID); err != nil {
+		return nil, err
+	}
+
+	original, err := s.webhookRepo.GetDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := models.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        models.DeliveryStatusPending,
+		NextAttemptAt: time.Now(),
+		RedeliveryOf:  &original.ID,
+	}
+	deliveries := []models.WebhookDelivery{delivery}
+	if err := s.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		return nil, err
+	}
+
+	return &deliveries[0], nil
+}
+
+func (s *WebhookService) Name() string {
+	return "webhooks"
+}
+
+// Publish ставит событие в очередь для всех активных вебхуков доски, подписанных на его тип.
+// Повторная публикация того же события не создает дубликатов доставок.
+func (s *WebhookService) Publish(ctx context.Context, event BoardEvent) error {
+	webhooks, err := s.webhookRepo.GetActiveByBoardID(ctx, event.BoardID)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding webhook payload: %w", err)
+	}
+
+	now := time.Now()
+	deliveries := make([]models.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if !webhookSubscribed(&webhook, event.Type) {
+			continue
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       string(payload),
+			Status:        models.DeliveryStatusPending,
+			NextAttemptAt: now,
+		})
+	}
+
+	return s.webhookRepo.CreateDeliveries(ctx, deliveries)
+}
+
+func webhookSubscribed(webhook *models.Webhook, eventType string) bool {
+	if len(webhook.Events) == 0 {
+		return true
+	}
+	return slices.Contains(webhook.Events, webhookAllEvents) || slices.Contains(webhook.Events, eventType)
+}
+
+func validateWebhook(webhook *models.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return models.NewValidationError("url", "must be an absolute http or https URL")
+	}
+	// Адреса, заданные именем, проверяются при доставке: имя может указывать куда угодно.
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil && !webhookAddrAllowed(addr) {
+		return models.NewValidationError("url", "must not point to a private or local address")
+	}
+
+	for _, eventType := range webhook.Events {
+		if eventType != webhookAllEvents && !slices.Contains(EventTypes, eventType) {
+			return models.NewValidationError("events", fmt.Sprintf("unknown event type %q", eventType))
+		}
+	}
+
+	return nil
+}
+
+// SignWebhookPayload вычисляет значение заголовка X-Octaview-Signature.
+func SignWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+var errWebhookAddrNotAllowed = errors.New("webhook address is private or local")
+
+// webhookBlockedPrefixes — публичные по виду диапазоны, которые не ведут в интернет:
+// "этот" хост, CGNAT (в нем бывают сервисы метаданных облаков) и стенды для тестов.
+var webhookBlockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// webhookAddrAllowed сообщает, можно ли доставлять вебхуки на addr. Loopback, link-local
+// (в том числе 169.254.169.254), частные и неуказанные адреса запрещены, чтобы через вебхук
+// нельзя было обращаться к внутренней сети сервера.
+func webhookAddrAllowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range webhookBlockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// webhookDialControl проверяет адрес, к которому уже подключается dialer, то есть после
+// разрешения имени: проверка URL при сохранении не защищает от имен, указывающих внутрь сети.
+func webhookDialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !webhookAddrAllowed(addr) {
+		return fmt.Errorf("%w: %s", errWebhookAddrNotAllowed, addr)
+	}
+	return nil
+}
+
+// newWebhookClient возвращает HTTP-клиент, который подключается только к публичным адресам.
+// Прокси из окружения не используется: иначе проверялся бы адрес прокси, а не получателя.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   webhookTimeout,
+		KeepAlive: 30 * time.Second,
+		Control:   webhookDialControl,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: webhookTimeout, Transport: transport}
+}
+
+// WebhookDispatcher доставляет события вебхукам, повторяя неудачные попытки
+// с экспоненциальной задержкой и отключая вебхуки после серии неудач.
+type WebhookDispatcher struct {
+	webhookRepo repository.WebhookRepository
+	client      *http.Client
+}
+
+func NewWebhookDispatcher(webhookRepo repository.WebhookRepository) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		client:      newWebhookClient(),
+	}
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.Dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch выполняет все доставки, готовые к отправке на данный момент.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		deliveries, err := d.webhookRepo.ClaimDueDeliveries(ctx, time.Now(), webhookBatchSize, webhookClaimLease)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to claim webhook deliveries", slog.Any("error", err))
+			return
+		}
+
+		for i := range deliveries {
+			d.deliver(ctx, &deliveries[i])
+		}
+
+		if len(deliveries) < webhookBatchSize {
+			return
+		}
+	}
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	webhook, err := d.webhookRepo.GetByID(ctx, delivery.WebhookID)
+	if err != nil || !webhook.Active {
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.LastError = "webhook is disabled or deleted"
+		d.save(ctx, delivery)
+		return
+	}
+
+	delivery.Attempts++
+	started := time.Now()
+	status, body, sendErr := d.send(ctx, webhook, delivery)
+	delivery.DurationMs = time.Since(started).Milliseconds()
+	delivery.ResponseStatus = status
+	delivery.ResponseBody = body
+
+	if sendErr == nil {
+		now := time.Now()
+		delivery.Status = models.DeliveryStatusSucceeded
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		d.save(ctx, delivery)
+
+		if err := d.webhookRepo.RecordSuccess(ctx, webhook.ID); err != nil {
+			slog.ErrorContext(ctx, "failed to record webhook success", slog.Any("error", err))
+		}
+		return
+	}
+
+	delivery.LastError = sendErr.Error()
+	if delivery.Attempts >= webhookMaxAttempts {
+		delivery.Status = models.DeliveryStatusFailed
+	} else {
+		delivery.NextAttemptAt = time.Now().Add(webhookBackoff(delivery.Attempts))
+	}
+	d.save(ctx, delivery)
+
+	disabled, err := d.webhookRepo.RecordFailure(ctx, webhook.ID, webhookDisableThreshold, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to record webhook failure", slog.Any("error", err))
+		return
+	}
+	if disabled {
+		slog.WarnContext(ctx, "webhook disabled after repeated failures",
+			slog.Uint64("webhook_id", uint64(webhook.ID)),
+			slog.Uint64("board_id", uint64(webhook.BoardID)),
+		)
+	}
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, string, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Kanban-Octaview-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, time.Now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, string(respBody), fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, string(respBody), nil
+}
+
+func (d *WebhookDispatcher) save(ctx context.Context, delivery *models.WebhookDelivery) {
+	if err := d.webhookRepo.SaveDeliveryAttempt(ctx, delivery); err != nil {
+		slog.ErrorContext(ctx, "failed to save webhook delivery", slog.Any("error", err))
+	}
+}
+
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff << (attempts - 1)
+	if backoff <= 0 || backoff > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+type fakeWebhookRepo struct {
+	repository.WebhookRepository
+	webhooks  map[uint]*models.Webhook
+	saved     []models.WebhookDelivery
+	successes int
+	failures  int
+}
+
+func (r *fakeWebhookRepo) GetByID(ctx context.Context, id uint) (*models.Webhook, error) {
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, models.ErrWebhookNotFound
+	}
+	copied := *webhook
+	return &copied, nil
+}
+
+func (r *fakeWebhookRepo) SaveDeliveryAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	r.saved = append(r.saved, *delivery)
+	return nil
+}
+
+func (r *fakeWebhookRepo) RecordSuccess(ctx context.Context, id uint) error {
+	r.successes++
+	return nil
+}
+
+func (r *fakeWebhookRepo) RecordFailure(ctx context.Context, id uint, threshold int, now time.Time) (bool, error) {
+	r.failures++
+	return false, nil
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// Ожидаемое значение посчитано независимо:
+	// printf '1700000000.{"type":"card.created"}' | openssl dgst -sha256 -hmac s3cret
+	got := SignWebhookPayload("s3cret", time.Unix(1700000000, 0), []byte(`{"type":"card.created"}`))
+	want := "t=1700000000,v1=7b99f2fe694c9e2e6914ebb9f63134faf36ba8d58cffb5f78df360d79724574a"
+	if got != want {
+		t.Errorf("SignWebhookPayload = %q, want %q", got, want)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 10, want: 4*time.Hour + 16*time.Minute},
+		{attempts: 11, want: webhookMaxBackoff},
+		{attempts: 64, want: webhookMaxBackoff},
+		{attempts: 200, want: webhookMaxBackoff},
+	}
+	for _, tt := range tests {
+		if got := webhookBackoff(tt.attempts); got != tt.want {
+			t.Errorf("webhookBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookAddrAllowed(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34":          true,
+		"2606:2800:220:1::":      true,
+		"127.0.0.1":              false,
+		"::1":                    false,
+		"169.254.169.254":        false,
+		"fe80::1":                false,
+		"10.1.2.3":               false,
+		"172.16.0.1":             false,
+		"192.168.1.1":            false,
+		"fd00::1":                false,
+		"0.0.0.0":                false,
+		"::":                     false,
+		"0.1.2.3":                false,
+		"100.100.100.200":        false,
+		"224.0.0.1":              false,
+		"255.255.255.255":        false,
+		"::ffff:127.0.0.1":       false,
+		"::ffff:169.254.169.254": false,
+	} {
+		if got := webhookAddrAllowed(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("webhookAddrAllowed(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestWebhookClientRefusesLocalAddresses(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	// Имя localhost разрешается в loopback уже при подключении, поэтому проверку при
+	// сохранении оно проходит, а доставку — нет.
+	for _, target := range []string{server.URL, strings.Replace(server.URL, "127.0.0.1", "localhost", 1)} {
+		resp, err := newWebhookClient().Get(target)
+		if err == nil {
+			resp.Body.Close()
+			t.Fatalf("GET %s succeeded, want an error", target)
+		}
+		if !errors.Is(err, errWebhookAddrNotAllowed) {
+			t.Errorf("GET %s error = %v, want %v", target, err, errWebhookAddrNotAllowed)
+		}
+	}
+	if called {
+		t.Error("request reached the local server")
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	for rawURL, valid := range map[string]bool{
+		"https://hooks.example.com/board":  true,
+		"http://localhost:8080/hook":       true,
+		"https://93.184.216.34/hook":       true,
+		"http://127.0.0.1:8080/hook":       false,
+		"http://169.254.169.254/latest":    false,
+		"http://[::1]/hook":                false,
+		"http://10.0.0.5/hook":             false,
+		"ftp://hooks.example.com/board":    false,
+		"hooks.example.com/board":          false,
+		"https:///no-host":                 false,
+		"http://[fe80::1%25eth0]:8080/":    false,
+		"https://[2606:2800:220:1::]/hook": true,
+	} {
+		err := validateWebhook(&models.Webhook{URL: rawURL})
+		if valid && err != nil {
+			t.Errorf("validateWebhook(%q) error: %v", rawURL, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("validateWebhook(%q) succeeded, want an error", rawURL)
+		}
+	}
+}
+
+func TestWebhookDispatcherDeliver(t *testing.T) {
+	const secret = "s3cret"
+	const payload = `{"type":"card.created"}`
+
+	tests := []struct {
+		name     string
+		status   int
+		attempts int
+		// wantStatus — состояние доставки после попытки.
+		wantStatus  string
+		wantBackoff time.Duration
+	}{
+		{name: "delivered", status: http.StatusOK, wantStatus: models.DeliveryStatusSucceeded},
+		{name: "first failure", status: http.StatusInternalServerError, wantStatus: models.DeliveryStatusPending, wantBackoff: webhookBaseBackoff},
+		{name: "third failure", status: http.StatusBadGateway, attempts: 2, wantStatus: models.DeliveryStatusPending, wantBackoff: 4 * webhookBaseBackoff},
+		{name: "last attempt", status: http.StatusInternalServerError, attempts: webhookMaxAttempts - 1, wantStatus: models.DeliveryStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != payload {
+					t.Errorf("body = %q, want %q", body, payload)
+				}
+				if got := r.Header.Get(WebhookEventHeader); got != EventCardCreated {
+					t.Errorf("%s = %q, want %q", WebhookEventHeader, got, EventCardCreated)
+				}
+				if got := r.Header.Get(WebhookDeliveryHeader); got != "42" {
+					t.Errorf("%s = %q, want 42", WebhookDeliveryHeader, got)
+				}
+
+				signature := r.Header.Get(WebhookSignatureHeader)
+				ts, _, _ := strings.Cut(strings.TrimPrefix(signature, "t="), ",")
+				unix, err := strconv.ParseInt(ts, 10, 64)
+				if err != nil {
+					t.Errorf("bad signature timestamp in %q", signature)
+				}
+				if want := SignWebhookPayload(secret, time.Unix(unix, 0), body); signature != want {
+					t.Errorf("signature = %q, want %q", signature, want)
+				}
+
+				w.WriteHeader(tt.status)
+				io.WriteString(w, "ok")
+			}))
+			defer server.Close()
+
+			repo := &fakeWebhookRepo{webhooks: map[uint]*models.Webhook{
+				1: {ID: 1, BoardID: 1, URL: server.URL, Secret: secret, Active: true},
+			}}
+			dispatcher := &WebhookDispatcher{webhookRepo: repo, client: server.Client()}
+
+			delivery := &models.WebhookDelivery{
+				ID:        42,
+				WebhookID: 1,
+				EventType: EventCardCreated,
+				Payload:   payload,
+				Status:    models.DeliveryStatusPending,
+				Attempts:  tt.attempts,
+			}
+			started := time.Now()
+			dispatcher.deliver(context.Background(), delivery)
+
+			if len(repo.saved) != 1 {
+				t.Fatalf("saved %d delivery attempts, want 1", len(repo.saved))
+			}
+			saved := repo.saved[0]
+			if saved.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", saved.Status, tt.wantStatus)
+			}
+			if saved.Attempts != tt.attempts+1 {
+				t.Errorf("attempts = %d, want %d", saved.Attempts, tt.attempts+1)
+			}
+			if saved.ResponseStatus != tt.status || saved.ResponseBody != "ok" {
+				t.Errorf("response = %d %q, want %d \"ok\"", saved.ResponseStatus, saved.ResponseBody, tt.status)
+			}
+
+			if tt.wantStatus == models.DeliveryStatusSucceeded {
+				if repo.successes != 1 || repo.failures != 0 || saved.DeliveredAt == nil {
+					t.Errorf("successes = %d, failures = %d, delivered at %v", repo.successes, repo.failures, saved.DeliveredAt)
+				}
+				return
+			}
+			if repo.failures != 1 || saved.LastError == "" {
+				t.Errorf("failures = %d, last error %q", repo.failures, saved.LastError)
+			}
+			if tt.wantBackoff > 0 {
+				if delay := saved.NextAttemptAt.Sub(started); delay < tt.wantBackoff || delay > tt.wantBackoff+time.Minute {
+					t.Errorf("next attempt in %v, want %v", delay, tt.wantBackoff)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookDispatcherSkipsDisabledWebhook(t *testing.T) {
+	repo := &fakeWebhookRepo{webhooks: map[uint]*models.Webhook{1: {ID: 1, Active: false}}}
+	dispatcher := &WebhookDispatcher{webhookRepo: repo, client: newWebhookClient()}
+
+	dispatcher.deliver(context.Background(), &models.WebhookDelivery{WebhookID: 1, Status: models.DeliveryStatusPending})
+
+	if len(repo.saved) != 1 || repo.saved[0].Status != models.DeliveryStatusFailed || repo.saved[0].Attempts != 0 {
+		t.Errorf("saved deliveries = %+v, want one failed delivery without attempts", repo.saved)
+	}
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/octaview/kanban-octaview/internal/models"
+	"github.com/octaview/kanban-octaview/internal/repository"
+)
+
+// WorkspaceService управляет рабочими пространствами и их участниками.
+type WorkspaceService struct {
+	workspaceRepo repository.WorkspaceRepository
+	memberRepo    repository.WorkspaceMemberRepository
+	userRepo      repository.UserRepository
+	transactor    repository.Transactor
+}
+
+func NewWorkspaceService(
+	workspaceRepo repository.WorkspaceRepository,
+	memberRepo repository.WorkspaceMemberRepository,
+	userRepo repository.UserRepository,
+	transactor repository.Transactor,
+) *WorkspaceService {
+	return &WorkspaceService{
+		workspaceRepo: workspaceRepo,
+		memberRepo:    memberRepo,
+		userRepo:      userRepo,
+		transactor:    transactor,
+	}
+}
+
+func validWorkspaceRole(role string) bool {
+	return role == models.WorkspaceRoleAdmin || role == models.WorkspaceRoleMember
+}
+
+// Create создает рабочее пространство; его владелец становится администратором.
+func (s *WorkspaceService) Create(ctx context.Context, workspace *models.Workspace) error {
+	if workspace.Name == "" {
+		return models.NewValidationError("name", "name is required")
+	}
+	if _, err := s.userRepo.GetByID(ctx, workspace.OwnerID); err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.workspaceRepo.Create(ctx, workspace); err != nil {
+			return err
+		}
+		return s.memberRepo.Add(ctx, &models.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      workspace.OwnerID,
+			Role:        models.WorkspaceRoleAdmin,
+		})
+	})
+}
+
+// CreatePersonal создает личное рабочее пространство пользователя.
+func (s *WorkspaceService) CreatePersonal(ctx context.Context, user *models.User) (*models.Workspace, error) {
+	workspace := &models.Workspace{
+		Name:     fmt.Sprintf("%s's workspace", user.Name),
+		OwnerID:  user.ID,
+		Personal: true,
+	}
+	if err := s.Create(ctx, workspace); err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+func (s *WorkspaceService) GetByID(ctx context.Context, id uint) (*models.Workspace, error) {
+	return s.workspaceRepo.GetByID(ctx, id)
+}
+
+// GetUserWorkspaces возвращает рабочие пространства, в которых состоит пользователь.
+func (s *WorkspaceService) GetUserWorkspaces(ctx context.Context, userID uint) ([]models.Workspace, error) {
+	return s.workspaceRepo.GetByUserID(ctx, userID)
+}
+
+// Update меняет название и описание рабочего пространства.
+func (s *WorkspaceService) Update(ctx context.Context, workspace *models.Workspace) error {
+	existing, err := s.workspaceRepo.GetByID(ctx, workspace.ID)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(ctx, existing.Version); err != nil {
+		return err
+	}
+	if workspace.Name == "" {
+		return models.NewValidationError("name", "name is required")
+	}
+
+	workspace.Version = existing.Version
+	workspace.OwnerID = existing.OwnerID
+	workspace.Personal = existing.Personal
+	workspace.CreatedAt = existing.CreatedAt
+	return s.workspaceRepo.Update(ctx, workspace)
+}
+
+// Delete удаляет пустое рабочее пространство. Личное пространство удалить нельзя.
+func (s *WorkspaceService) Delete(ctx context.Context, id uint) error {
+	workspace, err := s.workspaceRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if workspace.Personal {
+		return models.ErrPersonalWorkspace
+	}
+	if err := checkVersion(ctx, workspace.Version); err != nil {
+		return err
+	}
+	return s.workspaceRepo.Delete(ctx, id, workspace.Version)
+}
+
+func (s *WorkspaceService) GetMembers(ctx context.Context, workspaceID uint) ([]models.WorkspaceMember, error) {
+	return s.memberRepo.GetByWorkspaceID(ctx, workspaceID)
+}
+
+// AddMember добавляет пользователя в рабочее пространство. В личное пространство
+// других пользователей добавить нельзя.
+func (s *WorkspaceService) AddMember(ctx context.Context, workspaceID, userID uint, role string) (*models.WorkspaceMember, error) {
+	if role == "" {
+		role = models.WorkspaceRoleMember
+	}
+	if !validWorkspaceRole(role) {
+		return nil, models.ErrInvalidWorkspaceRole
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace.Personal {
+		return nil, models.NewValidationError("workspace_id", "personal workspace cannot have other members")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	member := &models.WorkspaceMember{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Role:        role,
+	}
+	if err := s.memberRepo.Add(ctx, member); err != nil {
+		return nil, err
+	}
+
+	member.User = *user
+	return member, nil
+}
+
+// UpdateRole меняет роль участника. Роль владельца не меняется.
+func (s *WorkspaceService) UpdateRole(ctx context.Context, workspaceID, userID uint, role string) error {
+	if !validWorkspaceRole(role) {
+		return models.ErrInvalidWorkspaceRole
+	}
+	if err := s.checkNotOwner(ctx, workspaceID, userID); err != nil {
+		return err
+	}
+	return s.memberRepo.UpdateRole(ctx, workspaceID, userID, role)
+}
+
+// RemoveMember исключает участника из рабочего пространства. Владельца исключить нельзя.
+// Доски, которыми участник владеет, остаются в пространстве.
+func (s *WorkspaceService) RemoveMember(ctx context.Context, workspaceID, userID uint) error {
+	if err := s.checkNotOwner(ctx, workspaceID, userID); err != nil {
+		return err
+	}
+	return s.memberRepo.Remove(ctx, workspaceID, userID)
+}
+
+func (s *WorkspaceService) checkNotOwner(ctx context.Context, workspaceID, userID uint) error {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	if workspace.OwnerID == userID {
+		return models.NewValidationError("user_id", "workspace owner cannot be removed or demoted")
+	}
+	return nil
+}
+
+// Role возвращает роль пользователя в рабочем пространстве. Пространства, в которых
+// пользователь не состоит, считаются ненайденными.
+func (s *WorkspaceService) Role(ctx context.Context, workspaceID, userID uint) (string, error) {
+	member, err := s.memberRepo.Get(ctx, workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrWorkspaceMemberNotFound) {
+			return "", models.ErrWorkspaceNotFound
+		}
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// checkWorkspaceMember проверяет, что пользователь состоит в рабочем пространстве.
+// Чужие пространства считаются ненайденными.
+func checkWorkspaceMember(ctx context.Context, members repository.WorkspaceMemberRepository, workspaceID, userID uint) error {
+	if workspaceID == 0 {
+		return models.NewValidationError("workspace_id", "workspace is required")
+	}
+	if _, err := members.Get(ctx, workspaceID, userID); err != nil {
+		if errors.Is(err, models.ErrWorkspaceMemberNotFound) {
+			return models.ErrWorkspaceNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS board_members (
+    id SERIAL PRIMARY KEY,
+    board_id INTEGER NOT NULL REFERENCES boards(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    role VARCHAR(50) NOT NULL DEFAULT 'member',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (board_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS mentions (
+    id SERIAL PRIMARY KEY,
+    source_type VARCHAR(50) NOT NULL,
+    source_id INTEGER NOT NULL,
+    card_id INTEGER NOT NULL REFERENCES cards(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    author_id INTEGER,
+    handle VARCHAR(255) NOT NULL,
+    span_start INTEGER NOT NULL,
+    span_end INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_mentions_source ON mentions(source_type, source_id);
+CREATE INDEX IF NOT EXISTS idx_mentions_user_id ON mentions(user_id);
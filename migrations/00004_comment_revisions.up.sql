@@ -0,0 +1,17 @@
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments(deleted_at);
+
+CREATE TABLE IF NOT EXISTS comment_revisions (
+    id SERIAL PRIMARY KEY,
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    edited_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (comment_id, version)
+);
+
+INSERT INTO comment_revisions (comment_id, version, content, edited_by, created_at)
+SELECT id, 1, content, user_id, created_at FROM comments
+ON CONFLICT (comment_id, version) DO NOTHING;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS card_watchers (
+    id SERIAL PRIMARY KEY,
+    card_id INTEGER NOT NULL REFERENCES cards(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (card_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_card_watchers_user_id ON card_watchers(user_id);
+
+-- Исполнители и авторы комментариев автоматически становятся наблюдателями существующих карточек.
+INSERT INTO card_watchers (card_id, user_id)
+SELECT id, assigned_to FROM cards WHERE assigned_to IS NOT NULL
+ON CONFLICT (card_id, user_id) DO NOTHING;
+
+INSERT INTO card_watchers (card_id, user_id)
+SELECT DISTINCT card_id, user_id FROM comments
+ON CONFLICT (card_id, user_id) DO NOTHING;
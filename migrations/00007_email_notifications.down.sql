@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS email_preferences;
+DROP TABLE IF EXISTS email_outbox;
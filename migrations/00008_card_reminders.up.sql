@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS card_reminders (
+    id SERIAL PRIMARY KEY,
+    card_id INTEGER NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    offset_seconds BIGINT NOT NULL DEFAULT 0,
+    due_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    fire_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_card_reminders_unique ON card_reminders(card_id, kind, offset_seconds, due_date);
+CREATE INDEX IF NOT EXISTS idx_card_reminders_pending ON card_reminders(fire_at) WHERE sent_at IS NULL;
+
+-- Напоминания для существующих карточек планируются при запуске сервера.
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_event;
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries(event_id);
+
+DROP TABLE IF EXISTS outbox;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS board_event_log (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(64) NOT NULL UNIQUE,
+    board_id INTEGER NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_board_event_log_board ON board_event_log(board_id, id);
+CREATE INDEX IF NOT EXISTS idx_board_event_log_created_at ON board_event_log(created_at);
@@ -0,0 +1,14 @@
+-- Присутствие эфемерно, поэтому таблица не журналируется: после сбоя она пуста, и клиенты
+-- регистрируются заново при следующем heartbeat.
+CREATE UNLOGGED TABLE IF NOT EXISTS board_presences (
+    board_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    session_id VARCHAR(64) NOT NULL,
+    card_id INTEGER,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (board_id, user_id, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_board_presences_expires_at ON board_presences(expires_at);
@@ -0,0 +1,5 @@
+ALTER TABLE comments DROP COLUMN IF EXISTS version;
+ALTER TABLE labels DROP COLUMN IF EXISTS version;
+ALTER TABLE cards DROP COLUMN IF EXISTS version;
+ALTER TABLE columns DROP COLUMN IF EXISTS version;
+ALTER TABLE boards DROP COLUMN IF EXISTS version;
@@ -0,0 +1,25 @@
+ALTER TABLE columns ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+
+UPDATE columns SET position = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY board_id ORDER BY rank, id) - 1 AS position
+    FROM columns
+) AS ordered
+WHERE columns.id = ordered.id;
+
+UPDATE cards SET position = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY column_id ORDER BY rank, id) - 1 AS position
+    FROM cards
+) AS ordered
+WHERE cards.id = ordered.id;
+
+ALTER TABLE columns ALTER COLUMN position DROP DEFAULT;
+ALTER TABLE cards ALTER COLUMN position DROP DEFAULT;
+
+DROP INDEX IF EXISTS idx_cards_column_rank;
+DROP INDEX IF EXISTS idx_columns_board_rank;
+
+ALTER TABLE cards DROP COLUMN IF EXISTS rank;
+ALTER TABLE columns DROP COLUMN IF EXISTS rank;
@@ -0,0 +1,24 @@
+ALTER TABLE columns ADD COLUMN IF NOT EXISTS rank VARCHAR(255) COLLATE "C" NOT NULL DEFAULT '';
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS rank VARCHAR(255) COLLATE "C" NOT NULL DEFAULT '';
+
+-- Ранги фиксированной ширины из прежних позиций: восемь цифр и "i" в конце, чтобы ранг
+-- не заканчивался нулем. Фоновая перебалансировка со временем заменит их короткими.
+UPDATE columns SET rank = ranked.rank
+FROM (
+    SELECT id, LPAD(ROW_NUMBER() OVER (PARTITION BY board_id ORDER BY position, id)::TEXT, 8, '0') || 'i' AS rank
+    FROM columns
+) AS ranked
+WHERE columns.id = ranked.id;
+
+UPDATE cards SET rank = ranked.rank
+FROM (
+    SELECT id, LPAD(ROW_NUMBER() OVER (PARTITION BY column_id ORDER BY position, id)::TEXT, 8, '0') || 'i' AS rank
+    FROM cards
+) AS ranked
+WHERE cards.id = ranked.id;
+
+ALTER TABLE columns DROP COLUMN IF EXISTS position;
+ALTER TABLE cards DROP COLUMN IF EXISTS position;
+
+CREATE INDEX IF NOT EXISTS idx_columns_board_rank ON columns(board_id, rank) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_cards_column_rank ON cards(column_id, rank) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE columns DROP COLUMN IF EXISTS wip_limit;
+ALTER TABLE boards DROP COLUMN IF EXISTS wip_mode;
@@ -0,0 +1,3 @@
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS wip_mode VARCHAR(16) NOT NULL DEFAULT 'warn'
+    CHECK (wip_mode IN ('warn', 'block'));
+ALTER TABLE columns ADD COLUMN IF NOT EXISTS wip_limit INTEGER CHECK (wip_limit > 0);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_cards_swimlane_id;
+ALTER TABLE cards DROP COLUMN IF EXISTS swimlane_id;
+DROP TABLE IF EXISTS swimlanes;
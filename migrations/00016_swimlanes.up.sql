@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS swimlanes (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    rank VARCHAR(255) COLLATE "C" NOT NULL DEFAULT '',
+    board_id INTEGER NOT NULL REFERENCES boards(id),
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_swimlanes_board_rank ON swimlanes(board_id, rank) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_swimlanes_deleted_at ON swimlanes(deleted_at);
+
+-- Карточки без дорожки относятся к дорожке по умолчанию.
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS swimlane_id INTEGER REFERENCES swimlanes(id);
+CREATE INDEX IF NOT EXISTS idx_cards_swimlane_id ON cards(swimlane_id);
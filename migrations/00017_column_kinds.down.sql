@@ -0,0 +1,3 @@
+ALTER TABLE cards DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE cards DROP COLUMN IF EXISTS started_at;
+ALTER TABLE columns DROP COLUMN IF EXISTS kind;
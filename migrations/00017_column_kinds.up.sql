@@ -0,0 +1,15 @@
+ALTER TABLE columns ADD COLUMN IF NOT EXISTS kind VARCHAR(16) NOT NULL DEFAULT 'todo'
+    CHECK (kind IN ('backlog', 'todo', 'in_progress', 'review', 'done'));
+
+-- Раньше завершенные колонки определялись по названию; сохраняем это для существующих досок.
+UPDATE columns SET kind = 'done'
+WHERE LOWER(TRIM(title)) IN ('done', 'completed', 'closed', 'готово', 'сделано');
+
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS started_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE cards ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP WITH TIME ZONE;
+
+-- Момент начала работы над уже завершенными карточками неизвестен, поэтому started_at
+-- остается пустым; завершение приближенно берется из последнего изменения.
+UPDATE cards SET completed_at = cards.updated_at
+FROM columns
+WHERE columns.id = cards.column_id AND columns.kind = 'done';
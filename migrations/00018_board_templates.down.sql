@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_board_templates_owner_id;
+DROP TABLE IF EXISTS board_templates;
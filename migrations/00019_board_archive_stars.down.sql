@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS board_views;
+DROP TABLE IF EXISTS board_stars;
+DROP INDEX IF EXISTS idx_boards_archived_at;
+ALTER TABLE boards DROP COLUMN IF EXISTS last_activity_at;
+ALTER TABLE boards DROP COLUMN IF EXISTS archived_at;
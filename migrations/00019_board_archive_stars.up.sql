@@ -0,0 +1,25 @@
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS last_activity_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_boards_archived_at ON boards(archived_at);
+
+-- До появления колонки активностью доски считается ее последнее изменение.
+UPDATE boards SET last_activity_at = updated_at;
+
+CREATE TABLE IF NOT EXISTS board_stars (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    board_id INTEGER NOT NULL REFERENCES boards(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, board_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_board_stars_board_id ON board_stars(board_id);
+
+CREATE TABLE IF NOT EXISTS board_views (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    board_id INTEGER NOT NULL REFERENCES boards(id),
+    viewed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, board_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_board_views_board_id ON board_views(board_id);
+CREATE INDEX IF NOT EXISTS idx_board_views_user_viewed ON board_views(user_id, viewed_at);
@@ -0,0 +1 @@
+-- Данные не откатываются: различить потомков, удаленных вручную и каскадом, невозможно.
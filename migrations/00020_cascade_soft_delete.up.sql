@@ -0,0 +1,22 @@
+-- Доски и колонки, удаленные до каскадного удаления, оставляли потомков доступными.
+-- Помечаем таких потомков удаленными тем же временем, что и родителя, чтобы восстановление
+-- доски вернуло их вместе с ней.
+UPDATE columns c
+SET deleted_at = b.deleted_at
+FROM boards b
+WHERE c.board_id = b.id AND b.deleted_at IS NOT NULL AND c.deleted_at IS NULL;
+
+UPDATE swimlanes s
+SET deleted_at = b.deleted_at
+FROM boards b
+WHERE s.board_id = b.id AND b.deleted_at IS NOT NULL AND s.deleted_at IS NULL;
+
+UPDATE cards ca
+SET deleted_at = c.deleted_at
+FROM columns c
+WHERE ca.column_id = c.id AND c.deleted_at IS NOT NULL AND ca.deleted_at IS NULL;
+
+UPDATE comments co
+SET deleted_at = ca.deleted_at
+FROM cards ca
+WHERE co.card_id = ca.id AND ca.deleted_at IS NOT NULL AND co.deleted_at IS NULL;
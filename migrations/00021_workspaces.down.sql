@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_labels_workspace_id;
+ALTER TABLE labels DROP COLUMN IF EXISTS workspace_id;
+DROP INDEX IF EXISTS idx_boards_workspace_id;
+ALTER TABLE boards DROP COLUMN IF EXISTS workspace_id;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
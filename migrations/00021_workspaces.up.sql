@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS workspaces (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    owner_id INTEGER NOT NULL REFERENCES users(id),
+    personal BOOLEAN NOT NULL DEFAULT FALSE,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspaces_owner_id ON workspaces(owner_id);
+-- У каждого пользователя одно личное рабочее пространство.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workspaces_personal ON workspaces(owner_id) WHERE personal;
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    id SERIAL PRIMARY KEY,
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    role VARCHAR(50) NOT NULL DEFAULT 'member',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workspace_members_workspace_user ON workspace_members(workspace_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id ON workspace_members(user_id);
+
+-- Существующие пользователи получают личные пространства, а их доски переносятся туда.
+INSERT INTO workspaces (name, owner_id, personal)
+SELECT name || '''s workspace', id, TRUE
+FROM users
+WHERE NOT EXISTS (SELECT 1 FROM workspaces w WHERE w.owner_id = users.id AND w.personal);
+
+INSERT INTO workspace_members (workspace_id, user_id, role)
+SELECT id, owner_id, 'admin'
+FROM workspaces
+WHERE personal
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS workspace_id INTEGER REFERENCES workspaces(id);
+
+UPDATE boards b
+SET workspace_id = w.id
+FROM workspaces w
+WHERE w.owner_id = b.owner_id AND w.personal AND b.workspace_id IS NULL;
+
+ALTER TABLE boards ALTER COLUMN workspace_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_boards_workspace_id ON boards(workspace_id);
+
+-- Общие метки: метка доски, которую можно назначать карточкам всех досок пространства.
+ALTER TABLE labels ADD COLUMN IF NOT EXISTS workspace_id INTEGER REFERENCES workspaces(id);
+CREATE INDEX IF NOT EXISTS idx_labels_workspace_id ON labels(workspace_id);
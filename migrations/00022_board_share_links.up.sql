@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS board_share_links (
+    id SERIAL PRIMARY KEY,
+    board_id INTEGER NOT NULL REFERENCES boards(id),
+    token VARCHAR(255) NOT NULL,
+    password_hash TEXT NOT NULL DEFAULT '',
+    show_comments BOOLEAN NOT NULL DEFAULT FALSE,
+    show_emails BOOLEAN NOT NULL DEFAULT FALSE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_board_share_links_token ON board_share_links(token);
+CREATE INDEX IF NOT EXISTS idx_board_share_links_board_id ON board_share_links(board_id);
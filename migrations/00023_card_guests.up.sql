@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS card_guests (
+    card_id INTEGER NOT NULL REFERENCES cards(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (card_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_card_guests_user_id ON card_guests(user_id);
@@ -28,11 +28,33 @@ func NewPostgresDB(cfg *config.Config) (*gorm.DB, error) {
 	if cfg.App.Env == "development" {
 		err = db.AutoMigrate(
 			&models.User{},
+			&models.Workspace{},
+			&models.WorkspaceMember{},
 			&models.Board{},
+			&models.BoardStar{},
+			&models.BoardView{},
+			&models.BoardShareLink{},
+			&models.BoardTemplate{},
 			&models.Column{},
+			&models.Swimlane{},
 			&models.Card{},
 			&models.Label{},
 			&models.Comment{},
+			&models.BoardMember{},
+			&models.CardGuest{},
+			&models.Mention{},
+			&models.CommentRevision{},
+			&models.CardWatcher{},
+			&models.Notification{},
+			&models.NotificationPreference{},
+			&models.OutboundEmail{},
+			&models.EmailPreference{},
+			&models.CardReminder{},
+			&models.Webhook{},
+			&models.WebhookDelivery{},
+			&models.OutboxEvent{},
+			&models.BoardEventLog{},
+			&models.BoardPresence{},
 		)
 		if err != nil {
 			return nil, fmt.Errorf("warning: Auto migration failed: %v", err)
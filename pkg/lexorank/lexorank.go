@@ -0,0 +1,158 @@
+// Package lexorank строит строковые ранги для упорядоченных списков. Между любыми двумя
+// рангами всегда найдется третий, поэтому при перемещении элемента меняется только его ранг.
+//
+// Ранг — дробная часть числа в системе счисления по основанию 36 (цифры 0-9 и a-z)
+// без завершающих нулей. Ранги сравниваются побайтно; в PostgreSQL колонка с рангом
+// должна использовать COLLATE "C".
+package lexorank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+var (
+	ErrInvalidRank  = errors.New("lexorank: invalid rank")
+	ErrInvalidRange = errors.New("lexorank: lower rank must be less than upper rank")
+)
+
+// Between возвращает ранг строго между lower и upper. Пустой lower означает начало
+// списка, пустой upper — его конец. У краев списка ранг отступает от соседа на
+// единицу младшего возможного разряда, а не делит промежуток пополам, поэтому
+// при добавлении в конец ранги удлиняются медленно.
+func Between(lower, upper string) (string, error) {
+	if !Valid(lower) || !Valid(upper) {
+		return "", ErrInvalidRank
+	}
+	switch {
+	case upper == "":
+		return after(lower), nil
+	case lower >= upper:
+		return "", ErrInvalidRange
+	case lower == "":
+		return before(upper), nil
+	}
+	return midpoint(lower, upper), nil
+}
+
+// Valid сообщает, является ли строка допустимым рангом. Пустая строка допустима
+// и обозначает границу списка.
+func Valid(rank string) bool {
+	if strings.HasSuffix(rank, digits[:1]) {
+		return false
+	}
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(digits, rank[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Spread возвращает n возрастающих рангов с равными промежутками. Ранги получаются
+// на один разряд длиннее минимально необходимого, чтобы между соседями оставалось место.
+func Spread(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	width, capacity := 1, base
+	for capacity <= n {
+		width++
+		capacity *= base
+	}
+	width++
+	capacity *= base
+
+	step := capacity / (n + 1)
+	ranks := make([]string, n)
+	buf := make([]byte, width)
+	for i := range ranks {
+		value := step * (i + 1)
+		for j := width - 1; j >= 0; j-- {
+			buf[j] = digits[value%base]
+			value /= base
+		}
+		ranks[i] = strings.TrimRight(string(buf), digits[:1])
+	}
+	return ranks
+}
+
+// after возвращает ближайший больший ранг, увеличивая самый старший разряд, который
+// еще можно увеличить.
+func after(a string) string {
+	for i := 0; i < len(a); i++ {
+		if d := strings.IndexByte(digits, a[i]); d < base-1 {
+			return a[:i] + string(digits[d+1])
+		}
+	}
+	return a + digits[1:2]
+}
+
+// before возвращает меньший ранг, уменьшая самый старший разряд, который еще можно
+// уменьшить без завершающего нуля.
+func before(b string) string {
+	for i := 0; i < len(b); i++ {
+		switch d := strings.IndexByte(digits, b[i]); {
+		case d > 1:
+			return b[:i] + string(digits[d-1])
+		case d == 1 && i < len(b)-1:
+			return b[:i+1]
+		case d == 1:
+			return b[:i] + digits[:1] + digits[base-1:]
+		}
+	}
+	return midpoint("", b)
+}
+
+// midpoint ищет ранг между a и b, где a < b, а пустой b означает единицу.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Общий префикс переносится в результат как есть; недостающие разряды a считаются нулями.
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	lo := 0
+	if a != "" {
+		lo = strings.IndexByte(digits, a[0])
+	}
+	hi := base
+	if b != "" {
+		hi = strings.IndexByte(digits, b[0])
+	}
+
+	if hi-lo > 1 {
+		return string(digits[(lo+hi)/2])
+	}
+	// Первые разряды соседние: если у b есть продолжение, подойдет его первый разряд,
+	// иначе берем первый разряд a и ищем середину между остатком a и единицей.
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(digits[lo]) + midpoint(rest, "")
+}
+
+func digitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return digits[0]
+}
@@ -0,0 +1,262 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// checkBetween проверяет, что rank — допустимый ранг строго между lower и upper.
+func checkBetween(t *testing.T, lower, upper, rank string) {
+	t.Helper()
+	if rank == "" || !Valid(rank) {
+		t.Fatalf("Between(%q, %q) = %q, want a valid non-empty rank", lower, upper, rank)
+	}
+	if rank <= lower || (upper != "" && rank >= upper) {
+		t.Fatalf("Between(%q, %q) = %q, want a rank strictly between the bounds", lower, upper, rank)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name         string
+		lower, upper string
+		want         string
+	}{
+		{name: "empty list", lower: "", upper: "", want: "1"},
+		{name: "append", lower: "i", upper: "", want: "j"},
+		{name: "append after last digit", lower: "z", upper: "", want: "z1"},
+		{name: "append after all z", lower: "zzz", upper: "", want: "zzz1"},
+		{name: "prepend", lower: "", upper: "i", want: "h"},
+		{name: "prepend before one", lower: "", upper: "1", want: "0z"},
+		{name: "prepend before longer one", lower: "", upper: "11", want: "1"},
+		{name: "prepend before leading zeros", lower: "", upper: "001", want: "000z"},
+		{name: "wide gap", lower: "1", upper: "z", want: "i"},
+		{name: "adjacent digits", lower: "a", upper: "b", want: "ai"},
+		{name: "adjacent with tail", lower: "a", upper: "b1", want: "b"},
+		{name: "prefix of upper", lower: "a", upper: "a1", want: "a0i"},
+		{name: "shared prefix", lower: "abc", upper: "abz", want: "abn"},
+		{name: "lower longer than upper", lower: "ay", upper: "b", want: "az"},
+		{name: "lower ends with z", lower: "az", upper: "b", want: "azi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.lower, tt.upper)
+			if err != nil {
+				t.Fatalf("Between(%q, %q) error: %v", tt.lower, tt.upper, err)
+			}
+			checkBetween(t, tt.lower, tt.upper, got)
+			if got != tt.want {
+				t.Errorf("Between(%q, %q) = %q, want %q", tt.lower, tt.upper, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBetweenErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		lower, upper string
+		want         error
+	}{
+		{name: "equal ranks", lower: "a", upper: "a", want: ErrInvalidRange},
+		{name: "reversed ranks", lower: "b", upper: "a", want: ErrInvalidRange},
+		{name: "trailing zero", lower: "a0", upper: "", want: ErrInvalidRank},
+		{name: "upper case", lower: "", upper: "A", want: ErrInvalidRank},
+		{name: "foreign byte", lower: "a-b", upper: "c", want: ErrInvalidRank},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Between(tt.lower, tt.upper); !errors.Is(err, tt.want) {
+				t.Errorf("Between(%q, %q) error = %v, want %v", tt.lower, tt.upper, err, tt.want)
+			}
+		})
+	}
+}
+
+// TestBetweenRepeatedHalving вставляет ранги снова и снова в одно и то же место, как при
+// перетаскивании карточек на одну позицию, пока ранг не станет длиннее порога перераздачи.
+func TestBetweenRepeatedHalving(t *testing.T) {
+	const maxLength = 12
+
+	tests := []struct {
+		name string
+		next func(lower, upper, prev string) (string, string)
+		// minInserts — сколько вставок должно поместиться до перераздачи.
+		minInserts int
+	}{
+		{
+			name:       "towards lower",
+			next:       func(lower, upper, prev string) (string, string) { return lower, prev },
+			minInserts: 50,
+		},
+		{
+			name:       "towards upper",
+			next:       func(lower, upper, prev string) (string, string) { return prev, upper },
+			minInserts: 50,
+		},
+		{
+			name:       "at the front",
+			next:       func(lower, upper, prev string) (string, string) { return "", prev },
+			minInserts: 300,
+		},
+		{
+			name:       "at the end",
+			next:       func(lower, upper, prev string) (string, string) { return prev, "" },
+			minInserts: 300,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lower, upper := "a", "b"
+			prev, err := Between(lower, upper)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			inserts := 1
+			for len(prev) <= maxLength {
+				lo, hi := tt.next(lower, upper, prev)
+				rank, err := Between(lo, hi)
+				if err != nil {
+					t.Fatalf("insert %d: Between(%q, %q) error: %v", inserts, lo, hi, err)
+				}
+				checkBetween(t, lo, hi, rank)
+				prev = rank
+				inserts++
+			}
+			if inserts < tt.minInserts {
+				t.Errorf("rank exceeded %d characters after %d inserts, want at least %d", maxLength, inserts, tt.minInserts)
+			}
+
+			// После перераздачи ранги снова короткие и между соседями есть место.
+			ranks := Spread(inserts)
+			for i := 1; i < len(ranks); i++ {
+				checkBetween(t, ranks[i-1], "", ranks[i])
+				if _, err := Between(ranks[i-1], ranks[i]); err != nil {
+					t.Fatalf("Between(%q, %q) after Spread error: %v", ranks[i-1], ranks[i], err)
+				}
+			}
+		})
+	}
+}
+
+func TestSpread(t *testing.T) {
+	tests := []struct {
+		n         int
+		maxLength int
+	}{
+		{n: 0},
+		{n: -1},
+		{n: 1, maxLength: 2},
+		{n: 35, maxLength: 2},
+		{n: 36, maxLength: 3},
+		{n: 1000, maxLength: 3},
+		{n: 1296, maxLength: 4},
+		{n: 50000, maxLength: 5},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.n), func(t *testing.T) {
+			ranks := Spread(tt.n)
+			if tt.n <= 0 {
+				if ranks != nil {
+					t.Fatalf("Spread(%d) = %v, want nil", tt.n, ranks)
+				}
+				return
+			}
+			if len(ranks) != tt.n {
+				t.Fatalf("Spread(%d) returned %d ranks", tt.n, len(ranks))
+			}
+
+			prev := ""
+			for i, rank := range ranks {
+				if rank == "" || !Valid(rank) {
+					t.Fatalf("rank %d = %q is not valid", i, rank)
+				}
+				if rank <= prev {
+					t.Fatalf("rank %d = %q is not greater than %q", i, rank, prev)
+				}
+				if len(rank) > tt.maxLength {
+					t.Fatalf("rank %d = %q is longer than %d", i, rank, tt.maxLength)
+				}
+				// Между соседями помещается ранг той же длины, что и сами ранги, плюс один разряд.
+				mid, err := Between(prev, rank)
+				if err != nil {
+					t.Fatalf("Between(%q, %q) error: %v", prev, rank, err)
+				}
+				if len(mid) > tt.maxLength+1 {
+					t.Fatalf("Between(%q, %q) = %q, want at most %d characters", prev, rank, mid, tt.maxLength+1)
+				}
+				prev = rank
+			}
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	for rank, want := range map[string]bool{
+		"":      true,
+		"1":     true,
+		"0z":    true,
+		"abc":   true,
+		"0":     false,
+		"a0":    false,
+		"A":     false,
+		"a b":   false,
+		"ё":     false,
+		"zzzz1": true,
+	} {
+		if got := Valid(rank); got != want {
+			t.Errorf("Valid(%q) = %v, want %v", rank, got, want)
+		}
+	}
+}
+
+// BenchmarkMove сравнивает перемещение элемента в начало списка по рангам и по целочисленным
+// позициям, как было до рангов: там при вставке сдвигаются позиции всех следующих элементов.
+// Метрика rows/op — сколько записей пришлось бы обновить в базе.
+func BenchmarkMove(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("rank/%d", n), func(b *testing.B) {
+			ranks := Spread(n)
+			first := ranks[0]
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// Последний элемент переносится в начало: меняется только его ранг.
+				rank, err := Between("", first)
+				if err != nil {
+					b.Fatal(err)
+				}
+				first = rank
+				if len(first) > 12 {
+					// Здесь список перераздал бы фоновый RankBalancer.
+					first = ranks[0]
+				}
+			}
+			b.ReportMetric(1, "rows/op")
+		})
+
+		b.Run(fmt.Sprintf("position/%d", n), func(b *testing.B) {
+			positions := make([]int, n)
+			for i := range positions {
+				positions[i] = i
+			}
+			order := make([]int, n)
+			for i := range order {
+				order[i] = i
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// Последний элемент переносится в начало: позиции остальных увеличиваются на единицу.
+				moved := order[n-1]
+				for _, item := range order[:n-1] {
+					positions[item]++
+				}
+				positions[moved] = 0
+				copy(order[1:], order[:n-1])
+				order[0] = moved
+			}
+			b.ReportMetric(float64(n), "rows/op")
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package mailer собирает multipart-письма и отправляет их по SMTP.
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Message — письмо с текстовой и HTML-версией. Если HTML пуст, письмо отправляется как text/plain.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Bytes сериализует письмо в формат RFC 5322.
+func (m *Message) Bytes() ([]byte, error) {
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+	if len(m.To) == 0 {
+		return nil, fmt.Errorf("message has no recipients")
+	}
+
+	to := make([]string, 0, len(m.To))
+	for _, addr := range m.To {
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient address %q: %w", addr, err)
+		}
+		to = append(to, parsed.String())
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", from.String())
+	writeHeader(&buf, "To", strings.Join(to, ", "))
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-ID", messageID(from.Address))
+	writeHeader(&buf, "MIME-Version", "1.0")
+
+	if m.HTML == "" {
+		writeHeader(&buf, "Content-Type", `text/plain; charset="utf-8"`)
+		writeHeader(&buf, "Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, m.Text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(&buf)
+	writeHeader(&buf, "Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", mw.Boundary()))
+	buf.WriteString("\r\n")
+
+	parts := []struct {
+		contentType string
+		body        string
+	}{
+		{`text/plain; charset="utf-8"`, m.Text},
+		{`text/html; charset="utf-8"`, m.HTML},
+	}
+	for _, part := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+		w, err := mw.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("creating message part: %w", err)
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("closing multipart message: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+	buf.WriteString(": ")
+	buf.WriteString(value)
+	buf.WriteString("\r\n")
+}
+
+func writeQuotedPrintable(w interface{ Write([]byte) (int, error) }, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return fmt.Errorf("encoding message body: %w", err)
+	}
+	return qp.Close()
+}
+
+func messageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = from[at+1:]
+	}
+
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain)
+}
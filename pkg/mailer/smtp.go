@@ -0,0 +1,115 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// ErrAuthNotSupported возвращается, если задано имя пользователя, а сервер не объявляет AUTH.
+var ErrAuthNotSupported = errors.New("mailer: SMTP server does not support authentication")
+
+// Sender отправляет письма.
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+type Config struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+// SMTPSender отправляет письма через SMTP-сервер, используя STARTTLS, если сервер его поддерживает.
+type SMTPSender struct {
+	cfg Config
+}
+
+func NewSMTPSender(cfg Config) *SMTPSender {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	return &SMTPSender{cfg: cfg}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	body, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(s.cfg.Host, s.cfg.Port)
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("connecting to SMTP server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("starting SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return fmt.Errorf("starting TLS: %w", err)
+		}
+	}
+
+	// Без AUTH сервер принял бы письмо анонимно, и ошибку в настройках никто бы не заметил.
+	if s.cfg.Username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return ErrAuthNotSupported
+		}
+		auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("authenticating: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("setting sender: %w", err)
+	}
+	for _, rcpt := range msg.To {
+		to, err := mail.ParseAddress(rcpt)
+		if err != nil {
+			return fmt.Errorf("invalid recipient address %q: %w", rcpt, err)
+		}
+		if err := client.Rcpt(to.Address); err != nil {
+			return fmt.Errorf("setting recipient: %w", err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("starting message data: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("writing message data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("finishing message data: %w", err)
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,107 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"testing"
+
+	"github.com/octaview/kanban-octaview/pkg/mailer/smtptest"
+)
+
+func TestSMTPSenderSendsMultipartAlternative(t *testing.T) {
+	server, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	sender := NewSMTPSender(Config{Host: server.Host(), Port: server.Port(), Username: "kanban", Password: "secret"})
+	err = sender.Send(context.Background(), &Message{
+		From:    "Kanban <noreply@kanban.example.com>",
+		To:      []string{"Анна <anna@example.com>"},
+		Subject: "Новый комментарий",
+		Text:    "Карточка «Релиз» обновлена",
+		HTML:    "<p>Карточка «Релиз» обновлена</p>",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	messages := server.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("server received %d messages, want 1", len(messages))
+	}
+	received := messages[0]
+	if received.From != "noreply@kanban.example.com" || len(received.To) != 1 || received.To[0] != "anna@example.com" {
+		t.Errorf("envelope = %s -> %v", received.From, received.To)
+	}
+
+	msg, err := received.Message()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "Новый комментарий" {
+		t.Errorf("subject = %q, %v", subject, err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("content type = %q, %v", mediaType, err)
+	}
+
+	want := []struct{ contentType, body string }{
+		{"text/plain", "Карточка «Релиз» обновлена"},
+		{"text/html", "<p>Карточка «Релиз» обновлена</p>"},
+	}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for i, w := range want {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("part %d: %v", i, err)
+		}
+		contentType, partParams, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if contentType != w.contentType || partParams["charset"] != "utf-8" {
+			t.Errorf("part %d content type = %q", i, part.Header.Get("Content-Type"))
+		}
+		// multipart.Reader сам снимает quoted-printable.
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != w.body {
+			t.Errorf("part %d body = %q, want %q", i, body, w.body)
+		}
+	}
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Errorf("extra part after HTML: %v", err)
+	}
+}
+
+func TestSMTPSenderRequiresAuthWhenConfigured(t *testing.T) {
+	server, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	server.DisableAuth()
+
+	msg := &Message{From: "noreply@kanban.example.com", To: []string{"anna@example.com"}, Text: "hello"}
+
+	sender := NewSMTPSender(Config{Host: server.Host(), Port: server.Port(), Username: "kanban", Password: "secret"})
+	if err := sender.Send(context.Background(), msg); !errors.Is(err, ErrAuthNotSupported) {
+		t.Fatalf("Send with credentials = %v, want ErrAuthNotSupported", err)
+	}
+	if n := len(server.Messages()); n != 0 {
+		t.Fatalf("server accepted %d messages without authentication", n)
+	}
+
+	// Без имени пользователя аутентификация не нужна.
+	sender = NewSMTPSender(Config{Host: server.Host(), Port: server.Port()})
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send without credentials: %v", err)
+	}
+}
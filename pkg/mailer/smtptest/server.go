@@ -0,0 +1,198 @@
+// Package smtptest предоставляет SMTP-сервер в памяти процесса для проверки отправки писем
+// без внешней инфраструктуры. Сервер принимает любые адреса и не поддерживает TLS.
+package smtptest
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// Received — письмо, принятое сервером.
+type Received struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// Message разбирает принятые данные как письмо RFC 5322.
+func (r Received) Message() (*mail.Message, error) {
+	return mail.ReadMessage(strings.NewReader(string(r.Data)))
+}
+
+type Server struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	messages []Received
+	failures int
+	noAuth   bool
+	wg       sync.WaitGroup
+}
+
+// NewServer запускает сервер на случайном локальном порту.
+func NewServer() (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{listener: ln}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.listener.Addr().String())
+	return host
+}
+
+func (s *Server) Port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+// Messages возвращает копию принятых писем.
+func (s *Server) Messages() []Received {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Received(nil), s.messages...)
+}
+
+// FailNext заставляет сервер отклонить следующие n писем временной ошибкой 451.
+func (s *Server) FailNext(n int) {
+	s.mu.Lock()
+	s.failures = n
+	s.mu.Unlock()
+}
+
+// DisableAuth убирает AUTH из расширений, которые объявляет сервер.
+func (s *Server) DisableAuth() {
+	s.mu.Lock()
+	s.noAuth = true
+	s.mu.Unlock()
+}
+
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	reply := func(code int, msg string) {
+		_ = tp.PrintfLine("%d %s", code, msg)
+	}
+
+	reply(220, "smtptest ready")
+
+	var current Received
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			_ = tp.PrintfLine("250-smtptest")
+			if s.authEnabled() {
+				_ = tp.PrintfLine("250-AUTH PLAIN")
+			}
+			_ = tp.PrintfLine("250 8BITMIME")
+		case "HELO":
+			reply(250, "smtptest")
+		case "AUTH":
+			if !s.authEnabled() {
+				reply(502, "command AUTH not implemented")
+				continue
+			}
+			reply(235, "authentication succeeded")
+		case "MAIL":
+			current = Received{From: extractAddress(arg)}
+			reply(250, "ok")
+		case "RCPT":
+			current.To = append(current.To, extractAddress(arg))
+			reply(250, "ok")
+		case "DATA":
+			if len(current.To) == 0 {
+				reply(503, "need RCPT command")
+				continue
+			}
+			reply(354, "end data with <CR><LF>.<CR><LF>")
+
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.Data = data
+
+			if s.accept(current) {
+				reply(250, "queued")
+			} else {
+				reply(451, "temporary failure")
+			}
+			current = Received{}
+		case "RSET":
+			current = Received{}
+			reply(250, "ok")
+		case "NOOP":
+			reply(250, "ok")
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(502, fmt.Sprintf("command %s not implemented", verb))
+		}
+	}
+}
+
+func (s *Server) authEnabled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.noAuth
+}
+
+func (s *Server) accept(msg Received) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return false
+	}
+	s.messages = append(s.messages, msg)
+	return true
+}
+
+func extractAddress(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr = strings.TrimSpace(addr)
+	if i := strings.IndexByte(addr, ' '); i >= 0 {
+		addr = addr[:i]
+	}
+	return strings.Trim(addr, "<>")
+}
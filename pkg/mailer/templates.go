@@ -0,0 +1,82 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+const (
+	layoutTemplate = "layout.html.tmpl"
+	textSuffix     = ".txt.tmpl"
+	htmlSuffix     = ".html.tmpl"
+)
+
+// Templates хранит шаблоны писем. Каждое письмо name описывается парой файлов:
+// name.txt.tmpl с блоком "subject" и текстом письма и name.html.tmpl с блоком "content",
+// который подставляется в общий layout.html.tmpl.
+type Templates struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+func NewTemplates(fsys fs.FS) (*Templates, error) {
+	names, err := fs.Glob(fsys, "*"+textSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Templates{
+		text: make(map[string]*texttemplate.Template),
+		html: make(map[string]*htmltemplate.Template),
+	}
+	for _, file := range names {
+		name := strings.TrimSuffix(path.Base(file), textSuffix)
+
+		text, err := texttemplate.ParseFS(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("parsing text template %s: %w", name, err)
+		}
+		if text.Lookup("subject") == nil {
+			return nil, fmt.Errorf("text template %s has no subject block", name)
+		}
+		t.text[name] = text
+
+		html, err := htmltemplate.ParseFS(fsys, layoutTemplate, name+htmlSuffix)
+		if err != nil {
+			return nil, fmt.Errorf("parsing html template %s: %w", name, err)
+		}
+		t.html[name] = html
+	}
+
+	return t, nil
+}
+
+// Render заполняет тему и обе версии письма. Адреса отправителя и получателя не задаются.
+func (t *Templates) Render(name string, data any) (*Message, error) {
+	text, ok := t.text[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, body, html bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("rendering subject for %s: %w", name, err)
+	}
+	if err := text.ExecuteTemplate(&body, name+textSuffix, data); err != nil {
+		return nil, fmt.Errorf("rendering text for %s: %w", name, err)
+	}
+	if err := t.html[name].ExecuteTemplate(&html, layoutTemplate, data); err != nil {
+		return nil, fmt.Errorf("rendering html for %s: %w", name, err)
+	}
+
+	return &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(body.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
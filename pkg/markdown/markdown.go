@@ -0,0 +1,171 @@
+package markdown
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+type Config struct {
+	// CacheSize — число отрендеренных документов, которые хранятся в памяти.
+	CacheSize int
+	// MentionURL и CardURL — шаблоны ссылок для упоминаний и ключей карточек, например "/users/%d".
+	MentionURL string
+	CardURL    string
+}
+
+// Renderer превращает CommonMark/GFM в безопасный HTML. Результаты кешируются по хешу
+// исходного текста и набора упоминаний.
+type Renderer struct {
+	md        goldmark.Markdown
+	policy    *bluemonday.Policy
+	cacheSize int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type cacheEntry struct {
+	key  string
+	html string
+}
+
+func NewRenderer(cfg Config) *Renderer {
+	if cfg.MentionURL == "" {
+		cfg.MentionURL = "/users/%d"
+	}
+	if cfg.CardURL == "" {
+		cfg.CardURL = "/cards/%d"
+	}
+
+	return &Renderer{
+		md:        newMarkdown(cfg.MentionURL, cfg.CardURL),
+		policy:    newPolicy(),
+		cacheSize: cfg.CacheSize,
+		entries:   make(map[string]*list.Element),
+		order:     list.New(),
+	}
+}
+
+// newMarkdown собирает goldmark со ссылками на пользователей и карточки. Сырой HTML
+// выводится как есть и вычищается политикой bluemonday: иначе goldmark выбрасывает
+// HTML-блок целиком, вместе с текстом после тега.
+func newMarkdown(mentionURL, cardURL string) goldmark.Markdown {
+	return goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(
+			parser.WithInlineParsers(
+				newReferenceParser(mentionURL, cardURL),
+			),
+			parser.WithASTTransformers(
+				util.Prioritized(referenceTransformer{}, referencePriority),
+			),
+		),
+		goldmark.WithRendererOptions(html.WithUnsafe()),
+	)
+}
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^(mention|card-ref)$`)).OnElements("a")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+// Render возвращает безопасный HTML для исходного текста. mentions сопоставляет
+// handle (в нижнем регистре) с ID пользователя; неизвестные упоминания остаются текстом.
+func (r *Renderer) Render(source string, mentions map[string]uint) string {
+	if source == "" {
+		return ""
+	}
+
+	key := cacheKey(source, mentions)
+	if html, ok := r.get(key); ok {
+		return html
+	}
+
+	pctx := parser.NewContext()
+	pctx.Set(mentionsKey, mentions)
+
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(source), &buf, parser.WithContext(pctx)); err != nil {
+		return r.policy.Sanitize(source)
+	}
+
+	html := r.policy.Sanitize(buf.String())
+	r.put(key, html)
+	return html
+}
+
+func cacheKey(source string, mentions map[string]uint) string {
+	h := sha256.New()
+	h.Write([]byte(source))
+
+	handles := make([]string, 0, len(mentions))
+	for handle := range mentions {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+	for _, handle := range handles {
+		fmt.Fprintf(h, "\x00%s=%d", handle, mentions[handle])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (r *Renderer) get(key string) (string, bool) {
+	if r.cacheSize <= 0 {
+		return "", false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	el, ok := r.entries[key]
+	if !ok {
+		return "", false
+	}
+	r.order.MoveToFront(el)
+	return el.Value.(*cacheEntry).html, true
+}
+
+func (r *Renderer) put(key, html string) {
+	if r.cacheSize <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if el, ok := r.entries[key]; ok {
+		r.order.MoveToFront(el)
+		return
+	}
+
+	r.entries[key] = r.order.PushFront(&cacheEntry{key: key, html: html})
+	for r.order.Len() > r.cacheSize {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// Handle нормализует имя пользователя для поиска в карте упоминаний.
+func Handle(handle string) string {
+	return strings.ToLower(handle)
+}
@@ -0,0 +1,180 @@
+package markdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	r := NewRenderer(Config{})
+	mentions := map[string]uint{"alice": 1, "bob@example.com": 2}
+
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "mention",
+			source: "ping @alice.",
+			want:   `<p>ping <a href="/users/1" class="mention" rel="nofollow">@alice</a>.</p>`,
+		},
+		{
+			name:   "mention by email",
+			source: "cc @bob@example.com",
+			want:   `<p>cc <a href="/users/2" class="mention" rel="nofollow">@bob@example.com</a></p>`,
+		},
+		{
+			name:   "unknown mention",
+			source: "ping @carol",
+			want:   `<p>ping @carol</p>`,
+		},
+		{
+			name:   "email is not a mention",
+			source: "write to x@alice",
+			want:   `<p>write to x@alice</p>`,
+		},
+		{
+			name:   "card reference",
+			source: "see #12",
+			want:   `<p>see <a href="/cards/12" class="card-ref" rel="nofollow">#12</a></p>`,
+		},
+		{
+			name:   "mention as link text",
+			source: "[@alice](https://example.com)",
+			want:   `<p><a href="https://example.com" rel="nofollow">@alice</a></p>`,
+		},
+		{
+			name:   "card reference as link text",
+			source: "see [card #12](https://example.com) and @alice",
+			want:   `<p>see <a href="https://example.com" rel="nofollow">card #12</a> and <a href="/users/1" class="mention" rel="nofollow">@alice</a></p>`,
+		},
+		{
+			name:   "mention in brackets",
+			source: "[@alice]",
+			want:   `<p>[<a href="/users/1" class="mention" rel="nofollow">@alice</a>]</p>`,
+		},
+		{
+			name:   "code span",
+			source: "`@alice #12`",
+			want:   `<p><code>@alice #12</code></p>`,
+		},
+		{
+			name:   "fenced code",
+			source: "```\n@alice #12\n```",
+			want:   "<pre><code>@alice #12\n</code></pre>",
+		},
+		{
+			name:   "paragraph starting with inline HTML",
+			source: "<b>note</b> for @alice",
+			want:   `<p><b>note</b> for <a href="/users/1" class="mention" rel="nofollow">@alice</a></p>`,
+		},
+		{
+			name:   "HTML block keeps the text after the tag",
+			source: "<div>note</div> keep this",
+			want:   `<div>note</div> keep this`,
+		},
+		{
+			name:   "comment keeps the text after it",
+			source: "<!-- hidden --> visible",
+			want:   `visible`,
+		},
+		{
+			name:   "script is removed",
+			source: "<script>alert(1)</script>\n\ntext",
+			want:   `<p>text</p>`,
+		},
+		{
+			name:   "event handlers are removed",
+			source: `<img src="https://example.com/a.png" onerror="alert(1)">`,
+			want:   `<img src="https://example.com/a.png">`,
+		},
+		{
+			name:   "javascript links are removed",
+			source: "[x](javascript:alert(1))",
+			want:   `<p>x</p>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.TrimSpace(r.Render(tt.source, mentions))
+			if got != tt.want {
+				t.Errorf("Render(%q)\n got: %s\nwant: %s", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderCache(t *testing.T) {
+	r := NewRenderer(Config{CacheSize: 1})
+
+	first := r.Render("@alice", map[string]uint{"alice": 1})
+	if got := r.Render("@alice", map[string]uint{"alice": 2}); got == first {
+		t.Errorf("Render reused %q for different mentions", got)
+	}
+	if got := r.Render("@alice", map[string]uint{"alice": 1}); got != first {
+		t.Errorf("Render = %q after eviction, want %q", got, first)
+	}
+	if len(r.entries) != 1 || r.order.Len() != 1 {
+		t.Errorf("cache holds %d entries, want 1", len(r.entries))
+	}
+}
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []Mention
+	}{
+		{
+			name:   "handles and emails",
+			source: "@alice and @bob@example.com.",
+			want:   []Mention{{Handle: "alice", Start: 0, End: 6}, {Handle: "bob@example.com", Start: 11, End: 27}},
+		},
+		{
+			name:   "trailing punctuation",
+			source: "thanks @alice.-",
+			want:   []Mention{{Handle: "alice", Start: 7, End: 13}},
+		},
+		{
+			name:   "email address",
+			source: "mail x@alice",
+		},
+		{
+			name:   "code span",
+			source: "run `@alice` then ask @bob",
+			want:   []Mention{{Handle: "bob", Start: 22, End: 26}},
+		},
+		{
+			name:   "fenced code",
+			source: "```\n@alice\n```\n@bob",
+			want:   []Mention{{Handle: "bob", Start: 15, End: 19}},
+		},
+		{
+			name:   "indented code",
+			source: "    @alice",
+		},
+		{
+			name:   "link text",
+			source: "[@alice](https://example.com) @bob",
+			want:   []Mention{{Handle: "bob", Start: 30, End: 34}},
+		},
+		{
+			name:   "image alt",
+			source: "![@alice](https://example.com/a.png)",
+		},
+		{
+			name:   "offsets in bytes",
+			source: "привет @alice",
+			want:   []Mention{{Handle: "alice", Start: 13, End: 19}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mentions(tt.source); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mentions(%q) = %+v, want %+v", tt.source, got, tt.want)
+			}
+		})
+	}
+}
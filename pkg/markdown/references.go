@@ -0,0 +1,183 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+var (
+	mentionsKey   = parser.NewContextKey()
+	referencesKey = parser.NewContextKey()
+	// collectKey включает режим Mentions: упоминанием считается любой @handle.
+	collectKey = parser.NewContextKey()
+)
+
+var (
+	mentionRef = regexp.MustCompile(`^@([\w.+-]+(?:@[\w-]+(?:\.[\w-]+)+)?)`)
+	cardRef    = regexp.MustCompile(`^#(\d+)\b`)
+)
+
+// referenceParser превращает @handle в ссылку на пользователя, а #123 — в ссылку на карточку.
+type referenceParser struct {
+	mentionURL string
+	cardURL    string
+}
+
+// referencePriority выше приоритета linkify, чтобы @user@example.com не превращался в mailto-ссылку.
+const referencePriority = 50
+
+func newReferenceParser(mentionURL, cardURL string) util.PrioritizedValue {
+	return util.Prioritized(&referenceParser{mentionURL: mentionURL, cardURL: cardURL}, referencePriority)
+}
+
+func (p *referenceParser) Trigger() []byte {
+	return []byte{'@', '#'}
+}
+
+func (p *referenceParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	if prev := block.PrecendingCharacter(); unicode.IsLetter(prev) || unicode.IsDigit(prev) || prev == '.' || prev == '@' || prev == '_' {
+		return nil
+	}
+
+	line, segment := block.PeekLine()
+
+	var href, class, handle string
+	var length int
+	switch line[0] {
+	case '@':
+		m := mentionRef.FindSubmatch(line)
+		if m == nil {
+			return nil
+		}
+		handle = strings.TrimRight(string(m[1]), ".-")
+		if handle == "" {
+			return nil
+		}
+		if pc.Get(collectKey) == nil {
+			mentions, _ := pc.Get(mentionsKey).(map[string]uint)
+			userID, ok := mentions[Handle(handle)]
+			if !ok {
+				return nil
+			}
+			href = fmt.Sprintf(p.mentionURL, userID)
+		}
+		class = "mention"
+		length = len(handle) + 1
+	case '#':
+		m := cardRef.FindSubmatch(line)
+		if m == nil {
+			return nil
+		}
+		cardID, err := strconv.ParseUint(string(m[1]), 10, 32)
+		if err != nil {
+			return nil
+		}
+		href = fmt.Sprintf(p.cardURL, cardID)
+		class = "card-ref"
+		length = len(m[0])
+	default:
+		return nil
+	}
+
+	ref := &reference{destination: []byte(href), class: class, handle: handle, start: segment.Start}
+	ref.AppendChild(ref, ast.NewTextSegment(text.NewSegment(segment.Start, segment.Start+length)))
+	refs, _ := pc.Get(referencesKey).([]*reference)
+	pc.Set(referencesKey, append(refs, ref))
+	block.Advance(length)
+	return ref
+}
+
+var kindReference = ast.NewNodeKind("Reference")
+
+// reference — найденная ссылка на пользователя или карточку. До конца разбора это не *ast.Link:
+// goldmark не собирает ссылку, в тексте которой уже есть *ast.Link, и [@alice](https://x)
+// превращался бы в текст. referenceTransformer заменяет ее на ссылку или на обычный текст.
+type reference struct {
+	ast.BaseInline
+	destination []byte
+	class       string
+	// handle — имя из упоминания; для ссылок на карточки пустое.
+	handle string
+	// start — смещение ссылки в исходном тексте.
+	start int
+}
+
+func (n *reference) Kind() ast.NodeKind { return kindReference }
+
+func (n *reference) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Class": n.class}, nil)
+}
+
+// referenceTransformer превращает найденные ссылки в *ast.Link, а внутри текста другой ссылки
+// или подписи картинки оставляет их текстом: вложенные ссылки в HTML недопустимы.
+type referenceTransformer struct{}
+
+func (referenceTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	refs, _ := pc.Get(referencesKey).([]*reference)
+	kept := refs[:0]
+	for _, ref := range refs {
+		parent := ref.Parent()
+		if parent == nil {
+			// Другие парсеры могли убрать узел из дерева.
+			continue
+		}
+		if insideLink(ref) {
+			ast.MergeOrReplaceTextSegment(parent, ref, ref.FirstChild().(*ast.Text).Segment)
+			continue
+		}
+
+		link := ast.NewLink()
+		link.Destination = ref.destination
+		link.SetAttributeString("class", []byte(ref.class))
+		link.AppendChild(link, ref.FirstChild())
+		parent.ReplaceChild(parent, ref, link)
+		kept = append(kept, ref)
+	}
+	pc.Set(referencesKey, kept)
+}
+
+func insideLink(n ast.Node) bool {
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		switch p.(type) {
+		case *ast.Link, *ast.Image:
+			return true
+		}
+	}
+	return false
+}
+
+// Mention — упоминание @handle в исходном тексте; Start и End — смещения в байтах,
+// включая символ @.
+type Mention struct {
+	Handle string
+	Start  int
+	End    int
+}
+
+var mentionParser = newMarkdown("", "").Parser()
+
+// Mentions возвращает упоминания в тех местах текста, где Render показывает их ссылками:
+// без кода и текста других ссылок.
+func Mentions(source string) []Mention {
+	pc := parser.NewContext()
+	pc.Set(collectKey, true)
+	mentionParser.Parse(text.NewReader([]byte(source)), parser.WithContext(pc))
+
+	refs, _ := pc.Get(referencesKey).([]*reference)
+	var mentions []Mention
+	for _, ref := range refs {
+		if ref.handle == "" {
+			continue
+		}
+		mentions = append(mentions, Mention{Handle: ref.handle, Start: ref.start, End: ref.start + len(ref.handle) + 1})
+	}
+	return mentions
+}
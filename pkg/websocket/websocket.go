@@ -0,0 +1,430 @@
+// Package websocket — серверная реализация протокола WebSocket (RFC 6455) без расширений.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+
+	continuationFrame = 0
+)
+
+const (
+	CloseNormalClosure    = 1000
+	CloseGoingAway        = 1001
+	CloseProtocolError    = 1002
+	CloseUnsupportedData  = 1003
+	CloseNoStatusReceived = 1005
+	CloseInvalidPayload   = 1007
+	ClosePolicyViolation  = 1008
+	CloseMessageTooBig    = 1009
+	CloseTryAgainLater    = 1013
+)
+
+const (
+	acceptGUID        = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	maxControlPayload = 125
+	defaultReadLimit  = 64 << 10
+	closeReplyTimeout = time.Second
+)
+
+var (
+	ErrBadHandshake = errors.New("websocket: bad handshake")
+	ErrBadOrigin    = errors.New("websocket: origin not allowed")
+	ErrCloseSent    = errors.New("websocket: close already sent")
+)
+
+// CloseError возвращается из ReadMessage, когда клиент закрыл соединение.
+type CloseError struct {
+	Code int
+	Text string
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("websocket: close %d %s", e.Code, e.Text)
+}
+
+type Conn struct {
+	conn net.Conn
+	br   *bufio.Reader
+
+	readLimit   int64
+	pongHandler func()
+
+	wmu           sync.Mutex
+	writeDeadline time.Time
+	closeSent     bool
+}
+
+// Upgrader переключает HTTP-соединения на протокол WebSocket.
+type Upgrader struct {
+	// CheckOrigin решает, можно ли открыть соединение со страницы из заголовка Origin.
+	// Браузер передает токен доступа и cookie при подключении с любого сайта, поэтому
+	// чужие страницы нужно отклонять. По умолчанию используется SameOrigin.
+	CheckOrigin func(r *http.Request) bool
+}
+
+// Upgrade переключает соединение с проверкой Origin по умолчанию.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	var u Upgrader
+	return u.Upgrade(w, r)
+}
+
+// SameOrigin разрешает запросы без заголовка Origin (не из браузера) и запросы со страниц
+// того же хоста, к которому обращается клиент.
+func SameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// AllowOrigins разрешает, помимо SameOrigin, страницы из origins вида "https://app.example.com".
+func AllowOrigins(origins ...string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		if SameOrigin(r) {
+			return true
+		}
+		origin := r.Header.Get("Origin")
+		for _, allowed := range origins {
+			if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Upgrade переключает HTTP-соединение на протокол WebSocket. При ошибке клиенту
+// отправляется ответ 400 (403 для чужого Origin), и соединение остается под управлением net/http.
+func (u *Upgrader) Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if r.Method != http.MethodGet ||
+		!headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		http.Error(w, "invalid Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+
+	checkOrigin := u.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = SameOrigin
+	}
+	if !checkOrigin(r) {
+		http.Error(w, "websocket origin not allowed", http.StatusForbidden)
+		return nil, ErrBadOrigin
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket is not supported", http.StatusInternalServerError)
+		return nil, errors.New("websocket: response does not implement http.Hijacker")
+	}
+
+	conn, brw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("websocket: hijack: %w", err)
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("websocket: writing handshake: %w", err)
+	}
+
+	return &Conn{
+		conn:      conn,
+		br:        brw.Reader,
+		readLimit: defaultReadLimit,
+	}, nil
+}
+
+func acceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *Conn) SetReadLimit(limit int64) {
+	c.readLimit = limit
+}
+
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline задает дедлайн для WriteMessage. Управляющие кадры используют собственный дедлайн.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	c.writeDeadline = t
+	return c.conn.SetWriteDeadline(t)
+}
+
+// SetPongHandler задает функцию, вызываемую при получении pong от клиента.
+func (c *Conn) SetPongHandler(handler func()) {
+	c.pongHandler = handler
+}
+
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// ReadMessage возвращает следующее сообщение с данными. Ping и pong обрабатываются
+// внутри; при закрытии соединения клиентом возвращается *CloseError.
+// Вызывать ReadMessage можно только из одной горутины.
+func (c *Conn) ReadMessage() (int, []byte, error) {
+	var (
+		messageType int
+		message     []byte
+	)
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case PingMessage:
+			if err := c.WriteControl(PongMessage, payload, time.Now().Add(closeReplyTimeout)); err != nil && !errors.Is(err, ErrCloseSent) {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			if c.pongHandler != nil {
+				c.pongHandler()
+			}
+			continue
+		case CloseMessage:
+			return 0, nil, c.handleClose(payload)
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, c.fail(CloseProtocolError, "expected continuation frame")
+			}
+			messageType = opcode
+		case continuationFrame:
+			if messageType == 0 {
+				return 0, nil, c.fail(CloseProtocolError, "unexpected continuation frame")
+			}
+		default:
+			return 0, nil, c.fail(CloseProtocolError, "unknown opcode")
+		}
+
+		if int64(len(message)+len(payload)) > c.readLimit {
+			return 0, nil, c.fail(CloseMessageTooBig, "message too big")
+		}
+		message = append(message, payload...)
+
+		if fin {
+			if messageType == TextMessage && !utf8.Valid(message) {
+				return 0, nil, c.fail(CloseInvalidPayload, "invalid UTF-8")
+			}
+			return messageType, message, nil
+		}
+	}
+}
+
+func (c *Conn) readFrame() (bool, int, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := header[0]&0x80 != 0
+	opcode := int(header[0] & 0x0f)
+	masked := header[1]&0x80 != 0
+	length := int64(header[1] & 0x7f)
+
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, c.fail(CloseProtocolError, "reserved bits set")
+	}
+	if !masked {
+		return false, 0, nil, c.fail(CloseProtocolError, "client frames must be masked")
+	}
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]))
+	}
+
+	if opcode >= CloseMessage && (!fin || length > maxControlPayload) {
+		return false, 0, nil, c.fail(CloseProtocolError, "invalid control frame")
+	}
+	if length < 0 || length > c.readLimit {
+		return false, 0, nil, c.fail(CloseMessageTooBig, "message too big")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, opcode, payload, nil
+}
+
+func (c *Conn) handleClose(payload []byte) error {
+	closeErr := &CloseError{Code: CloseNoStatusReceived}
+	if len(payload) >= 2 {
+		closeErr.Code = int(binary.BigEndian.Uint16(payload))
+		closeErr.Text = string(payload[2:])
+	}
+
+	reply := CloseNormalClosure
+	if closeErr.Code != CloseNoStatusReceived {
+		reply = closeErr.Code
+	}
+	_ = c.WriteClose(reply, "")
+	return closeErr
+}
+
+// fail отправляет клиенту кадр закрытия с кодом ошибки и возвращает ошибку для ReadMessage.
+func (c *Conn) fail(code int, reason string) error {
+	_ = c.WriteClose(code, reason)
+	return &CloseError{Code: code, Text: reason}
+}
+
+// WriteMessage отправляет сообщение одним кадром. Безопасно для вызова из нескольких горутин.
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return fmt.Errorf("websocket: invalid message type %d", messageType)
+	}
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if c.closeSent {
+		return ErrCloseSent
+	}
+	return c.writeFrame(messageType, data)
+}
+
+// WriteControl отправляет управляющий кадр (ping, pong или close) с собственным дедлайном.
+func (c *Conn) WriteControl(messageType int, data []byte, deadline time.Time) error {
+	if messageType < CloseMessage {
+		return fmt.Errorf("websocket: invalid control message type %d", messageType)
+	}
+	if len(data) > maxControlPayload {
+		return errors.New("websocket: control frame payload too large")
+	}
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if c.closeSent {
+		return ErrCloseSent
+	}
+	if messageType == CloseMessage {
+		c.closeSent = true
+	}
+
+	_ = c.conn.SetWriteDeadline(deadline)
+	defer c.conn.SetWriteDeadline(c.writeDeadline)
+	return c.writeFrame(messageType, data)
+}
+
+// WriteClose отправляет кадр закрытия. После него можно только читать ответ клиента и закрыть соединение.
+func (c *Conn) WriteClose(code int, reason string) error {
+	if len(reason) > maxControlPayload-2 {
+		reason = reason[:maxControlPayload-2]
+	}
+
+	payload := make([]byte, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	copy(payload[2:], reason)
+
+	return c.WriteControl(CloseMessage, payload, time.Now().Add(closeReplyTimeout))
+}
+
+func (c *Conn) writeFrame(opcode int, data []byte) error {
+	header := make([]byte, 2, 10)
+	header[0] = 0x80 | byte(opcode)
+
+	switch length := len(data); {
+	case length <= 125:
+		header[1] = byte(length)
+	case length <= 0xffff:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(length))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(length))
+	}
+
+	if _, err := c.conn.Write(append(header, data...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,489 @@
+package websocket
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testKey = "dGhlIHNhbXBsZSBub25jZQ=="
+
+func TestAcceptKey(t *testing.T) {
+	// Пример из RFC 6455, раздел 1.3.
+	if got, want := acceptKey(testKey), "s3pPLMBiTxaQ9kYGzzhZRbK+xOo="; got != want {
+		t.Errorf("acceptKey(%q) = %q, want %q", testKey, got, want)
+	}
+}
+
+// echoServer отвечает на каждое сообщение им же и передает в errs ошибку, на которой
+// остановилось чтение.
+func echoServer(t *testing.T, upgrader *Upgrader, readLimit int64) (*httptest.Server, <-chan error) {
+	t.Helper()
+	errs := make(chan error, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r)
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer conn.Close()
+		if readLimit > 0 {
+			conn.SetReadLimit(readLimit)
+		}
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				errs <- err
+				return
+			}
+			if err := conn.WriteMessage(messageType, data); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, errs
+}
+
+type testClient struct {
+	t    *testing.T
+	conn net.Conn
+	br   *bufio.Reader
+}
+
+// dial выполняет рукопожатие с заголовками headers поверх стандартных и возвращает ответ сервера.
+func dial(t *testing.T, srv *httptest.Server, headers map[string]string) (*testClient, *http.Response) {
+	t.Helper()
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/ws", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", testKey)
+	for name, value := range headers {
+		if value == "" {
+			req.Header.Del(name)
+		} else {
+			req.Header.Set(name, value)
+		}
+	}
+	if err := req.Write(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testClient{t: t, conn: conn, br: br}, resp
+}
+
+func dialOK(t *testing.T, srv *httptest.Server) *testClient {
+	t.Helper()
+	client, resp := dial(t, srv, nil)
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake status = %d, want 101", resp.StatusCode)
+	}
+	return client
+}
+
+// writeFrame отправляет кадр клиента; masked=false нарушает протокол.
+func (c *testClient) writeFrame(fin bool, opcode int, payload []byte, masked bool) {
+	c.t.Helper()
+	var frame []byte
+	first := byte(opcode)
+	if fin {
+		first |= 0x80
+	}
+	frame = append(frame, first)
+
+	maskBit := byte(0)
+	if masked {
+		maskBit = 0x80
+	}
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, maskBit|byte(n))
+	case n <= 0xffff:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+
+	data := append([]byte(nil), payload...)
+	if masked {
+		mask := [4]byte{0x12, 0x34, 0x56, 0x78}
+		frame = append(frame, mask[:]...)
+		for i := range data {
+			data[i] ^= mask[i%4]
+		}
+	}
+	if _, err := c.conn.Write(append(frame, data...)); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// readFrame читает кадр сервера и проверяет, что он не маскирован.
+func (c *testClient) readFrame() (fin bool, opcode int, payload []byte) {
+	c.t.Helper()
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		c.t.Fatalf("reading frame: %v", err)
+	}
+	if header[1]&0x80 != 0 {
+		c.t.Fatal("server frame is masked")
+	}
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		io.ReadFull(c.br, ext[:])
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		io.ReadFull(c.br, ext[:])
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		c.t.Fatalf("reading payload: %v", err)
+	}
+	return header[0]&0x80 != 0, int(header[0] & 0x0f), payload
+}
+
+// expectClose ждет кадр закрытия с кодом code.
+func (c *testClient) expectClose(code int) {
+	c.t.Helper()
+	_, opcode, payload := c.readFrame()
+	if opcode != CloseMessage {
+		c.t.Fatalf("opcode = %d, want close", opcode)
+	}
+	if len(payload) < 2 {
+		c.t.Fatalf("close payload %q has no status code", payload)
+	}
+	if got := int(binary.BigEndian.Uint16(payload)); got != code {
+		c.t.Fatalf("close code = %d (%s), want %d", got, payload[2:], code)
+	}
+}
+
+func expectCloseError(t *testing.T, errs <-chan error, code int) {
+	t.Helper()
+	select {
+	case err := <-errs:
+		var closeErr *CloseError
+		if !errors.As(err, &closeErr) || closeErr.Code != code {
+			t.Fatalf("server error = %v, want close %d", err, code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop reading")
+	}
+}
+
+func TestUpgradeHandshake(t *testing.T) {
+	srv, _ := echoServer(t, &Upgrader{}, 0)
+	_, resp := dial(t, srv, map[string]string{"Connection": "keep-alive, Upgrade"})
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("status = %d, want 101", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != acceptKey(testKey) {
+		t.Errorf("Sec-WebSocket-Accept = %q, want %q", got, acceptKey(testKey))
+	}
+	if !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") {
+		t.Errorf("Upgrade = %q, want websocket", resp.Header.Get("Upgrade"))
+	}
+}
+
+func TestUpgradeRejectsBadHandshake(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+		want    error
+	}{
+		{name: "no upgrade header", headers: map[string]string{"Upgrade": ""}, status: http.StatusBadRequest, want: ErrBadHandshake},
+		{name: "no connection upgrade", headers: map[string]string{"Connection": "keep-alive"}, status: http.StatusBadRequest, want: ErrBadHandshake},
+		{name: "old version", headers: map[string]string{"Sec-WebSocket-Version": "8"}, status: http.StatusBadRequest, want: ErrBadHandshake},
+		{name: "short key", headers: map[string]string{"Sec-WebSocket-Key": "c2hvcnQ="}, status: http.StatusBadRequest, want: ErrBadHandshake},
+		{name: "cross-site origin", headers: map[string]string{"Origin": "https://evil.example"}, status: http.StatusForbidden, want: ErrBadOrigin},
+		{name: "malformed origin", headers: map[string]string{"Origin": "://"}, status: http.StatusForbidden, want: ErrBadOrigin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, errs := echoServer(t, &Upgrader{}, 0)
+			_, resp := dial(t, srv, tt.headers)
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if err := <-errs; !errors.Is(err, tt.want) {
+				t.Errorf("Upgrade error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpgradeOrigin(t *testing.T) {
+	tests := []struct {
+		name     string
+		upgrader *Upgrader
+		origin   string
+		allowed  bool
+	}{
+		{name: "no origin", upgrader: &Upgrader{}, origin: "", allowed: true},
+		{name: "same host", upgrader: &Upgrader{}, origin: "http://{host}", allowed: true},
+		{name: "same host other case", upgrader: &Upgrader{}, origin: "http://{HOST}", allowed: true},
+		{name: "other port", upgrader: &Upgrader{}, origin: "http://127.0.0.1:1", allowed: false},
+		{name: "other site", upgrader: &Upgrader{}, origin: "https://evil.example", allowed: false},
+		{
+			name:     "allowed site",
+			upgrader: &Upgrader{CheckOrigin: AllowOrigins("https://app.example.com/")},
+			origin:   "https://app.example.com",
+			allowed:  true,
+		},
+		{
+			name:     "allowed list keeps same origin",
+			upgrader: &Upgrader{CheckOrigin: AllowOrigins("https://app.example.com")},
+			origin:   "http://{host}",
+			allowed:  true,
+		},
+		{
+			name:     "site outside allowed list",
+			upgrader: &Upgrader{CheckOrigin: AllowOrigins("https://app.example.com")},
+			origin:   "https://app.example.com.evil.example",
+			allowed:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := echoServer(t, tt.upgrader, 0)
+			host := srv.Listener.Addr().String()
+			origin := strings.NewReplacer("{host}", host, "{HOST}", strings.ToUpper(host)).Replace(tt.origin)
+
+			_, resp := dial(t, srv, map[string]string{"Origin": origin})
+			if got := resp.StatusCode == http.StatusSwitchingProtocols; got != tt.allowed {
+				t.Errorf("Origin %q: status = %d, allowed = %v", origin, resp.StatusCode, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestEchoMessages(t *testing.T) {
+	srv, _ := echoServer(t, &Upgrader{}, 1<<20)
+	client := dialOK(t, srv)
+
+	tests := []struct {
+		name    string
+		opcode  int
+		payload []byte
+	}{
+		{name: "empty text", opcode: TextMessage, payload: nil},
+		{name: "text", opcode: TextMessage, payload: []byte(`{"type":"ping"}`)},
+		{name: "utf-8 text", opcode: TextMessage, payload: []byte("привет")},
+		{name: "binary", opcode: BinaryMessage, payload: []byte{0, 1, 2, 0xff}},
+		{name: "16-bit length", opcode: BinaryMessage, payload: bytes.Repeat([]byte{'a'}, 300)},
+		{name: "64-bit length", opcode: BinaryMessage, payload: bytes.Repeat([]byte{'b'}, 70000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.writeFrame(true, tt.opcode, tt.payload, true)
+			fin, opcode, payload := client.readFrame()
+			if !fin || opcode != tt.opcode || !bytes.Equal(payload, tt.payload) {
+				t.Errorf("echo = (fin %v, opcode %d, %d bytes), want (true, %d, %d bytes)",
+					fin, opcode, len(payload), tt.opcode, len(tt.payload))
+			}
+		})
+	}
+}
+
+func TestFragmentedMessage(t *testing.T) {
+	srv, _ := echoServer(t, &Upgrader{}, 0)
+	client := dialOK(t, srv)
+
+	// Управляющие кадры могут приходить между фрагментами сообщения.
+	client.writeFrame(false, TextMessage, []byte("hel"), true)
+	client.writeFrame(true, PingMessage, []byte("p"), true)
+	client.writeFrame(false, continuationFrame, []byte("lo, "), true)
+	client.writeFrame(true, continuationFrame, []byte("world"), true)
+
+	_, opcode, payload := client.readFrame()
+	if opcode != PongMessage || string(payload) != "p" {
+		t.Fatalf("got opcode %d %q, want pong \"p\"", opcode, payload)
+	}
+	_, opcode, payload = client.readFrame()
+	if opcode != TextMessage || string(payload) != "hello, world" {
+		t.Fatalf("got opcode %d %q, want text \"hello, world\"", opcode, payload)
+	}
+}
+
+func TestPongHandler(t *testing.T) {
+	pongs := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetPongHandler(func() { pongs <- struct{}{} })
+		_ = conn.WriteControl(PingMessage, []byte("hi"), time.Now().Add(time.Second))
+		conn.ReadMessage()
+	}))
+	defer srv.Close()
+
+	client := dialOK(t, srv)
+	_, opcode, payload := client.readFrame()
+	if opcode != PingMessage || string(payload) != "hi" {
+		t.Fatalf("got opcode %d %q, want ping \"hi\"", opcode, payload)
+	}
+	client.writeFrame(true, PongMessage, payload, true)
+
+	select {
+	case <-pongs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pong handler was not called")
+	}
+}
+
+func TestClientClose(t *testing.T) {
+	srv, errs := echoServer(t, &Upgrader{}, 0)
+	client := dialOK(t, srv)
+
+	payload := binary.BigEndian.AppendUint16(nil, CloseGoingAway)
+	client.writeFrame(true, CloseMessage, append(payload, "bye"...), true)
+
+	client.expectClose(CloseGoingAway)
+	select {
+	case err := <-errs:
+		var closeErr *CloseError
+		if !errors.As(err, &closeErr) || closeErr.Code != CloseGoingAway || closeErr.Text != "bye" {
+			t.Fatalf("server error = %v, want close 1001 bye", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop reading")
+	}
+}
+
+func TestClientCloseWithoutStatus(t *testing.T) {
+	srv, errs := echoServer(t, &Upgrader{}, 0)
+	client := dialOK(t, srv)
+
+	client.writeFrame(true, CloseMessage, nil, true)
+
+	client.expectClose(CloseNormalClosure)
+	expectCloseError(t, errs, CloseNoStatusReceived)
+}
+
+func TestProtocolViolations(t *testing.T) {
+	tests := []struct {
+		name  string
+		send  func(c *testClient)
+		code  int
+		limit int64
+	}{
+		{
+			name: "unmasked frame",
+			send: func(c *testClient) { c.writeFrame(true, TextMessage, []byte("hi"), false) },
+			code: CloseProtocolError,
+		},
+		{
+			name: "reserved bits",
+			send: func(c *testClient) { c.conn.Write([]byte{0x80 | 0x40 | TextMessage, 0x80, 0, 0, 0, 0}) },
+			code: CloseProtocolError,
+		},
+		{
+			name: "unknown opcode",
+			send: func(c *testClient) { c.writeFrame(true, 3, nil, true) },
+			code: CloseProtocolError,
+		},
+		{
+			name: "continuation without start",
+			send: func(c *testClient) { c.writeFrame(true, continuationFrame, []byte("x"), true) },
+			code: CloseProtocolError,
+		},
+		{
+			name: "new message inside fragmented one",
+			send: func(c *testClient) {
+				c.writeFrame(false, TextMessage, []byte("a"), true)
+				c.writeFrame(true, TextMessage, []byte("b"), true)
+			},
+			code: CloseProtocolError,
+		},
+		{
+			name: "fragmented control frame",
+			send: func(c *testClient) { c.writeFrame(false, PingMessage, nil, true) },
+			code: CloseProtocolError,
+		},
+		{
+			name: "control frame too long",
+			send: func(c *testClient) { c.writeFrame(true, PingMessage, make([]byte, 126), true) },
+			code: CloseProtocolError,
+		},
+		{
+			name: "invalid utf-8",
+			send: func(c *testClient) { c.writeFrame(true, TextMessage, []byte{0xff, 0xfe}, true) },
+			code: CloseInvalidPayload,
+		},
+		{
+			name:  "frame over read limit",
+			send:  func(c *testClient) { c.writeFrame(true, BinaryMessage, make([]byte, 20), true) },
+			code:  CloseMessageTooBig,
+			limit: 16,
+		},
+		{
+			name: "fragments over read limit",
+			send: func(c *testClient) {
+				c.writeFrame(false, BinaryMessage, make([]byte, 10), true)
+				c.writeFrame(true, continuationFrame, make([]byte, 10), true)
+			},
+			code:  CloseMessageTooBig,
+			limit: 16,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, errs := echoServer(t, &Upgrader{}, tt.limit)
+			client := dialOK(t, srv)
+
+			tt.send(client)
+			client.expectClose(tt.code)
+			expectCloseError(t, errs, tt.code)
+		})
+	}
+}
+
+func TestWriteAfterClose(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	conn := &Conn{conn: server, br: bufio.NewReader(server), readLimit: defaultReadLimit}
+	go io.Copy(io.Discard, client)
+
+	if err := conn.WriteClose(CloseNormalClosure, ""); err != nil {
+		t.Fatalf("WriteClose: %v", err)
+	}
+	if err := conn.WriteMessage(TextMessage, []byte("late")); !errors.Is(err, ErrCloseSent) {
+		t.Errorf("WriteMessage after close = %v, want ErrCloseSent", err)
+	}
+	if err := conn.WriteControl(PingMessage, nil, time.Now().Add(time.Second)); !errors.Is(err, ErrCloseSent) {
+		t.Errorf("WriteControl after close = %v, want ErrCloseSent", err)
+	}
+	if err := conn.WriteMessage(PingMessage, nil); err == nil {
+		t.Error("WriteMessage accepted a control message type")
+	}
+}